@@ -19,8 +19,8 @@ import (
 	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller"
 	ctrAdmission "github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/admission"
 	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/configschema"
+	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/utils"
 	"github.com/aerospike/aerospike-kubernetes-operator/version"
-	"github.com/aerospike/aerospike-management-lib/asconfig"
 
 	log "github.com/inconshreveable/log15"
 	"github.com/operator-framework/operator-sdk/pkg/k8sutil"
@@ -60,8 +60,17 @@ var (
 )
 
 const (
-	logLevelEnvVar   = "LOG_LEVEL"
-	syncPeriodEnvVar = "SYNC_PERIOD_SECOND"
+	logLevelEnvVar        = "LOG_LEVEL"
+	syncPeriodEnvVar      = "SYNC_PERIOD_SECOND"
+	configSchemaDirEnvVar = "CONFIG_SCHEMA_DIR"
+
+	// Directory user password and bearer token files are read from
+	passwordFileDirEnvVar = "PASSWORD_FILE_DIR"
+	// Comma separated hosts user passwords are read from over HTTP
+	passwordHTTPHostsEnvVar = "PASSWORD_HTTP_HOSTS"
+
+	// Interval to check the config schema dir for changes
+	configSchemaReloadInterval = time.Minute
 )
 
 var mgr ctrl.Manager
@@ -71,6 +80,7 @@ var SchemeGroupVersion = schema.GroupVersion{Group: aerospikev1alpha1.SchemeGrou
 func addKnownTypes(scheme *k8Runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&aerospikev1alpha1.AerospikeCluster{},
+		&aerospikev1alpha1.AerospikeClusterSnapshot{},
 	)
 	k8v1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
@@ -268,10 +278,27 @@ func main() {
 	}
 
 	logger.Info("Init aerospike-server config schemas")
-	asconfig.InitFromMap(configschema.SchemaMap)
+	schemaDir := os.Getenv(configSchemaDirEnvVar)
+	if err := configschema.Init(schemaDir); err != nil {
+		logger.Error("Failed to init aerospike-server config schemas", log.Ctx{"dir": schemaDir, "err": err})
+		os.Exit(1)
+	}
+	logger.Info("Supported aerospike-server versions", log.Ctx{"versions": configschema.SupportedVersions()})
+
+	logger.Info("Init user password sources")
+	passwordFileDir := os.Getenv(passwordFileDirEnvVar)
+	if err := utils.InitPasswordSources(passwordFileDir, os.Getenv(passwordHTTPHostsEnvVar)); err != nil {
+		logger.Error("Failed to init user password sources", log.Ctx{"dir": passwordFileDir, "err": err})
+		os.Exit(1)
+	}
+
+	stopCh := signals.SetupSignalHandler()
+	if schemaDir != "" {
+		go configschema.WatchSchemaDir(schemaDir, configSchemaReloadInterval, stopCh)
+	}
 
 	logger.Info("Start the Cmd")
-	if err := mgr.Start(signals.SetupSignalHandler()); err != nil {
+	if err := mgr.Start(stopCh); err != nil {
 		logger.Error("Manager exited non-zero", log.Ctx{"err": err})
 		os.Exit(1)
 	}
@@ -313,14 +340,21 @@ func setupWebhookServer(cfg *rest.Config, operatorNs string) error {
 	logger.Info("Add validating webhook handler")
 	validatingHook := &webhook.Admission{
 		Handler: admission.HandlerFunc(func(ctx context.Context, req webhook.AdmissionRequest) webhook.AdmissionResponse {
-			return ctrAdmission.ValidateAerospikeCluster(req)
+			return ctrAdmission.ValidateAerospikeCluster(mgr.GetAPIReader(), req)
+		}),
+	}
+
+	logger.Info("Add config template validating webhook handler")
+	configTemplateValidatingHook := &webhook.Admission{
+		Handler: admission.HandlerFunc(func(ctx context.Context, req webhook.AdmissionRequest) webhook.AdmissionResponse {
+			return ctrAdmission.ValidateAerospikeConfigTemplate(mgr.GetAPIReader(), req)
 		}),
 	}
 
 	logger.Info("Add mutation webhook handler")
 	mutatingHook := &webhook.Admission{
 		Handler: admission.HandlerFunc(func(ctx context.Context, req webhook.AdmissionRequest) webhook.AdmissionResponse {
-			return ctrAdmission.MutateAerospikeCluster(req)
+			return ctrAdmission.MutateAerospikeCluster(mgr.GetAPIReader(), req)
 		}),
 	}
 
@@ -335,6 +369,7 @@ func setupWebhookServer(cfg *rest.Config, operatorNs string) error {
 
 	hookServer.Register(ctrAdmission.AerospikeClusterValidationWebhookPath, validatingHook)
 	hookServer.Register(ctrAdmission.AerospikeClusterMutationWebhookPath, mutatingHook)
+	hookServer.Register(ctrAdmission.AerospikeConfigTemplateValidationWebhookPath, configTemplateValidatingHook)
 
 	logger.Info("Register validation webhook")
 	wh1 := ctrAdmission.NewValidatingAdmissionWebhook(operatorNs, mgr, mgr.GetClient())
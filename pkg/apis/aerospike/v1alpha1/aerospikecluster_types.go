@@ -3,9 +3,12 @@ package v1alpha1
 import (
 	"fmt"
 	"path/filepath"
+	"reflect"
 
 	lib "github.com/aerospike/aerospike-management-lib"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -39,10 +42,17 @@ type AerospikeClusterSpec struct {
 	Storage AerospikeStorageSpec `json:"storage,omitempty"`
 	// AerospikeConfigSecret has secret info created by user. User needs to create this secret having tls files, feature key for cluster
 	AerospikeConfigSecret AerospikeConfigSecretSpec `json:"aerospikeConfigSecret,omitempty"`
+	// CertManager configures cert-manager to issue the certificates of the network.tls names and the client certificate
+	// of the operator. The certificates are mounted in the pods and the pods are restarted when they are renewed.
+	CertManager *AerospikeCertManagerSpec `json:"certManager,omitempty"`
 	// AerospikeAccessControl has the Aerospike roles and users definitions. Required if aerospike cluster security is enabled.
 	AerospikeAccessControl *AerospikeAccessControlSpec `json:"aerospikeAccessControl,omitempty"`
-	// AerospikeConfig sets config in aerospike.conf file. Other configs are taken as default
-	AerospikeConfig Values `json:"aerospikeConfig"`
+	// AerospikeConfig sets config in aerospike.conf file. Other configs are taken as default.
+	// Required unless aerospikeConfigFile is given.
+	AerospikeConfig Values `json:"aerospikeConfig,omitempty"`
+	// AerospikeConfigFile references a ConfigMap having an aerospike.conf file. The file is parsed into aerospikeConfig,
+	// replacing the aerospikeConfig values, and is parsed again when the ConfigMap changes.
+	AerospikeConfigFile *AerospikeConfigFileSpec `json:"aerospikeConfigFile,omitempty"`
 	// Define resources requests and limits for Aerospike Server Container. Please contact aerospike for proper sizing exercise
 	// Only Memory and Cpu resources can be given
 	// Resources.Limits should be more than Resources.Requests.
@@ -53,8 +63,18 @@ type AerospikeClusterSpec struct {
 	RackConfig RackConfig `json:"rackConfig,omitempty"`
 	// AerospikeNetworkPolicy specifies how clients and tools access the Aerospike cluster.
 	AerospikeNetworkPolicy AerospikeNetworkPolicy `json:"aerospikeNetworkPolicy,omitempty"`
+	// KubernetesNetworkPolicy makes the operator create Kubernetes NetworkPolicies restricting the access to the Aerospike
+	// ports of the pods. Heartbeat and fabric are only allowed between the pods of the cluster.
+	KubernetesNetworkPolicy *AerospikeKubernetesNetworkPolicySpec `json:"kubernetesNetworkPolicy,omitempty"`
 	// Additional configuration for create Aerospike pods.
 	PodSpec AerospikePodSpec `json:"podSpec,omitempty"`
+	// RestoreFromSnapshot is the name of a completed AerospikeClusterSnapshot in the cluster namespace.
+	// The PVCs of a new cluster are provisioned from the volume snapshots of the pods with the same rack ID and pod ordinal.
+	// Cannot be updated.
+	RestoreFromSnapshot string `json:"restoreFromSnapshot,omitempty"`
+	// ConfigTemplates are the names of AerospikeConfigTemplates in the cluster namespace. Their aerospikeConfig, storage
+	// and podSpec are merged in order into the cluster spec, the values in the cluster spec take precedence.
+	ConfigTemplates []string `json:"configTemplates,omitempty"`
 }
 
 // AerospikePodSpec contain configuration for created Aeropsike cluster pods.
@@ -124,6 +144,10 @@ type ValidationPolicySpec struct {
 
 	// ValidateXdrDigestLogFile validates that xdr digest log file is mounted on a persistent file storage. Defaults to false.
 	SkipXdrDlogFileValidate bool `json:"skipXdrDlogFileValidate"`
+
+	// SkipSchedulingValidate skips validation that the kubernetes nodes can host the cluster pods, e.g. when nodes are
+	// added by a cluster autoscaler. Defaults to false.
+	SkipSchedulingValidate bool `json:"skipSchedulingValidate,omitempty"`
 }
 
 // DeepCopy implements deepcopy func for ValidationPolicy.
@@ -146,6 +170,14 @@ type AerospikeRoleSpec struct {
 	// Whitelist of host address allowed for this role.
 	// +listType=set
 	Whitelist []string `json:"whitelist,omitempty"`
+
+	// ReadQuota is the maximum reads per second allowed for this role. Zero means no quota.
+	// Needs server version 5.6 or later with security.enable-quotas set.
+	ReadQuota uint32 `json:"readQuota,omitempty"`
+
+	// WriteQuota is the maximum writes per second allowed for this role. Zero means no quota.
+	// Needs server version 5.6 or later with security.enable-quotas set.
+	WriteQuota uint32 `json:"writeQuota,omitempty"`
 }
 
 // DeepCopy implements deepcopy func for AerospikeRoleSpec
@@ -163,13 +195,68 @@ type AerospikeUserSpec struct {
 
 	// SecretName has secret info created by user. User needs to create this secret from password literal.
 	// eg: kubectl create secret generic dev-db-secret --from-literal=password='password'
-	SecretName string `json:"secretName"`
+	// Either secretName or passwordSource is required.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// SecretKey is the key of the password in the secret. Defaults to password.
+	// +optional
+	SecretKey string `json:"secretKey,omitempty"`
+
+	// PasswordSource is the source of the password when it is not in a secret in the cluster namespace.
+	// +optional
+	PasswordSource *AerospikePasswordSourceSpec `json:"passwordSource,omitempty"`
 
 	// Roles is the list of roles granted to the user.
 	// +listType=set
 	Roles []string `json:"roles"`
 }
 
+// AerospikePasswordSourceSpec is the source of a user password. Exactly one source is set.
+type AerospikePasswordSourceSpec struct {
+	// File is the path of a file with the password in the operator pod, for example written by a Vault agent or
+	// mounted by the secrets store CSI driver. The file has to be in the PASSWORD_FILE_DIR directory of the operator.
+	// +optional
+	File string `json:"file,omitempty"`
+
+	// Secret is a secret in another namespace. The secret has to allow the cluster namespace in its
+	// aerospike.com/allowed-namespaces annotation.
+	// +optional
+	Secret *AerospikePasswordSecretSource `json:"secret,omitempty"`
+
+	// HTTP is an HTTP endpoint returning the password. The host has to be in the PASSWORD_HTTP_HOSTS of the operator.
+	// +optional
+	HTTP *AerospikePasswordHTTPSource `json:"http,omitempty"`
+}
+
+// AerospikePasswordSecretSource is a secret with a password in another namespace.
+type AerospikePasswordSecretSource struct {
+	// Name is the name of the secret.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the secret.
+	Namespace string `json:"namespace"`
+
+	// Key is the key of the password in the secret. Defaults to password.
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// AerospikePasswordHTTPSource is an HTTP endpoint returning a password.
+type AerospikePasswordHTTPSource struct {
+	// URL is the endpoint. The password is the body of the response to a GET request, without the trailing newline.
+	URL string `json:"url"`
+
+	// JSONKey is the key of the password in a JSON object response body.
+	// +optional
+	JSONKey string `json:"jsonKey,omitempty"`
+
+	// BearerTokenFile is the path of a file in the operator pod with a bearer token to send with the request. The file
+	// has to be in the PASSWORD_FILE_DIR directory of the operator.
+	// +optional
+	BearerTokenFile string `json:"bearerTokenFile,omitempty"`
+}
+
 // DeepCopy implements deepcopy func for AerospikeUserSpec
 func (v *AerospikeUserSpec) DeepCopy() *AerospikeUserSpec {
 	src := *v
@@ -196,6 +283,11 @@ func (v *AerospikeClientAdminPolicy) DeepCopy() *AerospikeClientAdminPolicy {
 type AerospikeAccessControlSpec struct {
 	AdminPolicy *AerospikeClientAdminPolicy `json:"adminPolicy,omitempty"`
 
+	// Policy decides which users and roles not in the spec are dropped from the cluster. Authoritative drops all of
+	// them, ManagedOnly only the ones created by the operator. Defaults to Authoritative.
+	// +optional
+	Policy AerospikeAccessControlPolicy `json:"policy,omitempty"`
+
 	// Roles is the set of roles to allow on the Aerospike cluster.
 	// +patchMergeKey=name
 	// +patchStrategy=merge
@@ -209,8 +301,109 @@ type AerospikeAccessControlSpec struct {
 	// +listType=map
 	// +listMapKey=name
 	Users []AerospikeUserSpec `json:"users" patchStrategy:"merge" patchMergeKey:"name"`
+
+	// LDAP configures authentication of external users with LDAP. It is rendered into the security.ldap config. The
+	// operator keeps using the internal admin user for its own connections.
+	// +optional
+	LDAP *AerospikeLDAPSpec `json:"ldap,omitempty"`
 }
 
+// AerospikeLDAPSpec configures authentication of external users with LDAP.
+type AerospikeLDAPSpec struct {
+	// Servers are the LDAP server URLs, for example ldaps://ldap.example.com:636.
+	// +listType=atomic
+	Servers []string `json:"servers"`
+
+	// DisableTLS disables TLS on the connections to the LDAP servers.
+	// +optional
+	DisableTLS bool `json:"disableTLS,omitempty"`
+
+	// TLSCASecretName is the secret with the CA certificate of the LDAP servers.
+	// +optional
+	TLSCASecretName string `json:"tlsCASecretName,omitempty"`
+
+	// TLSCASecretKey is the key of the CA certificate in the TLSCASecretName secret. Defaults to ca.crt.
+	// +optional
+	TLSCASecretKey string `json:"tlsCASecretKey,omitempty"`
+
+	// QueryBaseDN is the base DN of the user queries.
+	QueryBaseDN string `json:"queryBaseDN"`
+
+	// QueryUserDN is the DN of the user binding to the LDAP servers to query users and roles.
+	// +optional
+	QueryUserDN string `json:"queryUserDN,omitempty"`
+
+	// QueryUserSecretName is the secret with the password of QueryUserDN.
+	// +optional
+	QueryUserSecretName string `json:"queryUserSecretName,omitempty"`
+
+	// QueryUserSecretKey is the key of the password in the QueryUserSecretName secret. Defaults to password.
+	// +optional
+	QueryUserSecretKey string `json:"queryUserSecretKey,omitempty"`
+
+	// UserDNPattern is the pattern to build the DN of a user from the username, for example
+	// uid=${un},ou=People,dc=example,dc=com. Either userDNPattern or userQueryPattern is required.
+	// +optional
+	UserDNPattern string `json:"userDNPattern,omitempty"`
+
+	// UserQueryPattern is the filter to query the DN of a user from the username, for example (uid=${un}).
+	// +optional
+	UserQueryPattern string `json:"userQueryPattern,omitempty"`
+
+	// RoleQueryBaseDN is the base DN of the role queries. Defaults to QueryBaseDN.
+	// +optional
+	RoleQueryBaseDN string `json:"roleQueryBaseDN,omitempty"`
+
+	// RoleQueryPatterns are the filters to query the groups of a user, for example (&(objectClass=posixGroup)(memberUid=${un})).
+	// The names of the groups are the roles of the user.
+	// +listType=atomic
+	RoleQueryPatterns []string `json:"roleQueryPatterns"`
+
+	// RoleQuerySearchOU also takes the organizational units of the user DN as roles of the user.
+	// +optional
+	RoleQuerySearchOU bool `json:"roleQuerySearchOU,omitempty"`
+
+	// PollingPeriod is the period in seconds to refresh the roles of the external users.
+	// +optional
+	PollingPeriod *int `json:"pollingPeriod,omitempty"`
+
+	// SessionTTL is the lifetime in seconds of the access tokens of the external users.
+	// +optional
+	SessionTTL *int `json:"sessionTTL,omitempty"`
+
+	// RoleMappings map LDAP groups to roles. A role named after the group is created with the privileges of the
+	// mapped roles.
+	// +patchMergeKey=group
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=group
+	RoleMappings []AerospikeLDAPRoleMapping `json:"roleMappings,omitempty" patchStrategy:"merge" patchMergeKey:"group"`
+}
+
+// AerospikeLDAPRoleMapping maps an LDAP group to roles.
+type AerospikeLDAPRoleMapping struct {
+	// Group is the name of the LDAP group.
+	Group string `json:"group"`
+
+	// Roles are the predefined or access control roles whose privileges are granted to the group.
+	// +listType=set
+	Roles []string `json:"roles"`
+}
+
+// AerospikeAccessControlPolicy decides which users and roles not in the access control spec are dropped.
+// +kubebuilder:validation:Enum=Authoritative;ManagedOnly
+// +k8s:openapi-gen=true
+type AerospikeAccessControlPolicy string
+
+const (
+	// AerospikeAccessControlPolicyAuthoritative drops all the users and roles not in the spec.
+	AerospikeAccessControlPolicyAuthoritative AerospikeAccessControlPolicy = "Authoritative"
+
+	// AerospikeAccessControlPolicyManagedOnly drops only the users and roles created by the operator that are not in
+	// the spec, and preserves the ones created outside the operator.
+	AerospikeAccessControlPolicyManagedOnly AerospikeAccessControlPolicy = "ManagedOnly"
+)
+
 // DeepCopy implements deepcopy func for AerospikeAccessControlSpec
 func (v *AerospikeAccessControlSpec) DeepCopy() *AerospikeAccessControlSpec {
 	src := *v
@@ -225,6 +418,48 @@ type AerospikeConfigSecretSpec struct {
 	MountPath  string `json:"mountPath"`
 }
 
+// AerospikeCertManagerSpec configures the cert-manager Certificates of the cluster.
+// +k8s:openapi-gen=true
+type AerospikeCertManagerSpec struct {
+	// IssuerRef is the cert-manager issuer of the network.tls certificates.
+	IssuerRef CertManagerIssuerRef `json:"issuerRef"`
+	// ClientIssuerRef is the cert-manager issuer of the operator client certificate. Defaults to issuerRef.
+	ClientIssuerRef *CertManagerIssuerRef `json:"clientIssuerRef,omitempty"`
+	// Duration is the requested duration of the certificates. Defaults to the cert-manager default.
+	Duration *metav1.Duration `json:"duration,omitempty"`
+	// RenewBefore is how long before expiry the certificates are renewed. Defaults to the cert-manager default.
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+}
+
+// CertManagerIssuerRef references a cert-manager Issuer in the cluster namespace or a ClusterIssuer.
+// +k8s:openapi-gen=true
+type CertManagerIssuerRef struct {
+	// Name of the issuer.
+	Name string `json:"name"`
+	// Kind of the issuer, Issuer or ClusterIssuer. Defaults to Issuer.
+	Kind string `json:"kind,omitempty"`
+	// Group of the issuer. Defaults to cert-manager.io.
+	Group string `json:"group,omitempty"`
+}
+
+// AerospikeKubernetesNetworkPolicySpec configures the Kubernetes NetworkPolicies of the cluster.
+type AerospikeKubernetesNetworkPolicySpec struct {
+	// ServiceFrom are the sources allowed to access the service and TLS service ports, selected by namespace, pod
+	// selectors or IP blocks. The service ports are open to all sources when empty.
+	ServiceFrom []networkingv1.NetworkPolicyPeer `json:"serviceFrom,omitempty"`
+	// OperatorFrom is the source of the operator access to the service, TLS service and info ports. Defaults to the pods
+	// in the operator namespace.
+	OperatorFrom *networkingv1.NetworkPolicyPeer `json:"operatorFrom,omitempty"`
+}
+
+// AerospikeConfigFileSpec references a ConfigMap having an aerospike.conf file.
+type AerospikeConfigFileSpec struct {
+	// ConfigMapName is the name of the ConfigMap in the cluster namespace.
+	ConfigMapName string `json:"configMapName"`
+	// Key is the ConfigMap key having the aerospike.conf file. Defaults to aerospike.conf.
+	Key string `json:"key,omitempty"`
+}
+
 // DeepCopy implements deepcopy func for Values
 func (v *AerospikeConfigSecretSpec) DeepCopy() *AerospikeConfigSecretSpec {
 	src := *v
@@ -233,8 +468,8 @@ func (v *AerospikeConfigSecretSpec) DeepCopy() *AerospikeConfigSecretSpec {
 	return &dst
 }
 
-// AerospikeVolumeMode specifies if the volume is a block/raw or filesystem.
-// +kubebuilder:validation:Enum=filesystem;block;configMap
+// AerospikeVolumeMode specifies if the volume is a block/raw or filesystem persistent volume or one of the non persistent volume sources.
+// +kubebuilder:validation:Enum=filesystem;block;configMap;emptyDir;secret;hostPath;projected
 // +k8s:openapi-gen=true
 type AerospikeVolumeMode string
 
@@ -247,6 +482,18 @@ const (
 
 	// AerospikeVolumeModeConfigMap specifies the volume is a k8s config map.
 	AerospikeVolumeModeConfigMap AerospikeVolumeMode = "configMap"
+
+	// AerospikeVolumeModeEmptyDir specifies the volume is a k8s emptyDir, backed by the node or by memory.
+	AerospikeVolumeModeEmptyDir AerospikeVolumeMode = "emptyDir"
+
+	// AerospikeVolumeModeSecret specifies the volume is a k8s secret.
+	AerospikeVolumeModeSecret AerospikeVolumeMode = "secret"
+
+	// AerospikeVolumeModeHostPath specifies the volume is a file or directory on the k8s node.
+	AerospikeVolumeModeHostPath AerospikeVolumeMode = "hostPath"
+
+	// AerospikeVolumeModeProjected specifies the volume is a k8s projection of several volume sources.
+	AerospikeVolumeModeProjected AerospikeVolumeMode = "projected"
 )
 
 // AerospikeVolumeInitMethod specifies how block volumes should be initialized.
@@ -268,6 +515,22 @@ const (
 	AerospikeVolumeInitMethodDeleteFiles AerospikeVolumeInitMethod = "deleteFiles"
 )
 
+// AerospikeVolumeRetentionPolicy specifies what happens to the persistent volume claims of a pod removed from the cluster.
+// +kubebuilder:validation:Enum=Delete;Retain;RetainForDuration
+// +k8s:openapi-gen=true
+type AerospikeVolumeRetentionPolicy string
+
+const (
+	// AerospikeVolumeRetentionPolicyDelete specifies the PVCs are deleted along with the pod.
+	AerospikeVolumeRetentionPolicyDelete AerospikeVolumeRetentionPolicy = "Delete"
+
+	// AerospikeVolumeRetentionPolicyRetain specifies the PVCs are retained until reattached, also after the cluster is deleted.
+	AerospikeVolumeRetentionPolicyRetain AerospikeVolumeRetentionPolicy = "Retain"
+
+	// AerospikeVolumeRetentionPolicyRetainForDuration specifies the PVCs are retained for the retention duration and then deleted. They are deleted with the cluster.
+	AerospikeVolumeRetentionPolicyRetainForDuration AerospikeVolumeRetentionPolicy = "RetainForDuration"
+)
+
 // AerospikePersistentVolumePolicySpec contains policies to manage persistent volumes.
 type AerospikePersistentVolumePolicySpec struct {
 	// InitMethod determines how volumes attached to Aerospike server pods are initialized when the pods comes up the first time. Defaults to "none".
@@ -276,11 +539,24 @@ type AerospikePersistentVolumePolicySpec struct {
 	// CascadeDelete determines if the persistent volumes are deleted after the pod this volume binds to is terminated and removed from the cluster.
 	InputCascadeDelete *bool `json:"cascadeDelete,omitempty"`
 
+	// RetentionPolicy determines what happens to the persistent volumes after the pod this volume binds to is removed from the cluster on scale down or rack removal.
+	// Retained volumes are reattached when the pod is added back. Defaults to "Delete" if cascadeDelete is true else "Retain".
+	InputRetentionPolicy *AerospikeVolumeRetentionPolicy `json:"retentionPolicy,omitempty"`
+
+	// RetentionDuration is the time for which volumes are retained with the "RetainForDuration" policy, e.g. 24h.
+	InputRetentionDuration *metav1.Duration `json:"retentionDuration,omitempty"`
+
 	// Effective/operative value to use as the volume init method after applying defaults.
 	InitMethod AerospikeVolumeInitMethod `json:"effectiveInitMethod,omitempty"`
 
 	// Effective/operative value to use for cascade delete after applying defaults.
 	CascadeDelete bool `json:"effectiveCascadeDelete"`
+
+	// Effective/operative value to use as the volume retention policy after applying defaults.
+	RetentionPolicy AerospikeVolumeRetentionPolicy `json:"effectiveRetentionPolicy,omitempty"`
+
+	// Effective/operative value to use as the volume retention duration after applying defaults.
+	RetentionDuration metav1.Duration `json:"effectiveRetentionDuration,omitempty"`
 }
 
 // SetDefaults applies default values to unset fields of the policy using corresponding fields from defaultPolicy
@@ -296,6 +572,43 @@ func (v *AerospikePersistentVolumePolicySpec) SetDefaults(defaultPolicy *Aerospi
 	} else {
 		v.CascadeDelete = *v.InputCascadeDelete
 	}
+
+	if v.InputRetentionPolicy != nil {
+		v.RetentionPolicy = *v.InputRetentionPolicy
+	} else if v.InputCascadeDelete != nil {
+		// Keep the behaviour of the older cascadeDelete only specs.
+		v.RetentionPolicy = retentionPolicyForCascadeDelete(v.CascadeDelete)
+	} else {
+		v.RetentionPolicy = defaultPolicy.RetentionPolicy
+	}
+
+	if v.InputRetentionDuration == nil {
+		v.RetentionDuration = defaultPolicy.RetentionDuration
+	} else {
+		v.RetentionDuration = *v.InputRetentionDuration
+	}
+
+	// Keep cascade delete in sync with the retention policy.
+	v.CascadeDelete = v.RetentionPolicy == AerospikeVolumeRetentionPolicyDelete
+}
+
+// validateRetentionPolicy validates the retention policy input against the cascade delete input.
+func (v *AerospikePersistentVolumePolicySpec) validateRetentionPolicy() error {
+	if v.InputRetentionPolicy != nil && v.InputCascadeDelete != nil && *v.InputCascadeDelete != (*v.InputRetentionPolicy == AerospikeVolumeRetentionPolicyDelete) {
+		return fmt.Errorf("CascadeDelete %v conflicts with retentionPolicy %s", *v.InputCascadeDelete, *v.InputRetentionPolicy)
+	}
+
+	if v.RetentionPolicy == AerospikeVolumeRetentionPolicyRetainForDuration && v.RetentionDuration.Duration <= 0 {
+		return fmt.Errorf("RetentionDuration should be positive for retentionPolicy %s", v.RetentionPolicy)
+	}
+	return nil
+}
+
+func retentionPolicyForCascadeDelete(cascadeDelete bool) AerospikeVolumeRetentionPolicy {
+	if cascadeDelete {
+		return AerospikeVolumeRetentionPolicyDelete
+	}
+	return AerospikeVolumeRetentionPolicyRetain
 }
 
 // DeepCopy implements deepcopy func for AerospikePersistentVolumePolicySpec.
@@ -318,14 +631,45 @@ type AerospikePersistentVolumeSpec struct {
 	// Name of the configmap for 'configmap' mode volumes.
 	ConfigMapName string `json:"configMap,omitempty"`
 
-	// StorageClass should be pre-created by user.
-	StorageClass string `json:"storageClass"`
+	// EmptyDir is the volume source for 'emptyDir' mode volumes. Use medium "Memory" for a tmpfs backed volume.
+	EmptyDir *corev1.EmptyDirVolumeSource `json:"emptyDir,omitempty"`
+
+	// Secret is the volume source for 'secret' mode volumes.
+	Secret *corev1.SecretVolumeSource `json:"secret,omitempty"`
+
+	// HostPath is the volume source for 'hostPath' mode volumes.
+	HostPath *corev1.HostPathVolumeSource `json:"hostPath,omitempty"`
+
+	// Projected is the volume source for 'projected' mode volumes.
+	Projected *corev1.ProjectedVolumeSource `json:"projected,omitempty"`
+
+	// StorageClass should be pre-created by user. Required for 'block' and 'filesystem' mode volumes.
+	StorageClass string `json:"storageClass,omitempty"`
 
 	// VolumeMode specifies if the volume is block/raw or a filesystem.
 	VolumeMode AerospikeVolumeMode `json:"volumeMode"`
 
 	// SizeInGB Size of volume in GB.
-	SizeInGB int32 `json:"sizeInGB"`
+	// Deprecated: Use size instead.
+	SizeInGB int32 `json:"sizeInGB,omitempty"`
+
+	// Size of the volume as a kubernetes quantity, e.g. 375Gi or 500Mi. Required for 'block' and 'filesystem' mode volumes unless sizeInGB is specified.
+	Size *resource.Quantity `json:"size,omitempty"`
+
+	// AccessModes of the persistent volume claim. Defaults to ReadWriteOnce.
+	AccessModes []corev1.PersistentVolumeAccessMode `json:"accessModes,omitempty"`
+
+	// Labels to add to the persistent volume claim.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations to add to the persistent volume claim.
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Selector is a label query over persistent volumes to consider for binding, e.g. to bind to pre-provisioned local volumes.
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// Sidecars lists the sidecar containers this volume is also mounted into.
+	Sidecars []AerospikeVolumeAttachment `json:"sidecars,omitempty"`
 }
 
 // DeepCopy implements deepcopy func for AerospikePersistentVolumeSpec.
@@ -338,7 +682,172 @@ func (v *AerospikePersistentVolumeSpec) DeepCopy() *AerospikePersistentVolumeSpe
 
 // IsSafeChange indicates if a change to a volume is safe to allow.
 func (v *AerospikePersistentVolumeSpec) IsSafeChange(new AerospikePersistentVolumeSpec) bool {
-	return v.Path == new.Path && v.StorageClass == new.StorageClass && v.VolumeMode == new.VolumeMode && v.SizeInGB == new.SizeInGB && v.ConfigMapName == new.ConfigMapName
+	oldSize := v.GetSize()
+	newSize := new.GetSize()
+
+	return v.Path == new.Path && v.StorageClass == new.StorageClass && v.VolumeMode == new.VolumeMode && oldSize.Cmp(newSize) == 0 && v.ConfigMapName == new.ConfigMapName &&
+		reflect.DeepEqual(v.EmptyDir, new.EmptyDir) && reflect.DeepEqual(v.Secret, new.Secret) && reflect.DeepEqual(v.HostPath, new.HostPath) && reflect.DeepEqual(v.Projected, new.Projected) &&
+		reflect.DeepEqual(v.GetAccessModes(), new.GetAccessModes()) && reflect.DeepEqual(v.Labels, new.Labels) && reflect.DeepEqual(v.Annotations, new.Annotations) && reflect.DeepEqual(v.Selector, new.Selector)
+}
+
+// GetSize returns the size of the volume, using sizeInGB if size is not specified.
+func (v *AerospikePersistentVolumeSpec) GetSize() resource.Quantity {
+	if v.Size != nil {
+		return *v.Size
+	}
+	return *resource.NewQuantity(int64(v.SizeInGB)*1024*1024*1024, resource.BinarySI)
+}
+
+// GetAccessModes returns the access modes of the volume claim, defaults to ReadWriteOnce.
+func (v *AerospikePersistentVolumeSpec) GetAccessModes() []corev1.PersistentVolumeAccessMode {
+	if len(v.AccessModes) == 0 {
+		return []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+	}
+	return v.AccessModes
+}
+
+// IsPersistentVolume indicates if the volume is backed by a persistent volume claim.
+func (v *AerospikePersistentVolumeSpec) IsPersistentVolume() bool {
+	return v.VolumeMode == AerospikeVolumeModeBlock || v.VolumeMode == AerospikeVolumeModeFilesystem
+}
+
+// GetVolumeSource returns the k8s volume source for non persistent volumes.
+func (v *AerospikePersistentVolumeSpec) GetVolumeSource() corev1.VolumeSource {
+	switch v.VolumeMode {
+	case AerospikeVolumeModeConfigMap:
+		return corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: v.ConfigMapName,
+				},
+			},
+		}
+	case AerospikeVolumeModeEmptyDir:
+		return corev1.VolumeSource{EmptyDir: v.EmptyDir}
+	case AerospikeVolumeModeSecret:
+		return corev1.VolumeSource{Secret: v.Secret}
+	case AerospikeVolumeModeHostPath:
+		return corev1.VolumeSource{HostPath: v.HostPath}
+	case AerospikeVolumeModeProjected:
+		return corev1.VolumeSource{Projected: v.Projected}
+	}
+	return corev1.VolumeSource{}
+}
+
+// validateVolumeSource validates that the volume has exactly the source required by its mode.
+func (v *AerospikePersistentVolumeSpec) validateVolumeSource() error {
+	if v.IsPersistentVolume() && v.StorageClass == "" {
+		return fmt.Errorf("Missing storage class")
+	}
+
+	if v.VolumeMode == AerospikeVolumeModeConfigMap && v.ConfigMapName == "" {
+		return fmt.Errorf("Missing config map name")
+	}
+
+	sources := []struct {
+		mode  AerospikeVolumeMode
+		isSet bool
+	}{
+		{AerospikeVolumeModeConfigMap, v.ConfigMapName != ""},
+		{AerospikeVolumeModeEmptyDir, v.EmptyDir != nil},
+		{AerospikeVolumeModeSecret, v.Secret != nil},
+		{AerospikeVolumeModeHostPath, v.HostPath != nil},
+		{AerospikeVolumeModeProjected, v.Projected != nil},
+	}
+
+	for _, source := range sources {
+		if source.mode == v.VolumeMode && !source.isSet {
+			return fmt.Errorf("Missing %s volume source for volume mode %s", source.mode, v.VolumeMode)
+		}
+		if source.mode != v.VolumeMode && source.isSet {
+			return fmt.Errorf("Volume source %s not allowed for volume mode %s", source.mode, v.VolumeMode)
+		}
+	}
+
+	if v.IsPersistentVolume() {
+		if err := v.validateClaim(); err != nil {
+			return err
+		}
+	} else if v.SizeInGB != 0 || v.Size != nil || len(v.AccessModes) != 0 || len(v.Labels) != 0 || len(v.Annotations) != 0 || v.Selector != nil {
+		return fmt.Errorf("Size, sizeInGB, accessModes, labels, annotations and selector not allowed for volume mode %s", v.VolumeMode)
+	}
+
+	attachedContainers := map[string]int{}
+	for _, attachment := range v.Sidecars {
+		if attachment.ContainerName == "" {
+			return fmt.Errorf("Missing sidecar container name")
+		}
+
+		if _, ok := attachedContainers[attachment.ContainerName]; ok {
+			return fmt.Errorf("Duplicate sidecar container %s", attachment.ContainerName)
+		}
+		attachedContainers[attachment.ContainerName] = 1
+
+		if attachment.Path != "" && !filepath.IsAbs(attachment.Path) {
+			return fmt.Errorf("Sidecar %s path should be absolute: %s", attachment.ContainerName, attachment.Path)
+		}
+
+		if v.VolumeMode == AerospikeVolumeModeBlock && (attachment.SubPath != "" || attachment.ReadOnly) {
+			return fmt.Errorf("SubPath and readOnly not allowed for block volume in sidecar %s", attachment.ContainerName)
+		}
+	}
+	return nil
+}
+
+// validateClaim validates the persistent volume claim fields of the volume.
+func (v *AerospikePersistentVolumeSpec) validateClaim() error {
+	if v.Size != nil && v.SizeInGB != 0 {
+		return fmt.Errorf("Only one of size and sizeInGB can be specified")
+	}
+
+	if size := v.GetSize(); size.Sign() <= 0 {
+		return fmt.Errorf("Volume size should be positive")
+	}
+
+	for _, accessMode := range v.AccessModes {
+		if accessMode != corev1.ReadWriteOnce && accessMode != corev1.ReadOnlyMany && accessMode != corev1.ReadWriteMany {
+			return fmt.Errorf("Invalid access mode %s", accessMode)
+		}
+	}
+
+	if v.Selector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(v.Selector); err != nil {
+			return fmt.Errorf("Invalid selector: %v", err)
+		}
+	}
+	return nil
+}
+
+// AerospikeVolumeAttachment describes how a volume is mounted into a sidecar container.
+// +k8s:openapi-gen=true
+type AerospikeVolumeAttachment struct {
+	// ContainerName is the name of the sidecar container to mount the volume into.
+	ContainerName string `json:"containerName"`
+
+	// Path is the device path or the mount path of the volume in the sidecar. Defaults to the volume path.
+	Path string `json:"path,omitempty"`
+
+	// SubPath is the path within the volume to mount. Not allowed for 'block' mode volumes.
+	SubPath string `json:"subPath,omitempty"`
+
+	// ReadOnly mounts the volume read-only in the sidecar. Not allowed for 'block' mode volumes.
+	ReadOnly bool `json:"readOnly,omitempty"`
+}
+
+// GetPath returns the path of the volume in the sidecar.
+func (v *AerospikeVolumeAttachment) GetPath(volume AerospikePersistentVolumeSpec) string {
+	if v.Path == "" {
+		return volume.Path
+	}
+	return v.Path
+}
+
+// DeepCopy implements deepcopy func for AerospikeVolumeAttachment.
+func (v *AerospikeVolumeAttachment) DeepCopy() *AerospikeVolumeAttachment {
+	src := *v
+	var dst = AerospikeVolumeAttachment{}
+	lib.DeepCopy(dst, src)
+	return &dst
 }
 
 // AerospikeStorageSpec lists persistent volumes to claim and attach to Aerospike pods and persistence policies.
@@ -394,7 +903,7 @@ func (v *AerospikeStorageSpec) validateAddedOrRemovedVolumes(new AerospikeStorag
 		}
 
 		if !matched {
-			if newVolume.VolumeMode != AerospikeVolumeModeConfigMap {
+			if newVolume.IsPersistentVolume() {
 				return []AerospikePersistentVolumeSpec{}, []AerospikePersistentVolumeSpec{}, fmt.Errorf("Cannot add persistent volume: %v", newVolume)
 			}
 			addedVolumes = append(addedVolumes, newVolume)
@@ -410,7 +919,7 @@ func (v *AerospikeStorageSpec) validateAddedOrRemovedVolumes(new AerospikeStorag
 		}
 
 		if !matched {
-			if oldVolume.VolumeMode != AerospikeVolumeModeConfigMap {
+			if oldVolume.IsPersistentVolume() {
 				return []AerospikePersistentVolumeSpec{}, []AerospikePersistentVolumeSpec{}, fmt.Errorf("Cannot remove persistent volume: %v", oldVolume)
 			}
 			removedVolumes = append(removedVolumes, oldVolume)
@@ -426,9 +935,11 @@ func (v *AerospikeStorageSpec) SetDefaults() {
 	defaultBlockInitMethod := AerospikeVolumeInitMethodNone
 	defaultCascadeDelete := false
 
+	defaultRetentionPolicy := retentionPolicyForCascadeDelete(defaultCascadeDelete)
+
 	// Set storage level defaults.
-	v.FileSystemVolumePolicy.SetDefaults(&AerospikePersistentVolumePolicySpec{InitMethod: defaultFilesystemInitMethod, CascadeDelete: defaultCascadeDelete})
-	v.BlockVolumePolicy.SetDefaults(&AerospikePersistentVolumePolicySpec{InitMethod: defaultBlockInitMethod, CascadeDelete: defaultCascadeDelete})
+	v.FileSystemVolumePolicy.SetDefaults(&AerospikePersistentVolumePolicySpec{InitMethod: defaultFilesystemInitMethod, CascadeDelete: defaultCascadeDelete, RetentionPolicy: defaultRetentionPolicy})
+	v.BlockVolumePolicy.SetDefaults(&AerospikePersistentVolumePolicySpec{InitMethod: defaultBlockInitMethod, CascadeDelete: defaultCascadeDelete, RetentionPolicy: defaultRetentionPolicy})
 
 	for i := range v.Volumes {
 		// Use storage spec values as defaults for the volumes.
@@ -450,17 +961,25 @@ func (v *AerospikeStorageSpec) GetStorageList() (blockStorageDeviceList []string
 
 	storagePaths := map[string]int{}
 
+	if err := v.FileSystemVolumePolicy.validateRetentionPolicy(); err != nil {
+		return nil, nil, fmt.Errorf("Invalid filesystemVolumePolicy: %v", err)
+	}
+
+	if err := v.BlockVolumePolicy.validateRetentionPolicy(); err != nil {
+		return nil, nil, fmt.Errorf("Invalid blockVolumePolicy: %v", err)
+	}
+
 	for _, volume := range v.Volumes {
-		if volume.VolumeMode != AerospikeVolumeModeConfigMap && volume.StorageClass == "" {
-			return nil, nil, fmt.Errorf("Mising storage class. Invalid volume: %v", volume)
+		if err := volume.validateRetentionPolicy(); err != nil {
+			return nil, nil, fmt.Errorf("%v. Invalid volume: %v", err, volume)
 		}
 
-		if volume.VolumeMode == AerospikeVolumeModeConfigMap && volume.ConfigMapName == "" {
-			return nil, nil, fmt.Errorf("Mising config map name. Invalid volume: %v", volume)
+		if err := volume.validateVolumeSource(); err != nil {
+			return nil, nil, fmt.Errorf("%v. Invalid volume: %v", err, volume)
 		}
 
 		if volume.Path == "" {
-			return nil, nil, fmt.Errorf("Mising volume path. Invalid volume: %v", volume)
+			return nil, nil, fmt.Errorf("Missing volume path. Invalid volume: %v", volume)
 		}
 
 		if !filepath.IsAbs(volume.Path) {
@@ -489,6 +1008,9 @@ func (v *AerospikeStorageSpec) GetStorageList() (blockStorageDeviceList []string
 				return nil, nil, fmt.Errorf("Invalid init method %v for filesystem volume: %v2", volume.InitMethod, volume)
 			}
 
+			fileStorageList = append(fileStorageList, volume.Path)
+		} else if volume.VolumeMode == AerospikeVolumeModeEmptyDir || volume.VolumeMode == AerospikeVolumeModeHostPath {
+			// Writable non persistent directories can be used for namespace files, e.g. a memory backed emptyDir.
 			fileStorageList = append(fileStorageList, volume.Path)
 		}
 	}
@@ -505,6 +1027,18 @@ func (v *AerospikeStorageSpec) GetConfigMaps() (configMaps []AerospikePersistent
 	return configMaps, nil
 }
 
+// GetPodVolumes returns the non persistent volumes from the storage spec, i.e. volumes added to the pod spec
+// instead of the volume claim templates.
+func (v *AerospikeStorageSpec) GetPodVolumes() []AerospikePersistentVolumeSpec {
+	var podVolumes []AerospikePersistentVolumeSpec
+	for _, volume := range v.Volumes {
+		if !volume.IsPersistentVolume() {
+			podVolumes = append(podVolumes, volume)
+		}
+	}
+	return podVolumes
+}
+
 // DeepCopy implements deepcopy func for AerospikeStorageSpec.
 func (v *AerospikeStorageSpec) DeepCopy() *AerospikeStorageSpec {
 	src := *v
@@ -534,13 +1068,32 @@ type AerospikeClusterStatus struct {
 	// The current state of Aerospike cluster.
 	AerospikeClusterSpec
 
-	// Details about the current condition of the AerospikeCluster resource.
-	//Conditions []apiextensions.CustomResourceDefinitionCondition `json:"conditions"`
+	// Conditions has the latest observations of the state of the cluster.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []AerospikeClusterCondition `json:"conditions,omitempty"`
 
 	// Pods has Aerospike specific status of the pods. This is map instead of the conventional map as list convention to allow each pod to patch update its own status. The map key is the name of the pod.
 	// +patchStrategy=strategic
 	Pods map[string]AerospikePodStatus `json:"pods" patchStrategy:"strategic"`
 
+	// RetainedPVCs has the PVCs retained after their pods were removed from the cluster and the PVCs restored from a
+	// cluster snapshot till their pods are initialized. The map key is the name of the PVC.
+	RetainedPVCs map[string]AerospikeRetainedPVCStatus `json:"retainedPVCs,omitempty"`
+
+	// FeatureKey has the details of the feature key in the AerospikeConfigSecret.
+	FeatureKey *AerospikeFeatureKeyStatus `json:"featureKey,omitempty"`
+
+	// TLSCertificates has the details of the TLS certificates in the AerospikeConfigSecret.
+	TLSCertificates []AerospikeTLSCertificateStatus `json:"tlsCertificates,omitempty"`
+
+	// TLSCACertificates has the PEM encoded CA certificates of the operator client TLS name trusted by the operator. It
+	// has the CA certificates used before the last TLS files change till all the pods are rolled to the new files.
+	TLSCACertificates []string `json:"tlsCACertificates,omitempty"`
+
+	// ManagedAccessControl has the users and roles created by the operator.
+	ManagedAccessControl *AerospikeManagedAccessControlStatus `json:"managedAccessControl,omitempty"`
+
 	// TODO:
 	// Give asadm info
 	// Give pod specific summary
@@ -683,6 +1236,85 @@ func (v *AerospikePodStatus) DeepCopy() *AerospikePodStatus {
 	return &dst
 }
 
+// AerospikeRetainedPVCStatus contains the details of a PVC retained after its pod was removed from the cluster.
+// +k8s:openapi-gen=true
+type AerospikeRetainedPVCStatus struct {
+	// PodName is the name of the pod this PVC was attached to.
+	PodName string `json:"podName"`
+	// RackID of the rack the pod belonged to.
+	RackID int `json:"rackID"`
+	// Path is the storage path of the volume.
+	Path string `json:"path"`
+	// RetainedSince is the time the pod was removed and the PVC retained.
+	RetainedSince metav1.Time `json:"retainedSince"`
+	// ExpiryTime is the time after which the PVC is deleted. Not set if the PVC is retained until reattached.
+	ExpiryTime *metav1.Time `json:"expiryTime,omitempty"`
+}
+
+// DeepCopy implements deepcopy func for AerospikeRetainedPVCStatus
+func (v *AerospikeRetainedPVCStatus) DeepCopy() *AerospikeRetainedPVCStatus {
+	src := *v
+	var dst = AerospikeRetainedPVCStatus{}
+	lib.DeepCopy(dst, src)
+	return &dst
+}
+
+// AerospikeFeatureKeyStatus contains the details of the feature key used by the cluster.
+// +k8s:openapi-gen=true
+type AerospikeFeatureKeyStatus struct {
+	// SerialNumber of the feature key.
+	SerialNumber string `json:"serialNumber,omitempty"`
+	// ValidUntil is the date the feature key expires. Not set if the feature key does not expire.
+	ValidUntil *metav1.Time `json:"validUntil,omitempty"`
+}
+
+// AerospikeTLSCertificateStatus contains the details of a TLS certificate used by the cluster.
+// +k8s:openapi-gen=true
+type AerospikeTLSCertificateStatus struct {
+	// TLSName is the name of the network.tls config using the certificate.
+	TLSName string `json:"tlsName"`
+	// CertFile is the cert-file of the network.tls config.
+	CertFile string `json:"certFile"`
+	// NotAfter is the date the certificate expires.
+	NotAfter metav1.Time `json:"notAfter"`
+}
+
+// AerospikeClusterConditionType is the type of an AerospikeCluster condition.
+// +k8s:openapi-gen=true
+type AerospikeClusterConditionType string
+
+const (
+	// AerospikeClusterPodsSchedulable is false when a pod of the cluster cannot be scheduled, e.g. because no node has
+	// enough resources or matches the pod affinity. It is true once all the pods are ready.
+	AerospikeClusterPodsSchedulable AerospikeClusterConditionType = "PodsSchedulable"
+)
+
+// AerospikeClusterCondition contains the details of a condition of the cluster.
+// +k8s:openapi-gen=true
+type AerospikeClusterCondition struct {
+	// Type of the condition.
+	Type AerospikeClusterConditionType `json:"type"`
+	// Status of the condition, True, False or Unknown.
+	Status corev1.ConditionStatus `json:"status"`
+	// LastTransitionTime is the last time the condition changed status.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Reason is a brief reason of the last status change.
+	Reason string `json:"reason,omitempty"`
+	// Message has the details of the last status change.
+	Message string `json:"message,omitempty"`
+}
+
+// AerospikeManagedAccessControlStatus contains the users and roles created by the operator.
+// +k8s:openapi-gen=true
+type AerospikeManagedAccessControlStatus struct {
+	// Users are the names of the users created by the operator.
+	// +listType=set
+	Users []string `json:"users,omitempty"`
+	// Roles are the names of the roles created by the operator.
+	// +listType=set
+	Roles []string `json:"roles,omitempty"`
+}
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // AerospikeCluster is the Schema for the aerospikeclusters API
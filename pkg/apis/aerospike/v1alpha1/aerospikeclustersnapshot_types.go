@@ -0,0 +1,118 @@
+package v1alpha1
+
+import (
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AerospikeClusterSnapshotSpec defines the desired state of AerospikeClusterSnapshot
+// +k8s:openapi-gen=true
+type AerospikeClusterSnapshotSpec struct {
+	// ClusterName is the name of the AerospikeCluster to snapshot. The cluster should be in the namespace of the snapshot.
+	ClusterName string `json:"clusterName"`
+	// VolumeSnapshotClassName is the name of the CSI VolumeSnapshotClass used to create the volume snapshots.
+	// The default VolumeSnapshotClass is used if not specified.
+	VolumeSnapshotClassName string `json:"volumeSnapshotClassName,omitempty"`
+	// SkipQuiesce skips quiescing the pods of a rack before taking the volume snapshots of the rack.
+	// The snapshots are then only crash consistent. Racks are never quiesced for single rack or Community Edition clusters.
+	// Defaults to false.
+	SkipQuiesce bool `json:"skipQuiesce,omitempty"`
+}
+
+// AerospikeClusterSnapshotPhase is the phase of a cluster snapshot.
+// +kubebuilder:validation:Enum=InProgress;Completed;Failed
+// +k8s:openapi-gen=true
+type AerospikeClusterSnapshotPhase string
+
+const (
+	// AerospikeClusterSnapshotInProgress indicates the volume snapshots are being taken.
+	AerospikeClusterSnapshotInProgress AerospikeClusterSnapshotPhase = "InProgress"
+	// AerospikeClusterSnapshotCompleted indicates all volume snapshots are ready to use.
+	AerospikeClusterSnapshotCompleted AerospikeClusterSnapshotPhase = "Completed"
+	// AerospikeClusterSnapshotFailed indicates the snapshot cannot be completed.
+	AerospikeClusterSnapshotFailed AerospikeClusterSnapshotPhase = "Failed"
+)
+
+// AerospikeClusterSnapshotStatus defines the observed state of AerospikeClusterSnapshot
+// +k8s:openapi-gen=true
+type AerospikeClusterSnapshotStatus struct {
+	// Phase of the snapshot.
+	Phase AerospikeClusterSnapshotPhase `json:"phase,omitempty"`
+	// Message has the reason the snapshot failed.
+	Message string `json:"message,omitempty"`
+	// CompletedRacks are the IDs of the racks whose volume snapshots have been taken.
+	CompletedRacks []int `json:"completedRacks,omitempty"`
+	// CurrentRack is the ID of the rack whose volume snapshots are being cut.
+	CurrentRack *int `json:"currentRack,omitempty"`
+	// CurrentRackStartTime is the time the volume snapshots of the current rack were created.
+	CurrentRackStartTime *metav1.Time `json:"currentRackStartTime,omitempty"`
+	// VolumeSnapshots are the volume snapshots of the data PVCs of the cluster.
+	VolumeSnapshots []AerospikeVolumeSnapshotStatus `json:"volumeSnapshots,omitempty"`
+	// CompletionTime is the time all volume snapshots became ready to use.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// AerospikeVolumeSnapshotStatus contains the details of the VolumeSnapshot of a cluster PVC.
+// +k8s:openapi-gen=true
+type AerospikeVolumeSnapshotStatus struct {
+	// Name of the VolumeSnapshot.
+	Name string `json:"name"`
+	// PVCName is the name of the snapshotted PVC.
+	PVCName string `json:"pvcName"`
+	// PodName is the name of the pod the PVC is attached to.
+	PodName string `json:"podName"`
+	// RackID of the rack the pod belongs to.
+	RackID int `json:"rackID"`
+	// Path is the storage path of the volume.
+	Path string `json:"path"`
+	// ReadyToUse indicates if the VolumeSnapshot can be used to provision a PVC.
+	ReadyToUse bool `json:"readyToUse"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AerospikeClusterSnapshot is the Schema for the aerospikeclustersnapshots API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=aerospikeclustersnapshots,scope=Namespaced
+type AerospikeClusterSnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AerospikeClusterSnapshotSpec   `json:"spec,omitempty"`
+	Status AerospikeClusterSnapshotStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AerospikeClusterSnapshotList contains a list of AerospikeClusterSnapshot
+type AerospikeClusterSnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AerospikeClusterSnapshot `json:"items"`
+}
+
+// GetVolumeSnapshot returns the status of the volume snapshot of the volume at path of the pod with the given ordinal
+// in a rack, nil if not found.
+func (v *AerospikeClusterSnapshotStatus) GetVolumeSnapshot(rackID int, podOrdinal string, path string) *AerospikeVolumeSnapshotStatus {
+	for i := range v.VolumeSnapshots {
+		volumeSnapshot := &v.VolumeSnapshots[i]
+		if volumeSnapshot.RackID != rackID || volumeSnapshot.Path != path {
+			continue
+		}
+		if getPodOrdinal(volumeSnapshot.PodName) == podOrdinal {
+			return volumeSnapshot
+		}
+	}
+	return nil
+}
+
+// getPodOrdinal returns the ordinal of a statefulset pod from its name.
+func getPodOrdinal(podName string) string {
+	return podName[strings.LastIndex(podName, "-")+1:]
+}
+
+func init() {
+	SchemeBuilder.Register(&AerospikeClusterSnapshot{}, &AerospikeClusterSnapshotList{})
+}
@@ -0,0 +1,42 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AerospikeConfigTemplateSpec defines the desired state of AerospikeConfigTemplate
+// +k8s:openapi-gen=true
+type AerospikeConfigTemplateSpec struct {
+	// AerospikeConfig is a partial aerospike config. It is merged into the aerospikeConfig of the referencing clusters,
+	// the values in the cluster aerospikeConfig take precedence.
+	AerospikeConfig Values `json:"aerospikeConfig,omitempty"`
+	// Storage is used by the referencing clusters which do not have storage volumes.
+	Storage *AerospikeStorageSpec `json:"storage,omitempty"`
+	// PodSpec has the sidecars added to the referencing clusters which do not have a sidecar with the same name.
+	PodSpec *AerospikePodSpec `json:"podSpec,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AerospikeConfigTemplate is the Schema for the aerospikeconfigtemplates API
+// +k8s:openapi-gen=true
+// +kubebuilder:resource:path=aerospikeconfigtemplates,scope=Namespaced
+type AerospikeConfigTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec AerospikeConfigTemplateSpec `json:"spec,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AerospikeConfigTemplateList contains a list of AerospikeConfigTemplate
+type AerospikeConfigTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AerospikeConfigTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AerospikeConfigTemplate{}, &AerospikeConfigTemplateList{})
+}
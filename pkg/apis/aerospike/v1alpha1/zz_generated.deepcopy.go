@@ -6,6 +6,8 @@ package v1alpha1
 
 import (
 	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -16,6 +18,38 @@ func (in *AerospikeAccessControlSpec) DeepCopyInto(out *AerospikeAccessControlSp
 	return
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AerospikeCertManagerSpec) DeepCopyInto(out *AerospikeCertManagerSpec) {
+	*out = *in
+	out.IssuerRef = in.IssuerRef
+	if in.ClientIssuerRef != nil {
+		in, out := &in.ClientIssuerRef, &out.ClientIssuerRef
+		*out = new(CertManagerIssuerRef)
+		**out = **in
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AerospikeCertManagerSpec.
+func (in *AerospikeCertManagerSpec) DeepCopy() *AerospikeCertManagerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AerospikeCertManagerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AerospikeClientAdminPolicy) DeepCopyInto(out *AerospikeClientAdminPolicy) {
 	clone := in.DeepCopy()
@@ -51,6 +85,23 @@ func (in *AerospikeCluster) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AerospikeClusterCondition) DeepCopyInto(out *AerospikeClusterCondition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AerospikeClusterCondition.
+func (in *AerospikeClusterCondition) DeepCopy() *AerospikeClusterCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(AerospikeClusterCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AerospikeClusterList) DeepCopyInto(out *AerospikeClusterList) {
 	*out = *in
@@ -84,16 +135,142 @@ func (in *AerospikeClusterList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AerospikeClusterSnapshot) DeepCopyInto(out *AerospikeClusterSnapshot) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AerospikeClusterSnapshot.
+func (in *AerospikeClusterSnapshot) DeepCopy() *AerospikeClusterSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(AerospikeClusterSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AerospikeClusterSnapshot) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AerospikeClusterSnapshotList) DeepCopyInto(out *AerospikeClusterSnapshotList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AerospikeClusterSnapshot, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AerospikeClusterSnapshotList.
+func (in *AerospikeClusterSnapshotList) DeepCopy() *AerospikeClusterSnapshotList {
+	if in == nil {
+		return nil
+	}
+	out := new(AerospikeClusterSnapshotList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AerospikeClusterSnapshotList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AerospikeClusterSnapshotSpec) DeepCopyInto(out *AerospikeClusterSnapshotSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AerospikeClusterSnapshotSpec.
+func (in *AerospikeClusterSnapshotSpec) DeepCopy() *AerospikeClusterSnapshotSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AerospikeClusterSnapshotSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AerospikeClusterSnapshotStatus) DeepCopyInto(out *AerospikeClusterSnapshotStatus) {
+	*out = *in
+	if in.CompletedRacks != nil {
+		in, out := &in.CompletedRacks, &out.CompletedRacks
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.CurrentRack != nil {
+		in, out := &in.CurrentRack, &out.CurrentRack
+		*out = new(int)
+		**out = **in
+	}
+	if in.CurrentRackStartTime != nil {
+		in, out := &in.CurrentRackStartTime, &out.CurrentRackStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.VolumeSnapshots != nil {
+		in, out := &in.VolumeSnapshots, &out.VolumeSnapshots
+		*out = make([]AerospikeVolumeSnapshotStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AerospikeClusterSnapshotStatus.
+func (in *AerospikeClusterSnapshotStatus) DeepCopy() *AerospikeClusterSnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AerospikeClusterSnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AerospikeClusterSpec) DeepCopyInto(out *AerospikeClusterSpec) {
 	*out = *in
 	in.Storage.DeepCopyInto(&out.Storage)
 	in.AerospikeConfigSecret.DeepCopyInto(&out.AerospikeConfigSecret)
+	if in.CertManager != nil {
+		in, out := &in.CertManager, &out.CertManager
+		*out = new(AerospikeCertManagerSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.AerospikeAccessControl != nil {
 		in, out := &in.AerospikeAccessControl, &out.AerospikeAccessControl
 		*out = (*in).DeepCopy()
 	}
 	in.AerospikeConfig.DeepCopyInto(&out.AerospikeConfig)
+	if in.AerospikeConfigFile != nil {
+		in, out := &in.AerospikeConfigFile, &out.AerospikeConfigFile
+		*out = new(AerospikeConfigFileSpec)
+		**out = **in
+	}
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
 		*out = new(v1.ResourceRequirements)
@@ -105,7 +282,17 @@ func (in *AerospikeClusterSpec) DeepCopyInto(out *AerospikeClusterSpec) {
 	}
 	in.RackConfig.DeepCopyInto(&out.RackConfig)
 	in.AerospikeNetworkPolicy.DeepCopyInto(&out.AerospikeNetworkPolicy)
+	if in.KubernetesNetworkPolicy != nil {
+		in, out := &in.KubernetesNetworkPolicy, &out.KubernetesNetworkPolicy
+		*out = new(AerospikeKubernetesNetworkPolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.PodSpec.DeepCopyInto(&out.PodSpec)
+	if in.ConfigTemplates != nil {
+		in, out := &in.ConfigTemplates, &out.ConfigTemplates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -123,6 +310,13 @@ func (in *AerospikeClusterSpec) DeepCopy() *AerospikeClusterSpec {
 func (in *AerospikeClusterStatus) DeepCopyInto(out *AerospikeClusterStatus) {
 	*out = *in
 	in.AerospikeClusterSpec.DeepCopyInto(&out.AerospikeClusterSpec)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]AerospikeClusterCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Pods != nil {
 		in, out := &in.Pods, &out.Pods
 		*out = make(map[string]AerospikePodStatus, len(*in))
@@ -130,6 +324,35 @@ func (in *AerospikeClusterStatus) DeepCopyInto(out *AerospikeClusterStatus) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.RetainedPVCs != nil {
+		in, out := &in.RetainedPVCs, &out.RetainedPVCs
+		*out = make(map[string]AerospikeRetainedPVCStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.FeatureKey != nil {
+		in, out := &in.FeatureKey, &out.FeatureKey
+		*out = new(AerospikeFeatureKeyStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLSCertificates != nil {
+		in, out := &in.TLSCertificates, &out.TLSCertificates
+		*out = make([]AerospikeTLSCertificateStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TLSCACertificates != nil {
+		in, out := &in.TLSCACertificates, &out.TLSCACertificates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ManagedAccessControl != nil {
+		in, out := &in.ManagedAccessControl, &out.ManagedAccessControl
+		*out = new(AerospikeManagedAccessControlStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -143,6 +366,22 @@ func (in *AerospikeClusterStatus) DeepCopy() *AerospikeClusterStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AerospikeConfigFileSpec) DeepCopyInto(out *AerospikeConfigFileSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AerospikeConfigFileSpec.
+func (in *AerospikeConfigFileSpec) DeepCopy() *AerospikeConfigFileSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AerospikeConfigFileSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AerospikeConfigSecretSpec) DeepCopyInto(out *AerospikeConfigSecretSpec) {
 	clone := in.DeepCopy()
@@ -150,6 +389,112 @@ func (in *AerospikeConfigSecretSpec) DeepCopyInto(out *AerospikeConfigSecretSpec
 	return
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AerospikeConfigTemplate) DeepCopyInto(out *AerospikeConfigTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AerospikeConfigTemplate.
+func (in *AerospikeConfigTemplate) DeepCopy() *AerospikeConfigTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(AerospikeConfigTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AerospikeConfigTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AerospikeConfigTemplateList) DeepCopyInto(out *AerospikeConfigTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AerospikeConfigTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AerospikeConfigTemplateList.
+func (in *AerospikeConfigTemplateList) DeepCopy() *AerospikeConfigTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(AerospikeConfigTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AerospikeConfigTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AerospikeConfigTemplateSpec) DeepCopyInto(out *AerospikeConfigTemplateSpec) {
+	*out = *in
+	in.AerospikeConfig.DeepCopyInto(&out.AerospikeConfig)
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = (*in).DeepCopy()
+	}
+	if in.PodSpec != nil {
+		in, out := &in.PodSpec, &out.PodSpec
+		*out = new(AerospikePodSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AerospikeConfigTemplateSpec.
+func (in *AerospikeConfigTemplateSpec) DeepCopy() *AerospikeConfigTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AerospikeConfigTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AerospikeFeatureKeyStatus) DeepCopyInto(out *AerospikeFeatureKeyStatus) {
+	*out = *in
+	if in.ValidUntil != nil {
+		in, out := &in.ValidUntil, &out.ValidUntil
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AerospikeFeatureKeyStatus.
+func (in *AerospikeFeatureKeyStatus) DeepCopy() *AerospikeFeatureKeyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AerospikeFeatureKeyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AerospikeInstanceSummary) DeepCopyInto(out *AerospikeInstanceSummary) {
 	clone := in.DeepCopy()
@@ -157,6 +502,124 @@ func (in *AerospikeInstanceSummary) DeepCopyInto(out *AerospikeInstanceSummary)
 	return
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AerospikeKubernetesNetworkPolicySpec) DeepCopyInto(out *AerospikeKubernetesNetworkPolicySpec) {
+	*out = *in
+	if in.ServiceFrom != nil {
+		in, out := &in.ServiceFrom, &out.ServiceFrom
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OperatorFrom != nil {
+		in, out := &in.OperatorFrom, &out.OperatorFrom
+		*out = new(networkingv1.NetworkPolicyPeer)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AerospikeKubernetesNetworkPolicySpec.
+func (in *AerospikeKubernetesNetworkPolicySpec) DeepCopy() *AerospikeKubernetesNetworkPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AerospikeKubernetesNetworkPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AerospikeLDAPRoleMapping) DeepCopyInto(out *AerospikeLDAPRoleMapping) {
+	*out = *in
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AerospikeLDAPRoleMapping.
+func (in *AerospikeLDAPRoleMapping) DeepCopy() *AerospikeLDAPRoleMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(AerospikeLDAPRoleMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AerospikeLDAPSpec) DeepCopyInto(out *AerospikeLDAPSpec) {
+	*out = *in
+	if in.Servers != nil {
+		in, out := &in.Servers, &out.Servers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RoleQueryPatterns != nil {
+		in, out := &in.RoleQueryPatterns, &out.RoleQueryPatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PollingPeriod != nil {
+		in, out := &in.PollingPeriod, &out.PollingPeriod
+		*out = new(int)
+		**out = **in
+	}
+	if in.SessionTTL != nil {
+		in, out := &in.SessionTTL, &out.SessionTTL
+		*out = new(int)
+		**out = **in
+	}
+	if in.RoleMappings != nil {
+		in, out := &in.RoleMappings, &out.RoleMappings
+		*out = make([]AerospikeLDAPRoleMapping, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AerospikeLDAPSpec.
+func (in *AerospikeLDAPSpec) DeepCopy() *AerospikeLDAPSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AerospikeLDAPSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AerospikeManagedAccessControlStatus) DeepCopyInto(out *AerospikeManagedAccessControlStatus) {
+	*out = *in
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AerospikeManagedAccessControlStatus.
+func (in *AerospikeManagedAccessControlStatus) DeepCopy() *AerospikeManagedAccessControlStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AerospikeManagedAccessControlStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AerospikeNetworkPolicy) DeepCopyInto(out *AerospikeNetworkPolicy) {
 	clone := in.DeepCopy()
@@ -178,6 +641,64 @@ func (in *AerospikePersistentVolumeSpec) DeepCopyInto(out *AerospikePersistentVo
 	return
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AerospikePasswordHTTPSource) DeepCopyInto(out *AerospikePasswordHTTPSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AerospikePasswordHTTPSource.
+func (in *AerospikePasswordHTTPSource) DeepCopy() *AerospikePasswordHTTPSource {
+	if in == nil {
+		return nil
+	}
+	out := new(AerospikePasswordHTTPSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AerospikePasswordSecretSource) DeepCopyInto(out *AerospikePasswordSecretSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AerospikePasswordSecretSource.
+func (in *AerospikePasswordSecretSource) DeepCopy() *AerospikePasswordSecretSource {
+	if in == nil {
+		return nil
+	}
+	out := new(AerospikePasswordSecretSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AerospikePasswordSourceSpec) DeepCopyInto(out *AerospikePasswordSourceSpec) {
+	*out = *in
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(AerospikePasswordSecretSource)
+		**out = **in
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(AerospikePasswordHTTPSource)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AerospikePasswordSourceSpec.
+func (in *AerospikePasswordSourceSpec) DeepCopy() *AerospikePasswordSourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AerospikePasswordSourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AerospikePodSpec) DeepCopyInto(out *AerospikePodSpec) {
 	*out = *in
@@ -208,6 +729,13 @@ func (in *AerospikePodStatus) DeepCopyInto(out *AerospikePodStatus) {
 	return
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AerospikeRetainedPVCStatus) DeepCopyInto(out *AerospikeRetainedPVCStatus) {
+	clone := in.DeepCopy()
+	*out = *clone
+	return
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AerospikeRoleSpec) DeepCopyInto(out *AerospikeRoleSpec) {
 	clone := in.DeepCopy()
@@ -222,6 +750,23 @@ func (in *AerospikeStorageSpec) DeepCopyInto(out *AerospikeStorageSpec) {
 	return
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AerospikeTLSCertificateStatus) DeepCopyInto(out *AerospikeTLSCertificateStatus) {
+	*out = *in
+	in.NotAfter.DeepCopyInto(&out.NotAfter)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AerospikeTLSCertificateStatus.
+func (in *AerospikeTLSCertificateStatus) DeepCopy() *AerospikeTLSCertificateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AerospikeTLSCertificateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AerospikeUserSpec) DeepCopyInto(out *AerospikeUserSpec) {
 	clone := in.DeepCopy()
@@ -229,6 +774,22 @@ func (in *AerospikeUserSpec) DeepCopyInto(out *AerospikeUserSpec) {
 	return
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertManagerIssuerRef) DeepCopyInto(out *CertManagerIssuerRef) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerIssuerRef.
+func (in *CertManagerIssuerRef) DeepCopy() *CertManagerIssuerRef {
+	if in == nil {
+		return nil
+	}
+	out := new(CertManagerIssuerRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Rack) DeepCopyInto(out *Rack) {
 	clone := in.DeepCopy()
@@ -250,6 +811,29 @@ func (in *ValidationPolicySpec) DeepCopyInto(out *ValidationPolicySpec) {
 	return
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AerospikeVolumeAttachment) DeepCopyInto(out *AerospikeVolumeAttachment) {
+	clone := in.DeepCopy()
+	*out = *clone
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AerospikeVolumeSnapshotStatus) DeepCopyInto(out *AerospikeVolumeSnapshotStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AerospikeVolumeSnapshotStatus.
+func (in *AerospikeVolumeSnapshotStatus) DeepCopy() *AerospikeVolumeSnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AerospikeVolumeSnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in Values) DeepCopyInto(out *Values) {
 	{
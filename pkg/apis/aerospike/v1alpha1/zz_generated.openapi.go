@@ -11,13 +11,67 @@ import (
 
 func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
 	return map[string]common.OpenAPIDefinition{
-		"github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeCluster":              schema_pkg_apis_aerospike_v1alpha1_AerospikeCluster(ref),
-		"github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeClusterSpec":          schema_pkg_apis_aerospike_v1alpha1_AerospikeClusterSpec(ref),
-		"github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeClusterStatus":        schema_pkg_apis_aerospike_v1alpha1_AerospikeClusterStatus(ref),
-		"github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeInstanceSummary":      schema_pkg_apis_aerospike_v1alpha1_AerospikeInstanceSummary(ref),
-		"github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikePersistentVolumeSpec": schema_pkg_apis_aerospike_v1alpha1_AerospikePersistentVolumeSpec(ref),
-		"github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikePodStatus":            schema_pkg_apis_aerospike_v1alpha1_AerospikePodStatus(ref),
-		"github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeStorageSpec":          schema_pkg_apis_aerospike_v1alpha1_AerospikeStorageSpec(ref),
+		"github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeCertManagerSpec":            schema_pkg_apis_aerospike_v1alpha1_AerospikeCertManagerSpec(ref),
+		"github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeCluster":                    schema_pkg_apis_aerospike_v1alpha1_AerospikeCluster(ref),
+		"github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeClusterCondition":           schema_pkg_apis_aerospike_v1alpha1_AerospikeClusterCondition(ref),
+		"github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeClusterSnapshot":            schema_pkg_apis_aerospike_v1alpha1_AerospikeClusterSnapshot(ref),
+		"github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeClusterSnapshotSpec":        schema_pkg_apis_aerospike_v1alpha1_AerospikeClusterSnapshotSpec(ref),
+		"github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeClusterSnapshotStatus":      schema_pkg_apis_aerospike_v1alpha1_AerospikeClusterSnapshotStatus(ref),
+		"github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeClusterSpec":                schema_pkg_apis_aerospike_v1alpha1_AerospikeClusterSpec(ref),
+		"github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeClusterStatus":              schema_pkg_apis_aerospike_v1alpha1_AerospikeClusterStatus(ref),
+		"github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeConfigTemplate":             schema_pkg_apis_aerospike_v1alpha1_AerospikeConfigTemplate(ref),
+		"github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeConfigTemplateSpec":         schema_pkg_apis_aerospike_v1alpha1_AerospikeConfigTemplateSpec(ref),
+		"github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeFeatureKeyStatus":           schema_pkg_apis_aerospike_v1alpha1_AerospikeFeatureKeyStatus(ref),
+		"github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeInstanceSummary":            schema_pkg_apis_aerospike_v1alpha1_AerospikeInstanceSummary(ref),
+		"github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeManagedAccessControlStatus": schema_pkg_apis_aerospike_v1alpha1_AerospikeManagedAccessControlStatus(ref),
+		"github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikePersistentVolumeSpec":       schema_pkg_apis_aerospike_v1alpha1_AerospikePersistentVolumeSpec(ref),
+		"github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikePodStatus":                  schema_pkg_apis_aerospike_v1alpha1_AerospikePodStatus(ref),
+		"github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeRetainedPVCStatus":          schema_pkg_apis_aerospike_v1alpha1_AerospikeRetainedPVCStatus(ref),
+		"github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeStorageSpec":                schema_pkg_apis_aerospike_v1alpha1_AerospikeStorageSpec(ref),
+		"github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeTLSCertificateStatus":       schema_pkg_apis_aerospike_v1alpha1_AerospikeTLSCertificateStatus(ref),
+		"github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeVolumeAttachment":           schema_pkg_apis_aerospike_v1alpha1_AerospikeVolumeAttachment(ref),
+		"github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeVolumeSnapshotStatus":       schema_pkg_apis_aerospike_v1alpha1_AerospikeVolumeSnapshotStatus(ref),
+		"github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.CertManagerIssuerRef":                schema_pkg_apis_aerospike_v1alpha1_CertManagerIssuerRef(ref),
+	}
+}
+
+func schema_pkg_apis_aerospike_v1alpha1_AerospikeCertManagerSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "AerospikeCertManagerSpec configures the cert-manager Certificates of the cluster.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"issuerRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IssuerRef is the cert-manager issuer of the network.tls certificates.",
+							Ref:         ref("github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.CertManagerIssuerRef"),
+						},
+					},
+					"clientIssuerRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClientIssuerRef is the cert-manager issuer of the operator client certificate. Defaults to issuerRef.",
+							Ref:         ref("github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.CertManagerIssuerRef"),
+						},
+					},
+					"duration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Duration is the requested duration of the certificates. Defaults to the cert-manager default.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"renewBefore": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RenewBefore is how long before expiry the certificates are renewed. Defaults to the cert-manager default.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+				},
+				Required: []string{"issuerRef"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.CertManagerIssuerRef", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
 	}
 }
 
@@ -65,6 +119,210 @@ func schema_pkg_apis_aerospike_v1alpha1_AerospikeCluster(ref common.ReferenceCal
 	}
 }
 
+func schema_pkg_apis_aerospike_v1alpha1_AerospikeClusterCondition(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "AerospikeClusterCondition contains the details of a condition of the cluster.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type of the condition.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Status of the condition, True, False or Unknown.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"lastTransitionTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastTransitionTime is the last time the condition changed status.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"reason": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Reason is a brief reason of the last status change.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message has the details of the last status change.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"type", "status"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_aerospike_v1alpha1_AerospikeClusterSnapshot(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "AerospikeClusterSnapshot is the Schema for the aerospikeclustersnapshots API",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeClusterSnapshotSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeClusterSnapshotStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeClusterSnapshotSpec", "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeClusterSnapshotStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_aerospike_v1alpha1_AerospikeClusterSnapshotSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "AerospikeClusterSnapshotSpec defines the desired state of AerospikeClusterSnapshot",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"clusterName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClusterName is the name of the AerospikeCluster to snapshot. The cluster should be in the namespace of the snapshot.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"volumeSnapshotClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "VolumeSnapshotClassName is the name of the CSI VolumeSnapshotClass used to create the volume snapshots. The default VolumeSnapshotClass is used if not specified.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"skipQuiesce": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SkipQuiesce skips quiescing the pods of a rack before taking the volume snapshots of the rack. The snapshots are then only crash consistent. Racks are never quiesced for single rack or Community Edition clusters. Defaults to false.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"clusterName"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_aerospike_v1alpha1_AerospikeClusterSnapshotStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "AerospikeClusterSnapshotStatus defines the observed state of AerospikeClusterSnapshot",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Phase of the snapshot.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message has the reason the snapshot failed.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"completedRacks": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CompletedRacks are the IDs of the racks whose volume snapshots have been taken.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"integer"},
+										Format: "int32",
+									},
+								},
+							},
+						},
+					},
+					"currentRack": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CurrentRack is the ID of the rack whose volume snapshots are being cut.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"currentRackStartTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CurrentRackStartTime is the time the volume snapshots of the current rack were created.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"volumeSnapshots": {
+						SchemaProps: spec.SchemaProps{
+							Description: "VolumeSnapshots are the volume snapshots of the data PVCs of the cluster.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeVolumeSnapshotStatus"),
+									},
+								},
+							},
+						},
+					},
+					"completionTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CompletionTime is the time all volume snapshots became ready to use.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeVolumeSnapshotStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
 func schema_pkg_apis_aerospike_v1alpha1_AerospikeClusterSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -105,6 +363,12 @@ func schema_pkg_apis_aerospike_v1alpha1_AerospikeClusterSpec(ref common.Referenc
 							Ref:         ref("github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeConfigSecretSpec"),
 						},
 					},
+					"certManager": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CertManager configures cert-manager to issue the certificates of the network.tls names and the client certificate of the operator. The certificates are mounted in the pods and the pods are restarted when they are renewed.",
+							Ref:         ref("github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeCertManagerSpec"),
+						},
+					},
 					"aerospikeAccessControl": {
 						SchemaProps: spec.SchemaProps{
 							Description: "AerospikeAccessControl has the Aerospike roles and users definitions. Required if aerospike cluster security is enabled.",
@@ -113,7 +377,7 @@ func schema_pkg_apis_aerospike_v1alpha1_AerospikeClusterSpec(ref common.Referenc
 					},
 					"aerospikeConfig": {
 						SchemaProps: spec.SchemaProps{
-							Description: "AerospikeConfig sets config in aerospike.conf file. Other configs are taken as default",
+							Description: "AerospikeConfig sets config in aerospike.conf file. Other configs are taken as default. Required unless aerospikeConfigFile is given.",
 							Type:        []string{"object"},
 							AdditionalProperties: &spec.SchemaOrBool{
 								Allows: true,
@@ -126,6 +390,12 @@ func schema_pkg_apis_aerospike_v1alpha1_AerospikeClusterSpec(ref common.Referenc
 							},
 						},
 					},
+					"aerospikeConfigFile": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AerospikeConfigFile references a ConfigMap having an aerospike.conf file. The file is parsed into aerospikeConfig, replacing the aerospikeConfig values, and is parsed again when the ConfigMap changes.",
+							Ref:         ref("github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeConfigFileSpec"),
+						},
+					},
 					"resources": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Define resources requests and limits for Aerospike Server Container. Please contact aerospike for proper sizing exercise Only Memory and Cpu resources can be given Resources.Limits should be more than Resources.Requests.",
@@ -150,18 +420,45 @@ func schema_pkg_apis_aerospike_v1alpha1_AerospikeClusterSpec(ref common.Referenc
 							Ref:         ref("github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeNetworkPolicy"),
 						},
 					},
+					"kubernetesNetworkPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "KubernetesNetworkPolicy makes the operator create Kubernetes NetworkPolicies restricting the access to the Aerospike ports of the pods. Heartbeat and fabric are only allowed between the pods of the cluster.",
+							Ref:         ref("github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeKubernetesNetworkPolicySpec"),
+						},
+					},
 					"podSpec": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Additional configuration for create Aerospike pods.",
 							Ref:         ref("github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikePodSpec"),
 						},
 					},
+					"restoreFromSnapshot": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RestoreFromSnapshot is the name of a completed AerospikeClusterSnapshot in the cluster namespace. The PVCs of a new cluster are provisioned from the volume snapshots of the pods with the same rack ID and pod ordinal. Cannot be updated.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"configTemplates": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ConfigTemplates are the names of AerospikeConfigTemplates in the cluster namespace. Their aerospikeConfig, storage and podSpec are merged in order into the cluster spec, the values in the cluster spec take precedence.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
 				},
-				Required: []string{"size", "image", "aerospikeConfig", "resources"},
+				Required: []string{"size", "image", "resources"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeAccessControlSpec", "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeConfigSecretSpec", "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeNetworkPolicy", "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikePodSpec", "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeStorageSpec", "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.RackConfig", "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.ValidationPolicySpec", "k8s.io/api/core/v1.ResourceRequirements"},
+			"github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeAccessControlSpec", "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeCertManagerSpec", "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeConfigFileSpec", "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeConfigSecretSpec", "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeKubernetesNetworkPolicySpec", "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeNetworkPolicy", "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikePodSpec", "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeStorageSpec", "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.RackConfig", "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.ValidationPolicySpec", "k8s.io/api/core/v1.ResourceRequirements"},
 	}
 }
 
@@ -178,6 +475,25 @@ func schema_pkg_apis_aerospike_v1alpha1_AerospikeClusterStatus(ref common.Refere
 							Ref:         ref("github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeClusterSpec"),
 						},
 					},
+					"conditions": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": "type",
+								"x-kubernetes-list-type":     "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Conditions has the latest observations of the state of the cluster.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeClusterCondition"),
+									},
+								},
+							},
+						},
+					},
 					"pods": {
 						VendorExtensible: spec.VendorExtensible{
 							Extensions: spec.Extensions{
@@ -197,12 +513,174 @@ func schema_pkg_apis_aerospike_v1alpha1_AerospikeClusterStatus(ref common.Refere
 							},
 						},
 					},
+					"retainedPVCs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RetainedPVCs has the PVCs retained after their pods were removed from the cluster and the PVCs restored from a cluster snapshot till their pods are initialized. The map key is the name of the PVC.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeRetainedPVCStatus"),
+									},
+								},
+							},
+						},
+					},
+					"featureKey": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FeatureKey has the details of the feature key in the AerospikeConfigSecret.",
+							Ref:         ref("github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeFeatureKeyStatus"),
+						},
+					},
+					"tlsCertificates": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TLSCertificates has the details of the TLS certificates in the AerospikeConfigSecret.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeTLSCertificateStatus"),
+									},
+								},
+							},
+						},
+					},
+					"tlsCACertificates": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TLSCACertificates has the PEM encoded CA certificates of the operator client TLS name trusted by the operator. It has the CA certificates used before the last TLS files change till all the pods are rolled to the new files.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"managedAccessControl": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ManagedAccessControl has the users and roles created by the operator.",
+							Ref:         ref("github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeManagedAccessControlStatus"),
+						},
+					},
 				},
 				Required: []string{"AerospikeClusterSpec", "pods"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeClusterSpec", "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikePodStatus"},
+			"github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeClusterCondition", "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeClusterSpec", "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeFeatureKeyStatus", "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeManagedAccessControlStatus", "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikePodStatus", "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeRetainedPVCStatus", "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeTLSCertificateStatus"},
+	}
+}
+
+func schema_pkg_apis_aerospike_v1alpha1_AerospikeConfigTemplate(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "AerospikeConfigTemplate is the Schema for the aerospikeconfigtemplates API",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeConfigTemplateSpec"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeConfigTemplateSpec", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_aerospike_v1alpha1_AerospikeConfigTemplateSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "AerospikeConfigTemplateSpec defines the desired state of AerospikeConfigTemplate",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"aerospikeConfig": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AerospikeConfig is a partial aerospike config. It is merged into the aerospikeConfig of the referencing clusters, the values in the cluster aerospikeConfig take precedence.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"object"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"storage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Storage is used by the referencing clusters which do not have storage volumes.",
+							Ref:         ref("github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeStorageSpec"),
+						},
+					},
+					"podSpec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodSpec has the sidecars added to the referencing clusters which do not have a sidecar with the same name.",
+							Ref:         ref("github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikePodSpec"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikePodSpec", "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeStorageSpec"},
+	}
+}
+
+func schema_pkg_apis_aerospike_v1alpha1_AerospikeFeatureKeyStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "AerospikeFeatureKeyStatus contains the details of the feature key used by the cluster.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"serialNumber": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SerialNumber of the feature key.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"validUntil": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ValidUntil is the date the feature key expires. Not set if the feature key does not expire.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
@@ -271,7 +749,54 @@ func schema_pkg_apis_aerospike_v1alpha1_AerospikeInstanceSummary(ref common.Refe
 					},
 					"tlsAccessEndpoints": {
 						SchemaProps: spec.SchemaProps{
-							Description: "TLSAccessEndpoints are the TLS access endpoints for this pod.",
+							Description: "TLSAccessEndpoints are the TLS access endpoints for this pod.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"tlsAlternateAccessEndpoints": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TLSAlternateAccessEndpoints are the alternate TLS access endpoints for this pod.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"clusterName", "nodeID"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_aerospike_v1alpha1_AerospikeManagedAccessControlStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "AerospikeManagedAccessControlStatus contains the users and roles created by the operator.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"users": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Users are the names of the users created by the operator.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
@@ -283,9 +808,14 @@ func schema_pkg_apis_aerospike_v1alpha1_AerospikeInstanceSummary(ref common.Refe
 							},
 						},
 					},
-					"tlsAlternateAccessEndpoints": {
+					"roles": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
 						SchemaProps: spec.SchemaProps{
-							Description: "TLSAlternateAccessEndpoints are the alternate TLS access endpoints for this pod.",
+							Description: "Roles are the names of the roles created by the operator.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
@@ -298,7 +828,6 @@ func schema_pkg_apis_aerospike_v1alpha1_AerospikeInstanceSummary(ref common.Refe
 						},
 					},
 				},
-				Required: []string{"clusterName", "nodeID"},
 			},
 		},
 	}
@@ -332,6 +861,19 @@ func schema_pkg_apis_aerospike_v1alpha1_AerospikePersistentVolumeSpec(ref common
 							Format:      "",
 						},
 					},
+					"retentionPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RetentionPolicy determines what happens to the persistent volumes after the pod this volume binds to is removed from the cluster on scale down or rack removal. Retained volumes are reattached when the pod is added back. Defaults to \"Delete\" if cascadeDelete is true else \"Retain\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"retentionDuration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RetentionDuration is the time for which volumes are retained with the \"RetainForDuration\" policy, e.g. 24h.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
 					"effectiveCascadeDelete": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Effective/operative value to use for cascade delete after applying defaults.",
@@ -339,6 +881,19 @@ func schema_pkg_apis_aerospike_v1alpha1_AerospikePersistentVolumeSpec(ref common
 							Format:      "",
 						},
 					},
+					"effectiveRetentionPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Effective/operative value to use as the volume retention policy after applying defaults.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"effectiveRetentionDuration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Effective/operative value to use as the volume retention duration after applying defaults.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
 					"path": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Path is the device path where block 'block' mode volumes are attached to the pod or the mount path for 'filesystem' mode.",
@@ -353,9 +908,33 @@ func schema_pkg_apis_aerospike_v1alpha1_AerospikePersistentVolumeSpec(ref common
 							Format:      "",
 						},
 					},
+					"emptyDir": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EmptyDir is the volume source for 'emptyDir' mode volumes. Use medium \"Memory\" for a tmpfs backed volume.",
+							Ref:         ref("k8s.io/api/core/v1.EmptyDirVolumeSource"),
+						},
+					},
+					"secret": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Secret is the volume source for 'secret' mode volumes.",
+							Ref:         ref("k8s.io/api/core/v1.SecretVolumeSource"),
+						},
+					},
+					"hostPath": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HostPath is the volume source for 'hostPath' mode volumes.",
+							Ref:         ref("k8s.io/api/core/v1.HostPathVolumeSource"),
+						},
+					},
+					"projected": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Projected is the volume source for 'projected' mode volumes.",
+							Ref:         ref("k8s.io/api/core/v1.ProjectedVolumeSource"),
+						},
+					},
 					"storageClass": {
 						SchemaProps: spec.SchemaProps{
-							Description: "StorageClass should be pre-created by user.",
+							Description: "StorageClass should be pre-created by user. Required for 'block' and 'filesystem' mode volumes.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -369,15 +948,86 @@ func schema_pkg_apis_aerospike_v1alpha1_AerospikePersistentVolumeSpec(ref common
 					},
 					"sizeInGB": {
 						SchemaProps: spec.SchemaProps{
-							Description: "SizeInGB Size of volume in GB.",
+							Description: "SizeInGB Size of volume in GB. Deprecated: Use size instead.",
 							Type:        []string{"integer"},
 							Format:      "int32",
 						},
 					},
+					"size": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Size of the volume as a kubernetes quantity, e.g. 375Gi or 500Mi. Required for 'block' and 'filesystem' mode volumes unless sizeInGB is specified.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"accessModes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AccessModes of the persistent volume claim. Defaults to ReadWriteOnce.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"labels": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Labels to add to the persistent volume claim.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"annotations": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Annotations to add to the persistent volume claim.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"selector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Selector is a label query over persistent volumes to consider for binding, e.g. to bind to pre-provisioned local volumes.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+						},
+					},
+					"sidecars": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Sidecars lists the sidecar containers this volume is also mounted into.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeVolumeAttachment"),
+									},
+								},
+							},
+						},
+					},
 				},
-				Required: []string{"effectiveCascadeDelete", "path", "storageClass", "volumeMode", "sizeInGB"},
+				Required: []string{"effectiveCascadeDelete", "path", "volumeMode"},
 			},
 		},
+		Dependencies: []string{
+			"github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikeVolumeAttachment", "k8s.io/api/core/v1.EmptyDirVolumeSource", "k8s.io/api/core/v1.HostPathVolumeSource", "k8s.io/api/core/v1.ProjectedVolumeSource", "k8s.io/api/core/v1.SecretVolumeSource", "k8s.io/apimachinery/pkg/api/resource.Quantity", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
 	}
 }
 
@@ -480,6 +1130,55 @@ func schema_pkg_apis_aerospike_v1alpha1_AerospikePodStatus(ref common.ReferenceC
 	}
 }
 
+func schema_pkg_apis_aerospike_v1alpha1_AerospikeRetainedPVCStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "AerospikeRetainedPVCStatus contains the details of a PVC retained after its pod was removed from the cluster.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"podName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodName is the name of the pod this PVC was attached to.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"rackID": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RackID of the rack the pod belonged to.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"path": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Path is the storage path of the volume.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"retainedSince": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RetainedSince is the time the pod was removed and the PVC retained.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"expiryTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ExpiryTime is the time after which the PVC is deleted. Not set if the PVC is retained until reattached.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+				Required: []string{"podName", "rackID", "path", "retainedSince"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
 func schema_pkg_apis_aerospike_v1alpha1_AerospikeStorageSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -527,3 +1226,172 @@ func schema_pkg_apis_aerospike_v1alpha1_AerospikeStorageSpec(ref common.Referenc
 			"github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikePersistentVolumePolicySpec", "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1.AerospikePersistentVolumeSpec"},
 	}
 }
+
+func schema_pkg_apis_aerospike_v1alpha1_AerospikeTLSCertificateStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "AerospikeTLSCertificateStatus contains the details of a TLS certificate used by the cluster.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"tlsName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TLSName is the name of the network.tls config using the certificate.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"certFile": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CertFile is the cert-file of the network.tls config.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"notAfter": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NotAfter is the date the certificate expires.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+				Required: []string{"tlsName", "certFile", "notAfter"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_aerospike_v1alpha1_AerospikeVolumeAttachment(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "AerospikeVolumeAttachment describes how a volume is mounted into a sidecar container.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"containerName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ContainerName is the name of the sidecar container to mount the volume into.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"path": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Path is the device path or the mount path of the volume in the sidecar. Defaults to the volume path.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"subPath": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SubPath is the path within the volume to mount. Not allowed for 'block' mode volumes.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"readOnly": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ReadOnly mounts the volume read-only in the sidecar. Not allowed for 'block' mode volumes.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"containerName"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_aerospike_v1alpha1_AerospikeVolumeSnapshotStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "AerospikeVolumeSnapshotStatus contains the details of the VolumeSnapshot of a cluster PVC.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the VolumeSnapshot.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"pvcName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PVCName is the name of the snapshotted PVC.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"podName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodName is the name of the pod the PVC is attached to.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"rackID": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RackID of the rack the pod belongs to.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"path": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Path is the storage path of the volume.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"readyToUse": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ReadyToUse indicates if the VolumeSnapshot can be used to provision a PVC.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name", "pvcName", "podName", "rackID", "path", "readyToUse"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_aerospike_v1alpha1_CertManagerIssuerRef(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "CertManagerIssuerRef references a cert-manager Issuer in the cluster namespace or a ClusterIssuer.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the issuer.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind of the issuer, Issuer or ClusterIssuer. Defaults to Issuer.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"group": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Group of the issuer. Defaults to cert-manager.io.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
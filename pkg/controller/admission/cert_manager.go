@@ -0,0 +1,90 @@
+package admission
+
+import (
+	"fmt"
+
+	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
+	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/utils"
+	log "github.com/inconshreveable/log15"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// setDefaultTLSConf sets the files of the network.tls entries to the mounted cert-manager certificates.
+func setDefaultTLSConf(logger log.Logger, config aerospikev1alpha1.Values) error {
+	networkConf, _ := config["network"].(map[string]interface{})
+	tlsConfList, _ := networkConf["tls"].([]interface{})
+	for _, tlsConfInt := range tlsConfList {
+		tlsConf, ok := tlsConfInt.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("aerospikeConfig.network.tls entry not a valid map %v", tlsConfInt)
+		}
+		tlsName, ok := tlsConf["name"].(string)
+		if !ok {
+			return fmt.Errorf("aerospikeConfig.network.tls entry has no name %v", tlsConf)
+		}
+
+		tlsDefaults := map[string]interface{}{}
+		tlsDefaults["cert-file"] = utils.CertManagerTLSPath(tlsName, utils.CertManagerCertFile)
+		tlsDefaults["key-file"] = utils.CertManagerTLSPath(tlsName, utils.CertManagerKeyFile)
+		tlsDefaults["ca-file"] = utils.CertManagerTLSPath(tlsName, utils.CertManagerCAFile)
+
+		if err := setDefaultsInConfigMap(logger, tlsConf, tlsDefaults); err != nil {
+			return fmt.Errorf("Failed to set default aerospikeConfig.network.tls %s config: %v", tlsName, err)
+		}
+	}
+
+	logger.Info("Set cert-manager certificates in aerospikeConfig.network.tls", log.Ctx{"aerospikeConfig.network.tls": tlsConfList})
+
+	return nil
+}
+
+// validateCertManager validates the cert-manager issuers and the TLS names the certificates are issued for.
+func validateCertManager(aeroCluster *aerospikev1alpha1.AerospikeCluster, fldPath, configPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	certManager := aeroCluster.Spec.CertManager
+	if certManager == nil {
+		return allErrs
+	}
+
+	allErrs = append(allErrs, validateIssuerRef(&certManager.IssuerRef, fldPath.Child("issuerRef"))...)
+	if certManager.ClientIssuerRef != nil {
+		allErrs = append(allErrs, validateIssuerRef(certManager.ClientIssuerRef, fldPath.Child("clientIssuerRef"))...)
+	}
+
+	if certManager.Duration != nil && certManager.Duration.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("duration"), certManager.Duration.Duration.String(), "must be positive"))
+	}
+	if certManager.RenewBefore != nil {
+		if certManager.RenewBefore.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("renewBefore"), certManager.RenewBefore.Duration.String(), "must be positive"))
+		} else if certManager.Duration != nil && certManager.RenewBefore.Duration >= certManager.Duration.Duration {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("renewBefore"), certManager.RenewBefore.Duration.String(), "must be less than duration"))
+		}
+	}
+
+	tlsNames := utils.GetTLSNames(aeroCluster.Spec.AerospikeConfig)
+	if len(tlsNames) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath, "certManager needs network.tls entries in aerospikeConfig to issue certificates for"))
+	}
+	for i, tlsName := range tlsNames {
+		// The TLS name is used in the certificate secret name and is a DNS name of the certificate.
+		for _, msg := range validation.IsDNS1123Subdomain(utils.CertManagerSecretName(aeroCluster, tlsName)) {
+			allErrs = append(allErrs, field.Invalid(configPath.Child("network", "tls").Index(i).Child("name"), tlsName, msg))
+		}
+	}
+	return allErrs
+}
+
+func validateIssuerRef(issuerRef *aerospikev1alpha1.CertManagerIssuerRef, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if issuerRef.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("name"), "issuer name cannot be empty"))
+	}
+	switch issuerRef.Kind {
+	case "", "Issuer", "ClusterIssuer":
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("kind"), issuerRef.Kind, []string{"Issuer", "ClusterIssuer"}))
+	}
+	return allErrs
+}
@@ -0,0 +1,107 @@
+package admission
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
+	log15 "github.com/inconshreveable/log15"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func tlsConfig(tlsConfList ...interface{}) aerospikev1alpha1.Values {
+	return aerospikev1alpha1.Values{
+		"network": map[string]interface{}{
+			"service": map[string]interface{}{"tls-name": "aerospike-a-0.test-runner"},
+			"tls":     tlsConfList,
+		},
+	}
+}
+
+func TestSetDefaultTLSConf(t *testing.T) {
+	config := tlsConfig(map[string]interface{}{"name": "aerospike-a-0.test-runner"})
+	if err := setDefaultTLSConf(log15.New(), config); err != nil {
+		t.Fatal(err)
+	}
+
+	tlsConf := config["network"].(map[string]interface{})["tls"].([]interface{})[0]
+	want := map[string]interface{}{
+		"name":      "aerospike-a-0.test-runner",
+		"cert-file": "/etc/aerospike/certs/aerospike-a-0.test-runner/tls.crt",
+		"key-file":  "/etc/aerospike/certs/aerospike-a-0.test-runner/tls.key",
+		"ca-file":   "/etc/aerospike/certs/aerospike-a-0.test-runner/ca.crt",
+	}
+	if !reflect.DeepEqual(tlsConf, want) {
+		t.Errorf("got tls conf %v, want %v", tlsConf, want)
+	}
+
+	// Defaulting again keeps the files.
+	if err := setDefaultTLSConf(log15.New(), config); err != nil {
+		t.Errorf("default again: %v", err)
+	}
+
+	// Files of the user secret cannot be used with cert-manager.
+	config = tlsConfig(map[string]interface{}{"name": "aerospike-a-0.test-runner", "cert-file": "/etc/aerospike/secret/svc_cluster_chain.pem"})
+	if err := setDefaultTLSConf(log15.New(), config); err == nil {
+		t.Errorf("user cert-file: expected error")
+	}
+}
+
+func TestValidateCertManager(t *testing.T) {
+	duration := func(d time.Duration) *metav1.Duration { return &metav1.Duration{Duration: d} }
+
+	tests := []struct {
+		name        string
+		certManager *aerospikev1alpha1.AerospikeCertManagerSpec
+		config      aerospikev1alpha1.Values
+		want        []string
+	}{
+		{name: "no cert-manager", config: aerospikev1alpha1.Values{}, want: []string{}},
+		{
+			name:        "valid",
+			certManager: &aerospikev1alpha1.AerospikeCertManagerSpec{IssuerRef: aerospikev1alpha1.CertManagerIssuerRef{Name: "ca-issuer", Kind: "ClusterIssuer"}, Duration: duration(90 * 24 * time.Hour), RenewBefore: duration(30 * 24 * time.Hour)},
+			config:      tlsConfig(map[string]interface{}{"name": "aerospike-a-0.test-runner"}),
+			want:        []string{},
+		},
+		{
+			name: "invalid issuers",
+			certManager: &aerospikev1alpha1.AerospikeCertManagerSpec{
+				IssuerRef:       aerospikev1alpha1.CertManagerIssuerRef{Kind: "Issuer"},
+				ClientIssuerRef: &aerospikev1alpha1.CertManagerIssuerRef{Name: "client-issuer", Kind: "Vault"},
+			},
+			config: tlsConfig(map[string]interface{}{"name": "aerospike-a-0.test-runner"}),
+			want:   []string{"spec.certManager.issuerRef.name", "spec.certManager.clientIssuerRef.kind"},
+		},
+		{
+			name:        "renew after expiry",
+			certManager: &aerospikev1alpha1.AerospikeCertManagerSpec{IssuerRef: aerospikev1alpha1.CertManagerIssuerRef{Name: "ca-issuer"}, Duration: duration(time.Hour), RenewBefore: duration(2 * time.Hour)},
+			config:      tlsConfig(map[string]interface{}{"name": "aerospike-a-0.test-runner"}),
+			want:        []string{"spec.certManager.renewBefore"},
+		},
+		{
+			name:        "no tls",
+			certManager: &aerospikev1alpha1.AerospikeCertManagerSpec{IssuerRef: aerospikev1alpha1.CertManagerIssuerRef{Name: "ca-issuer"}},
+			config:      aerospikev1alpha1.Values{},
+			want:        []string{"spec.certManager"},
+		},
+		{
+			name:        "invalid tls name",
+			certManager: &aerospikev1alpha1.AerospikeCertManagerSpec{IssuerRef: aerospikev1alpha1.CertManagerIssuerRef{Name: "ca-issuer"}},
+			config:      tlsConfig(map[string]interface{}{"name": "aerospike-a-0.test-runner"}, map[string]interface{}{"name": "Fabric_TLS"}),
+			want:        []string{"spec.aerospikeConfig.network.tls[1].name"},
+		},
+	}
+
+	for _, test := range tests {
+		aeroCluster := &aerospikev1alpha1.AerospikeCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "aerocluster"},
+			Spec:       aerospikev1alpha1.AerospikeClusterSpec{CertManager: test.certManager, AerospikeConfig: test.config},
+		}
+		errs := validateCertManager(aeroCluster, field.NewPath("spec", "certManager"), field.NewPath("spec", "aerospikeConfig"))
+		if got := errorFields(errs); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got error fields %v, want %v", test.name, got, test.want)
+		}
+	}
+}
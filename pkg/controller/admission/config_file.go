@@ -0,0 +1,53 @@
+package admission
+
+import (
+	"fmt"
+
+	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
+	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/conffile"
+	log "github.com/inconshreveable/log15"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// applyConfigFile parses the aerospike.conf file of the aerospikeConfigFile ConfigMap into the cluster aerospikeConfig,
+// replacing its values. The file is parsed on every update so that the parsed config follows the ConfigMap.
+func (s *ClusterMutatingAdmissionWebhook) applyConfigFile() error {
+	configFile := s.obj.Spec.AerospikeConfigFile
+	if configFile == nil {
+		delete(s.obj.Annotations, conffile.VersionAnnotation)
+		return nil
+	}
+
+	if configFile.Key == "" {
+		configFile.Key = conffile.DefaultKey
+	}
+
+	if s.client == nil {
+		return fmt.Errorf("Cannot read aerospikeConfigFile ConfigMap %s", configFile.ConfigMapName)
+	}
+	config, version, err := conffile.Load(s.client, &s.obj)
+	if err != nil {
+		return err
+	}
+
+	s.obj.Spec.AerospikeConfig = config
+	if s.obj.Annotations == nil {
+		s.obj.Annotations = map[string]string{}
+	}
+	s.obj.Annotations[conffile.VersionAnnotation] = version
+
+	s.logger.Info("Parsed aerospikeConfigFile", log.Ctx{"configMap": configFile.ConfigMapName, "key": configFile.Key, "version": version})
+	return nil
+}
+
+// validateConfigFile validates the aerospike.conf file reference.
+func validateConfigFile(configFile *aerospikev1alpha1.AerospikeConfigFileSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if configFile == nil {
+		return allErrs
+	}
+	if configFile.ConfigMapName == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("configMapName"), "aerospikeConfigFile configMapName cannot be empty"))
+	}
+	return allErrs
+}
@@ -0,0 +1,66 @@
+package admission
+
+import (
+	"fmt"
+
+	"github.com/aerospike/aerospike-management-lib/asconfig"
+)
+
+// configLayout describes the aerospike.conf layout of the server versions starting at minVersion.
+type configLayout struct {
+	minVersion string
+
+	// xdrDigestLog is true if xdr ships records using the xdr-digestlog-path. Replaced by xdr dcs in 5.0.
+	xdrDigestLog bool
+
+	// namespaceMemorySize is true if namespace data in memory is sized by the namespace memory-size and
+	// storage-engine data-in-memory. From 7.0 namespace memory is configured in storage-engine memory instead.
+	namespaceMemorySize bool
+}
+
+// configLayouts has the config layouts sorted by minVersion.
+var configLayouts = []configLayout{
+	{
+		minVersion:          baseVersion,
+		xdrDigestLog:        true,
+		namespaceMemorySize: true,
+	},
+	{
+		minVersion:          "5.0.0",
+		namespaceMemorySize: true,
+	},
+	{
+		minVersion: "7.0.0",
+	},
+}
+
+// getConfigLayout returns the config layout for the server version.
+func getConfigLayout(version string) (*configLayout, error) {
+	for i := len(configLayouts) - 1; i >= 0; i-- {
+		val, err := asconfig.CompareVersions(version, configLayouts[i].minVersion)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to check image version: %v", err)
+		}
+		if val >= 0 {
+			return &configLayouts[i], nil
+		}
+	}
+	return nil, fmt.Errorf("Image version %s not supported. Base version %s", version, baseVersion)
+}
+
+// isConfigLayoutChanged returns true if the config layouts of the two server versions differ. Unknown versions are
+// treated as unchanged.
+func isConfigLayoutChanged(newVersion, oldVersion string) bool {
+	if newVersion == "" || oldVersion == "" {
+		return false
+	}
+	newLayout, err := getConfigLayout(newVersion)
+	if err != nil {
+		return false
+	}
+	oldLayout, err := getConfigLayout(oldVersion)
+	if err != nil {
+		return false
+	}
+	return newLayout != oldLayout
+}
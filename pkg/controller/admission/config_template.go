@@ -0,0 +1,279 @@
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
+	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/utils"
+	log "github.com/inconshreveable/log15"
+	av1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// applyConfigTemplates merges the referenced config templates into the cluster spec, the values in the cluster spec
+// take precedence. The merged templates are kept in an annotation and the values merged from them are removed before
+// merging again, so that template changes reach the clusters which do not override the changed values.
+func (s *ClusterMutatingAdmissionWebhook) applyConfigTemplates() error {
+	applied, err := getAppliedConfigTemplate(s.obj.Annotations)
+	if err != nil {
+		return err
+	}
+	if applied != nil {
+		removeConfigTemplate(&s.obj.Spec, applied)
+	}
+
+	if len(s.obj.Spec.ConfigTemplates) == 0 {
+		delete(s.obj.Annotations, utils.ConfigTemplateAppliedAnnotation)
+		delete(s.obj.Annotations, utils.ConfigTemplateVersionAnnotation)
+		return nil
+	}
+
+	if s.client == nil {
+		return fmt.Errorf("Cannot read configTemplates %v", s.obj.Spec.ConfigTemplates)
+	}
+	templates, err := utils.GetConfigTemplates(s.client, &s.obj)
+	if err != nil {
+		return err
+	}
+
+	template, err := mergeConfigTemplates(templates)
+	if err != nil {
+		return err
+	}
+
+	// Keep the merged templates before the cluster spec is merged and defaulted since they share values.
+	appliedJSON, err := json.Marshal(template)
+	if err != nil {
+		return fmt.Errorf("Failed to marshal configTemplates: %v", err)
+	}
+	if s.obj.Annotations == nil {
+		s.obj.Annotations = map[string]string{}
+	}
+	s.obj.Annotations[utils.ConfigTemplateAppliedAnnotation] = string(appliedJSON)
+	s.obj.Annotations[utils.ConfigTemplateVersionAnnotation] = utils.GetConfigTemplateVersion(templates)
+
+	if err := addConfigTemplate(&s.obj.Spec, template); err != nil {
+		return err
+	}
+	s.logger.Info("Applied configTemplates", log.Ctx{"configTemplates": s.obj.Spec.ConfigTemplates, "version": s.obj.Annotations[utils.ConfigTemplateVersionAnnotation]})
+	return nil
+}
+
+// ValidateAerospikeConfigTemplate validates a config template update against the clusters referencing it. The update
+// is denied if a referencing cluster could not apply it, like when it changes the cluster storage.
+func ValidateAerospikeConfigTemplate(k8sClient client.Reader, req webhook.AdmissionRequest) webhook.AdmissionResponse {
+	if req.Operation != av1beta1.Update {
+		return webhook.Allowed("Validation passed. No update")
+	}
+
+	decoder, _ := admission.NewDecoder(scheme)
+
+	template := &aerospikev1alpha1.AerospikeConfigTemplate{}
+	decoder.DecodeRaw(req.Object, template)
+
+	logger := pkglog.New(log.Ctx{"AerospikeConfigTemplate": utils.NamespacedName(template.Namespace, template.Name)})
+	logger.Info("Validate AerospikeConfigTemplate update")
+
+	clusterList := &aerospikev1alpha1.AerospikeClusterList{}
+	if err := k8sClient.List(context.TODO(), clusterList, client.InNamespace(template.Namespace)); err != nil {
+		return webhook.Errored(http.StatusInternalServerError, fmt.Errorf("Failed to list AerospikeClusters: %v", err))
+	}
+
+	allErrs := field.ErrorList{}
+	for i := range clusterList.Items {
+		aeroCluster := &clusterList.Items[i]
+		if !utils.IsConfigTemplateReferenced(aeroCluster, template.Name) {
+			continue
+		}
+
+		templates, err := utils.GetConfigTemplates(k8sClient, aeroCluster)
+		if err != nil {
+			// The cluster cannot apply its templates regardless of this update.
+			logger.Warn("Skipping config template validation", log.Ctx{"AerospikeCluster": utils.ClusterNamespacedName(aeroCluster), "err": err})
+			continue
+		}
+		for j := range templates {
+			if templates[j].Name == template.Name {
+				templates[j] = *template
+			}
+		}
+		allErrs = append(allErrs, validateConfigTemplateUpdate(logger, aeroCluster, templates, field.NewPath("spec"))...)
+	}
+
+	if len(allErrs) != 0 {
+		logger.Error("Validate AerospikeConfigTemplate update failed", log.Ctx{"err": allErrs.ToAggregate()})
+		return invalidResponse("AerospikeConfigTemplate", template.Name, allErrs)
+	}
+	return webhook.Allowed("Validation passed")
+}
+
+// validateConfigTemplateUpdate validates that the cluster spec with the config templates applied is a valid update of
+// the cluster spec. Only the values the config templates set are checked, the rest of the spec is unchanged.
+func validateConfigTemplateUpdate(logger log.Logger, aeroCluster *aerospikev1alpha1.AerospikeCluster, templates []aerospikev1alpha1.AerospikeConfigTemplate, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	clusterName := utils.ClusterNamespacedName(aeroCluster)
+
+	newSpec, err := getConfigTemplateSpec(aeroCluster, templates)
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, aeroCluster.Name, fmt.Sprintf("Cannot be applied to AerospikeCluster %s: %v", clusterName, err)))
+	}
+
+	if err := aeroCluster.Spec.Storage.ValidateStorageSpecChange(newSpec.Storage); err != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("storage"), fmt.Sprintf("Storage of AerospikeCluster %s cannot be updated: %v", clusterName, err)))
+	}
+
+	version, err := getImageVersion(aeroCluster.Spec.Image)
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, aeroCluster.Name, fmt.Sprintf("Cannot be validated for AerospikeCluster %s: %v", clusterName, err)))
+	}
+	for _, e := range validateAerospikeConfigUpdate(logger, version, version, newSpec.AerospikeConfig, aeroCluster.Spec.AerospikeConfig, fldPath.Child("aerospikeConfig")) {
+		e.Detail = fmt.Sprintf("%s in AerospikeCluster %s", e.Detail, clusterName)
+		allErrs = append(allErrs, e)
+	}
+	return allErrs
+}
+
+// getConfigTemplateSpec returns the cluster spec with the config templates applied in place of the config templates
+// last applied, like the mutating webhook does.
+func getConfigTemplateSpec(aeroCluster *aerospikev1alpha1.AerospikeCluster, templates []aerospikev1alpha1.AerospikeConfigTemplate) (*aerospikev1alpha1.AerospikeClusterSpec, error) {
+	spec := aeroCluster.Spec.DeepCopy()
+
+	applied, err := getAppliedConfigTemplate(aeroCluster.Annotations)
+	if err != nil {
+		return nil, err
+	}
+	if applied != nil {
+		removeConfigTemplate(spec, applied)
+	}
+
+	template, err := mergeConfigTemplates(templates)
+	if err != nil {
+		return nil, err
+	}
+	if err := addConfigTemplate(spec, template); err != nil {
+		return nil, err
+	}
+	spec.Storage.SetDefaults()
+	return spec, nil
+}
+
+// validateConfigTemplates validates the config template names.
+func validateConfigTemplates(names []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	seen := map[string]bool{}
+	for i, name := range names {
+		if name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Index(i), "configTemplate name cannot be empty"))
+		} else if seen[name] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i), name))
+		}
+		seen[name] = true
+	}
+	return allErrs
+}
+
+// getAppliedConfigTemplate returns the merged config templates last applied to the cluster, nil if none.
+func getAppliedConfigTemplate(annotations map[string]string) (*aerospikev1alpha1.AerospikeConfigTemplateSpec, error) {
+	appliedJSON, ok := annotations[utils.ConfigTemplateAppliedAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	applied := &aerospikev1alpha1.AerospikeConfigTemplateSpec{}
+	if err := json.Unmarshal([]byte(appliedJSON), applied); err != nil {
+		return nil, fmt.Errorf("Invalid annotation %s: %v", utils.ConfigTemplateAppliedAnnotation, err)
+	}
+	return applied, nil
+}
+
+// mergeConfigTemplates merges the config templates in order, the values of later templates take precedence.
+func mergeConfigTemplates(templates []aerospikev1alpha1.AerospikeConfigTemplate) (*aerospikev1alpha1.AerospikeConfigTemplateSpec, error) {
+	res := &aerospikev1alpha1.AerospikeConfigTemplateSpec{}
+	for _, template := range templates {
+		if template.Spec.AerospikeConfig != nil {
+			config, err := merge(res.AerospikeConfig, template.Spec.AerospikeConfig)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to merge AerospikeConfigTemplate %s: %v", template.Name, err)
+			}
+			res.AerospikeConfig = config
+		}
+		if template.Spec.Storage != nil {
+			res.Storage = template.Spec.Storage
+		}
+		if template.Spec.PodSpec != nil {
+			if res.PodSpec == nil {
+				res.PodSpec = &aerospikev1alpha1.AerospikePodSpec{}
+			}
+			res.PodSpec.Sidecars = mergeSidecars(res.PodSpec.Sidecars, template.Spec.PodSpec.Sidecars)
+		}
+	}
+	return res, nil
+}
+
+// addConfigTemplate merges the config template into the cluster spec.
+func addConfigTemplate(spec *aerospikev1alpha1.AerospikeClusterSpec, template *aerospikev1alpha1.AerospikeConfigTemplateSpec) error {
+	if template.AerospikeConfig != nil {
+		config, err := merge(template.AerospikeConfig, spec.AerospikeConfig)
+		if err != nil {
+			return fmt.Errorf("Failed to merge configTemplates aerospikeConfig: %v", err)
+		}
+		spec.AerospikeConfig = config
+	}
+	if template.Storage != nil && len(spec.Storage.Volumes) == 0 {
+		spec.Storage = *template.Storage
+	}
+	if template.PodSpec != nil {
+		spec.PodSpec.Sidecars = mergeSidecars(template.PodSpec.Sidecars, spec.PodSpec.Sidecars)
+	}
+	return nil
+}
+
+// removeConfigTemplate removes the values merged from the config template which are not changed in the cluster spec.
+func removeConfigTemplate(spec *aerospikev1alpha1.AerospikeClusterSpec, template *aerospikev1alpha1.AerospikeConfigTemplateSpec) {
+	if template.AerospikeConfig != nil {
+		spec.AerospikeConfig = unmerge(spec.AerospikeConfig, template.AerospikeConfig)
+	}
+	if template.Storage != nil {
+		// The cluster storage has the defaults set after the template was merged.
+		storage := *template.Storage
+		storage.SetDefaults()
+		if isValueEqual(spec.Storage, storage) {
+			spec.Storage = aerospikev1alpha1.AerospikeStorageSpec{}
+		}
+	}
+	if template.PodSpec != nil {
+		var sidecars []corev1.Container
+		for _, sidecar := range spec.PodSpec.Sidecars {
+			if templateSidecar := getContainer(template.PodSpec.Sidecars, sidecar.Name); templateSidecar == nil || !isValueEqual(sidecar, *templateSidecar) {
+				sidecars = append(sidecars, sidecar)
+			}
+		}
+		spec.PodSpec.Sidecars = sidecars
+	}
+}
+
+// mergeSidecars adds the patch sidecars to base sidecars, replacing the base sidecars with the same name.
+func mergeSidecars(base, patch []corev1.Container) []corev1.Container {
+	var res []corev1.Container
+	for _, sidecar := range base {
+		if getContainer(patch, sidecar.Name) == nil {
+			res = append(res, sidecar)
+		}
+	}
+	return append(res, patch...)
+}
+
+func getContainer(containers []corev1.Container, name string) *corev1.Container {
+	for i := range containers {
+		if containers[i].Name == name {
+			return &containers[i]
+		}
+	}
+	return nil
+}
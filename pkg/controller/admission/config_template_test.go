@@ -0,0 +1,156 @@
+package admission
+
+import (
+	"encoding/json"
+	"testing"
+
+	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
+	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/utils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func configTemplate(protoFdMax, replicationFactor int, sidecarImage string, sizeInGB int32) *aerospikev1alpha1.AerospikeConfigTemplateSpec {
+	return &aerospikev1alpha1.AerospikeConfigTemplateSpec{
+		AerospikeConfig: aerospikev1alpha1.Values{
+			"service": map[string]interface{}{
+				"proto-fd-max":    protoFdMax,
+				"migrate-threads": 1,
+			},
+			"namespaces": []interface{}{
+				map[string]interface{}{"name": "test", "replication-factor": replicationFactor, "memory-size": 1000000000},
+			},
+		},
+		Storage: &aerospikev1alpha1.AerospikeStorageSpec{
+			Volumes: []aerospikev1alpha1.AerospikePersistentVolumeSpec{
+				{Path: "/opt/aerospike", SizeInGB: sizeInGB, StorageClass: "ssd", VolumeMode: aerospikev1alpha1.AerospikeVolumeModeFilesystem},
+			},
+		},
+		PodSpec: &aerospikev1alpha1.AerospikePodSpec{
+			Sidecars: []corev1.Container{{Name: "exporter", Image: sidecarImage}},
+		},
+	}
+}
+
+// applyConfigTemplate applies the config template like the mutating webhook and returns the template as read back
+// from the applied annotation.
+func applyConfigTemplate(t *testing.T, spec *aerospikev1alpha1.AerospikeClusterSpec, template *aerospikev1alpha1.AerospikeConfigTemplateSpec) *aerospikev1alpha1.AerospikeConfigTemplateSpec {
+	appliedJSON, err := json.Marshal(template)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := addConfigTemplate(spec, template); err != nil {
+		t.Fatal(err)
+	}
+	spec.Storage.SetDefaults()
+
+	applied := &aerospikev1alpha1.AerospikeConfigTemplateSpec{}
+	if err := json.Unmarshal(appliedJSON, applied); err != nil {
+		t.Fatal(err)
+	}
+	return applied
+}
+
+func TestConfigTemplate(t *testing.T) {
+	spec := &aerospikev1alpha1.AerospikeClusterSpec{
+		AerospikeConfig: aerospikev1alpha1.Values{
+			"service": map[string]interface{}{"migrate-threads": 4},
+			"namespaces": []interface{}{
+				map[string]interface{}{"name": "test", "memory-size": 2000000000},
+				map[string]interface{}{"name": "bar", "replication-factor": 1},
+			},
+		},
+	}
+
+	applied := applyConfigTemplate(t, spec, configTemplate(15000, 2, "exporter:1", 1))
+
+	want := map[string]interface{}{
+		"service": map[string]interface{}{"proto-fd-max": 15000, "migrate-threads": 4},
+		"namespaces": []interface{}{
+			map[string]interface{}{"name": "test", "replication-factor": 2, "memory-size": 2000000000},
+			map[string]interface{}{"name": "bar", "replication-factor": 1},
+		},
+	}
+	if !isValueEqual(spec.AerospikeConfig, want) {
+		t.Fatalf("got aerospikeConfig %v, want %v", spec.AerospikeConfig, want)
+	}
+	if len(spec.Storage.Volumes) != 1 || len(spec.PodSpec.Sidecars) != 1 {
+		t.Fatalf("template storage and sidecars not applied, got storage %v, sidecars %v", spec.Storage, spec.PodSpec.Sidecars)
+	}
+
+	// Changed template values reach the cluster unless the cluster overrides them.
+	removeConfigTemplate(spec, applied)
+	applyConfigTemplate(t, spec, configTemplate(20000, 3, "exporter:2", 2))
+
+	want = map[string]interface{}{
+		"service": map[string]interface{}{"proto-fd-max": 20000, "migrate-threads": 4},
+		"namespaces": []interface{}{
+			map[string]interface{}{"name": "test", "replication-factor": 3, "memory-size": 2000000000},
+			map[string]interface{}{"name": "bar", "replication-factor": 1},
+		},
+	}
+	if !isValueEqual(spec.AerospikeConfig, want) {
+		t.Fatalf("got aerospikeConfig %v, want %v", spec.AerospikeConfig, want)
+	}
+	if len(spec.Storage.Volumes) != 1 || spec.Storage.Volumes[0].SizeInGB != 2 {
+		t.Fatalf("changed template storage not applied, got %v", spec.Storage)
+	}
+	if len(spec.PodSpec.Sidecars) != 1 || spec.PodSpec.Sidecars[0].Image != "exporter:2" {
+		t.Fatalf("changed template sidecar not applied, got %v", spec.PodSpec.Sidecars)
+	}
+}
+
+func TestValidateConfigTemplates(t *testing.T) {
+	fldPath := field.NewPath("spec", "configTemplates")
+	if errs := validateConfigTemplates([]string{"t1", "t2"}, fldPath); len(errs) != 0 {
+		t.Errorf("unexpected errors %v", errs)
+	}
+	got := errorFields(validateConfigTemplates([]string{"t1", "", "t1"}, fldPath))
+	want := []string{"spec.configTemplates[1]", "spec.configTemplates[2]"}
+	if !isValueEqual(got, want) {
+		t.Errorf("got error fields %v, want %v", got, want)
+	}
+}
+
+func TestValidateConfigTemplateUpdate(t *testing.T) {
+	aeroCluster := &aerospikev1alpha1.AerospikeCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "aerocluster", Namespace: "test"},
+		Spec: aerospikev1alpha1.AerospikeClusterSpec{
+			Image:           "aerospike/aerospike-server-enterprise:5.5.0.3",
+			ConfigTemplates: []string{"base"},
+			AerospikeConfig: aerospikev1alpha1.Values{
+				"namespaces": []interface{}{
+					map[string]interface{}{"name": "test", "memory-size": 2000000000},
+				},
+			},
+		},
+	}
+	applied := applyConfigTemplate(t, &aeroCluster.Spec, configTemplate(15000, 2, "exporter:1", 1))
+	appliedJSON, err := json.Marshal(applied)
+	if err != nil {
+		t.Fatal(err)
+	}
+	aeroCluster.Annotations = map[string]string{utils.ConfigTemplateAppliedAnnotation: string(appliedJSON)}
+
+	tests := []struct {
+		name     string
+		template *aerospikev1alpha1.AerospikeConfigTemplateSpec
+		want     []string
+	}{
+		{name: "unchanged", template: configTemplate(15000, 2, "exporter:1", 1), want: []string{}},
+		{name: "dynamic config and sidecar", template: configTemplate(20000, 2, "exporter:2", 1), want: []string{}},
+		{name: "replication factor", template: configTemplate(15000, 3, "exporter:1", 1), want: []string{"spec.aerospikeConfig.namespaces[0].replication-factor"}},
+		{name: "storage", template: configTemplate(15000, 2, "exporter:1", 2), want: []string{"spec.storage"}},
+	}
+
+	for _, test := range tests {
+		templates := []aerospikev1alpha1.AerospikeConfigTemplate{
+			{ObjectMeta: metav1.ObjectMeta{Name: "base", Namespace: "test"}, Spec: *test.template},
+		}
+		got := errorFields(validateConfigTemplateUpdate(pkglog, aeroCluster, templates, field.NewPath("spec")))
+		if !isValueEqual(got, test.want) {
+			t.Errorf("%s: got error fields %v, want %v", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package admission
+
+import (
+	"fmt"
+	"strings"
+
+	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
+	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/utils"
+	log "github.com/inconshreveable/log15"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ldapConfKeys are the security.ldap config keys rendered from the access control LDAP spec.
+var ldapConfKeys = []string{
+	"server", "disable-tls", "tls-ca-file", "query-base-dn", "query-user-dn", "query-user-password-file",
+	"user-dn-pattern", "user-query-pattern", "role-query-base-dn", "role-query-patterns", "role-query-search-ou",
+	"polling-period", "session-ttl",
+}
+
+// setLDAPConf renders the access control LDAP spec into the security.ldap config.
+//
+// The keys rendered from the spec replace the ones in the config, so that the config follows the spec when it changes.
+// Other security.ldap keys are kept.
+func setLDAPConf(logger log.Logger, config aerospikev1alpha1.Values, ldap *aerospikev1alpha1.AerospikeLDAPSpec) error {
+	securityConf, ok := config["security"].(map[string]interface{})
+	if !ok {
+		// Security is not enabled. Fails the access control validation.
+		return nil
+	}
+
+	ldapConf := map[string]interface{}{}
+	if current, ok := securityConf["ldap"]; ok {
+		currentConf, ok := current.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("aerospikeConfig.security.ldap not a valid map %v", current)
+		}
+		for k, v := range currentConf {
+			ldapConf[k] = v
+		}
+	}
+	for _, k := range ldapConfKeys {
+		delete(ldapConf, k)
+	}
+
+	// The server URLs are separated by spaces and tried in order.
+	ldapConf["server"] = strings.Join(ldap.Servers, " ")
+	ldapConf["query-base-dn"] = ldap.QueryBaseDN
+	ldapConf["role-query-patterns"] = toInterfaceList(ldap.RoleQueryPatterns)
+
+	if ldap.DisableTLS {
+		ldapConf["disable-tls"] = true
+	}
+	if ldap.TLSCASecretName != "" {
+		ldapConf["tls-ca-file"] = utils.LDAPTLSCAFile(ldap)
+	}
+	if ldap.QueryUserDN != "" {
+		ldapConf["query-user-dn"] = ldap.QueryUserDN
+	}
+	if ldap.QueryUserSecretName != "" {
+		ldapConf["query-user-password-file"] = utils.LDAPQueryUserPasswordFile(ldap)
+	}
+	if ldap.UserDNPattern != "" {
+		ldapConf["user-dn-pattern"] = ldap.UserDNPattern
+	}
+	if ldap.UserQueryPattern != "" {
+		ldapConf["user-query-pattern"] = ldap.UserQueryPattern
+	}
+	if ldap.RoleQueryBaseDN != "" {
+		ldapConf["role-query-base-dn"] = ldap.RoleQueryBaseDN
+	}
+	if ldap.RoleQuerySearchOU {
+		ldapConf["role-query-search-ou"] = true
+	}
+	if ldap.PollingPeriod != nil {
+		ldapConf["polling-period"] = int64(*ldap.PollingPeriod)
+	}
+	if ldap.SessionTTL != nil {
+		ldapConf["session-ttl"] = int64(*ldap.SessionTTL)
+	}
+
+	securityConf["enable-ldap"] = true
+	securityConf["ldap"] = ldapConf
+
+	logger.Info("Set access control LDAP in aerospikeConfig.security.ldap", log.Ctx{"aerospikeConfig.security.ldap": ldapConf})
+
+	return nil
+}
+
+// validateLDAPRoleMappings validates that the LDAP role mapping groups are not named after access control roles. The
+// role created for the group would replace the access control role.
+func validateLDAPRoleMappings(accessControl *aerospikev1alpha1.AerospikeAccessControlSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if accessControl == nil || accessControl.LDAP == nil {
+		return allErrs
+	}
+
+	roleNames := map[string]bool{}
+	for _, role := range accessControl.Roles {
+		roleNames[role.Name] = true
+	}
+
+	for i, roleMapping := range accessControl.LDAP.RoleMappings {
+		if roleNames[roleMapping.Group] {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("ldap", "roleMappings").Index(i).Child("group"), roleMapping.Group, "cannot be named after a role in roles"))
+		}
+	}
+	return allErrs
+}
@@ -0,0 +1,97 @@
+package admission
+
+import (
+	"reflect"
+	"testing"
+
+	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
+	log15 "github.com/inconshreveable/log15"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestSetLDAPConf(t *testing.T) {
+	pollingPeriod := 60
+	ldap := &aerospikev1alpha1.AerospikeLDAPSpec{
+		Servers:             []string{"ldaps://ldap-0.example.com", "ldaps://ldap-1.example.com"},
+		TLSCASecretName:     "ldap-ca",
+		QueryBaseDN:         "dc=example,dc=com",
+		QueryUserDN:         "cn=admin,dc=example,dc=com",
+		QueryUserSecretName: "ldap-query-user",
+		UserQueryPattern:    "(uid=${un})",
+		RoleQueryPatterns:   []string{"(&(objectClass=posixGroup)(memberUid=${un}))"},
+		PollingPeriod:       &pollingPeriod,
+	}
+	config := aerospikev1alpha1.Values{
+		"security": map[string]interface{}{
+			"enable-security": true,
+			"ldap": map[string]interface{}{
+				"token-hash-method": "sha-256",
+				"user-dn-pattern":   "uid=${un},dc=example,dc=com",
+			},
+		},
+	}
+
+	if err := setLDAPConf(log15.New(), config, ldap); err != nil {
+		t.Fatal(err)
+	}
+
+	securityConf := config["security"].(map[string]interface{})
+	if securityConf["enable-ldap"] != true {
+		t.Errorf("got enable-ldap %v, want true", securityConf["enable-ldap"])
+	}
+
+	want := map[string]interface{}{
+		"server":                   "ldaps://ldap-0.example.com ldaps://ldap-1.example.com",
+		"tls-ca-file":              "/etc/aerospike/ldap/tls-ca/ca.crt",
+		"query-base-dn":            "dc=example,dc=com",
+		"query-user-dn":            "cn=admin,dc=example,dc=com",
+		"query-user-password-file": "/etc/aerospike/ldap/query-user/password",
+		"user-query-pattern":       "(uid=${un})",
+		"role-query-patterns":      []interface{}{"(&(objectClass=posixGroup)(memberUid=${un}))"},
+		"polling-period":           int64(60),
+		"token-hash-method":        "sha-256",
+	}
+	if !reflect.DeepEqual(securityConf["ldap"], want) {
+		t.Errorf("got ldap conf %v, want %v", securityConf["ldap"], want)
+	}
+
+	// Rendering again after a spec change follows the spec.
+	ldap.PollingPeriod = nil
+	if err := setLDAPConf(log15.New(), config, ldap); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := securityConf["ldap"].(map[string]interface{})["polling-period"]; ok {
+		t.Errorf("got polling-period after it was removed from the spec")
+	}
+}
+
+func TestValidateLDAPRoleMappings(t *testing.T) {
+	accessControl := &aerospikev1alpha1.AerospikeAccessControlSpec{
+		Roles: []aerospikev1alpha1.AerospikeRoleSpec{
+			{Name: "profiler", Privileges: []string{"read"}},
+		},
+		LDAP: &aerospikev1alpha1.AerospikeLDAPSpec{
+			RoleMappings: []aerospikev1alpha1.AerospikeLDAPRoleMapping{
+				{Group: "dba", Roles: []string{"read-write"}},
+				{Group: "profiler", Roles: []string{"read-write"}},
+			},
+		},
+	}
+	fldPath := field.NewPath("spec", "aerospikeAccessControl")
+
+	errs := validateLDAPRoleMappings(accessControl, fldPath)
+	if len(errs) != 1 {
+		t.Fatalf("got errors %v, want 1 error", errs)
+	}
+	if want := "spec.aerospikeAccessControl.ldap.roleMappings[1].group"; errs[0].Field != want {
+		t.Errorf("got error field %s, want %s", errs[0].Field, want)
+	}
+
+	accessControl.LDAP.RoleMappings = accessControl.LDAP.RoleMappings[:1]
+	if errs := validateLDAPRoleMappings(accessControl, fldPath); len(errs) != 0 {
+		t.Errorf("got unexpected errors %v", errs)
+	}
+	if errs := validateLDAPRoleMappings(&aerospikev1alpha1.AerospikeAccessControlSpec{}, fldPath); len(errs) != 0 {
+		t.Errorf("got unexpected errors without LDAP %v", errs)
+	}
+}
@@ -1,8 +1,11 @@
 package admission
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
+
+	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/utils"
 )
 
 // merge (base, patch)
@@ -25,6 +28,10 @@ import (
 // 			(corresponding maps are found by matching special `name` key in maps.
 // 			Here this list of map is actually a map of map and main map keys are added in submap
 // 			with key as `name` to convert map of map to list of map).
+// - If a key in patch map has a delete directive then it will not be added in result map
+//    - a null value or a map with `$patch: delete` deletes the key
+//    - an entry with `$patch: delete` in a list of map deletes the base entry with the same `name`
+//    - directives for keys or entries not in base map are ignored
 
 func merge(base, patch map[string]interface{}) (map[string]interface{}, error) {
 	if len(patch) == 0 {
@@ -34,22 +41,26 @@ func merge(base, patch map[string]interface{}) (map[string]interface{}, error) {
 	res := map[string]interface{}{}
 
 	for key, patchValue := range patch {
+		// value was deleted
+		if key == utils.PatchDirectiveKey || utils.IsDeleteDirective(patchValue) {
+			continue
+		}
 		baseValue, ok := base[key]
 		// value was added
 		if !ok {
-			res[key] = patchValue
+			res[key] = utils.StripDirectives(patchValue)
 			continue
 		}
 		// If types have changed, replace completely
 		if reflect.TypeOf(baseValue) != reflect.TypeOf(patchValue) {
-			res[key] = patchValue
+			res[key] = utils.StripDirectives(patchValue)
 			continue
 		}
 		// Special check for typed sections "storage-engine" or "index-type"
 		// Check value type and replace if it's type has changed
 		if (key == "storage-engine" && isStorageEngineTypeChanged(baseValue, patchValue)) ||
 			(key == "index-type" && isTypeChanged(baseValue, patchValue)) {
-			res[key] = patchValue
+			res[key] = utils.StripDirectives(patchValue)
 			continue
 		}
 
@@ -157,11 +168,15 @@ func handleValues(baseValue, patchValue interface{}) (interface{}, error) {
 				}
 
 				if pName == bName {
+					found = true
+					// entry was deleted
+					if utils.IsDeleteDirective(pEle) {
+						break
+					}
 					mMap, err := merge(bEle, pEle)
 					if err != nil {
 						return nil, err
 					}
-					found = true
 					patchedList = append(patchedList, mMap)
 					break
 				}
@@ -172,6 +187,9 @@ func handleValues(baseValue, patchValue interface{}) (interface{}, error) {
 		}
 
 		for _, pEleInt := range patchValue.([]interface{}) {
+			if utils.IsDeleteDirective(pEleInt) {
+				continue
+			}
 			pName := pEleInt.(map[string]interface{})["name"]
 
 			var found bool
@@ -185,7 +203,7 @@ func handleValues(baseValue, patchValue interface{}) (interface{}, error) {
 			}
 
 			if !found {
-				patchedList = append(patchedList, pEleInt)
+				patchedList = append(patchedList, utils.StripDirectives(pEleInt))
 			}
 		}
 
@@ -194,6 +212,94 @@ func handleValues(baseValue, patchValue interface{}) (interface{}, error) {
 		panic(fmt.Sprintf("Unknown type:%T, value:%v ", baseValue, baseValue))
 	}
 }
+
+// unmerge (merged, base)
+// unmerge will create a map by removing the values of base map from the merged map recursively, i.e. the patch which
+// merged into base gives merged, for the values in merged map which are unchanged since the merge
+// - If a key/value in merged map is not in base map then it will be added in result map
+// - If a key/value in merged map is equal to the one in base map then it will not be added in result map
+// - If a key/value in merged map is changed then
+//    - if key is `storage-engine` or `index-type` then key/value from merged map will be added in result map
+//      since these are replaced completely on type change
+//    - if values are of map type then they will be recursively unmerged
+//    - if values are list of map then corresponding entries, found by matching `name` key, will be recursively
+//      unmerged and entries with only the `name` key left are removed
+//    - else key/value from merged map will be added in result map
+func unmerge(merged, base map[string]interface{}) map[string]interface{} {
+	res := map[string]interface{}{}
+
+	for key, value := range merged {
+		baseValue, ok := base[key]
+		if !ok {
+			res[key] = value
+			continue
+		}
+		if isValueEqual(value, baseValue) {
+			continue
+		}
+		if key == "storage-engine" || key == "index-type" {
+			res[key] = value
+			continue
+		}
+
+		switch vt := value.(type) {
+		case map[string]interface{}:
+			if bvt, ok := baseValue.(map[string]interface{}); ok {
+				if m := unmerge(vt, bvt); len(m) != 0 {
+					res[key] = m
+				}
+				continue
+			}
+
+		case []interface{}:
+			if bvt, ok := baseValue.([]interface{}); ok && isMapList(vt) && isMapList(bvt) {
+				if l := unmergeList(vt, bvt); len(l) != 0 {
+					res[key] = l
+				}
+				continue
+			}
+		}
+		res[key] = value
+	}
+	return res
+}
+
+// unmergeList unmerges the corresponding entries of name keyed lists of map.
+func unmergeList(merged, base []interface{}) []interface{} {
+	var res []interface{}
+
+	for _, mEleInt := range merged {
+		mEle := mEleInt.(map[string]interface{})
+		name := mEle["name"]
+
+		var bEle map[string]interface{}
+		for _, bEleInt := range base {
+			if bEleInt.(map[string]interface{})["name"] == name {
+				bEle = bEleInt.(map[string]interface{})
+				break
+			}
+		}
+		if bEle == nil {
+			res = append(res, mEle)
+			continue
+		}
+
+		// name is equal and removed, add it back to identify the entry.
+		if m := unmerge(mEle, bEle); len(m) != 0 {
+			m["name"] = name
+			res = append(res, m)
+		}
+	}
+	return res
+}
+
+// isValueEqual compares values by their json, numbers decoded from json may have different types.
+func isValueEqual(value1, value2 interface{}) bool {
+	json1, err1 := json.Marshal(value1)
+	json2, err2 := json.Marshal(value2)
+	return err1 == nil && err2 == nil && string(json1) == string(json2)
+}
+
 func isPrimList(list []interface{}) bool {
 	for _, e := range list {
 		switch e.(type) {
@@ -378,11 +378,109 @@ var mergedMap4 = map[string]interface{}{
 	},
 }
 
+// delete directives
+var baseMap5 = map[string]interface{}{
+	"service": map[string]interface{}{
+		"proto-fd-max":     15000,
+		"feature-key-file": "features.conf",
+	},
+	"logging": []interface{}{
+		map[string]interface{}{
+			"name": "console",
+			"any":  "info",
+		},
+		map[string]interface{}{
+			"name": "/var/log/aerospike.log",
+			"any":  "info",
+		},
+	},
+	"namespaces": []interface{}{
+		map[string]interface{}{
+			"name":               "test",
+			"replication-factor": 2,
+			"storage-engine": map[string]interface{}{
+				"type":           "device",
+				"files":          []interface{}{"test.dat"},
+				"data-in-memory": true,
+			},
+		},
+		map[string]interface{}{
+			"name":               "bar",
+			"replication-factor": 2,
+		},
+	},
+}
+var patchMap5 = map[string]interface{}{
+	"service": map[string]interface{}{
+		"proto-fd-max": nil,
+	},
+	"logging": []interface{}{
+		map[string]interface{}{
+			"name":   "/var/log/aerospike.log",
+			"$patch": "delete",
+		},
+		map[string]interface{}{
+			"name":   "/var/log/missing.log",
+			"$patch": "delete",
+		},
+	},
+	"namespaces": []interface{}{
+		map[string]interface{}{
+			"name": "test",
+			"storage-engine": map[string]interface{}{
+				"type":           "device",
+				"data-in-memory": nil,
+			},
+		},
+		map[string]interface{}{
+			"name":   "bar",
+			"$patch": "delete",
+		},
+	},
+	"xdr": map[string]interface{}{
+		"$patch": "delete",
+	},
+	"network": map[string]interface{}{
+		"service": map[string]interface{}{
+			"port":                    3000,
+			"access-port":             nil,
+			"tls-authenticate-client": map[string]interface{}{"$patch": "delete"},
+		},
+	},
+}
+var mergedMap5 = map[string]interface{}{
+	"service": map[string]interface{}{
+		"feature-key-file": "features.conf",
+	},
+	"logging": []interface{}{
+		map[string]interface{}{
+			"name": "console",
+			"any":  "info",
+		},
+	},
+	"namespaces": []interface{}{
+		map[string]interface{}{
+			"name":               "test",
+			"replication-factor": 2,
+			"storage-engine": map[string]interface{}{
+				"type":  "device",
+				"files": []interface{}{"test.dat"},
+			},
+		},
+	},
+	"network": map[string]interface{}{
+		"service": map[string]interface{}{
+			"port": 3000,
+		},
+	},
+}
+
 func TestConfigMerge(t *testing.T) {
 	mergeAndCheck(t, baseMap1, patchMap1, mergedMap1)
 	mergeAndCheck(t, baseMap2, patchMap2, mergedMap2)
 	mergeAndCheck(t, baseMap3, patchMap3, mergedMap3)
 	mergeAndCheck(t, baseMap4, patchMap4, mergedMap4)
+	mergeAndCheck(t, baseMap5, patchMap5, mergedMap5)
 }
 
 func mergeAndCheck(t *testing.T, baseM, patchM, mergedM map[string]interface{}) {
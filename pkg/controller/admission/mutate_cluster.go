@@ -7,6 +7,7 @@ import (
 	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/utils"
 	log "github.com/inconshreveable/log15"
 	av1beta1 "k8s.io/api/admission/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
@@ -15,10 +16,12 @@ import (
 type ClusterMutatingAdmissionWebhook struct {
 	obj    aerospikev1alpha1.AerospikeCluster
 	logger log.Logger
+	// client reads the config templates and the aerospike.conf file ConfigMap.
+	client client.Reader
 }
 
 // MutateAerospikeCluster mutate cluster operation
-func MutateAerospikeCluster(req webhook.AdmissionRequest) webhook.AdmissionResponse {
+func MutateAerospikeCluster(k8sClient client.Reader, req webhook.AdmissionRequest) webhook.AdmissionResponse {
 
 	decoder, _ := admission.NewDecoder(scheme)
 
@@ -33,6 +36,7 @@ func MutateAerospikeCluster(req webhook.AdmissionRequest) webhook.AdmissionRespo
 	s := ClusterMutatingAdmissionWebhook{
 		obj:    *newAeroCluster,
 		logger: logger,
+		client: k8sClient,
 	}
 
 	// mutate the new AerospikeCluster
@@ -76,7 +80,7 @@ func (s *ClusterMutatingAdmissionWebhook) MutateCreate() webhook.AdmissionRespon
 		return webhook.Denied(err.Error())
 	}
 
-	return webhook.Patched("Patched aerospike spec with defaults", webhook.JSONPatchOp{Operation: "replace", Path: "/spec", Value: s.obj.Spec})
+	return webhook.Patched("Patched aerospike spec with defaults", s.patches()...)
 }
 
 // MutateUpdate mutate update
@@ -89,12 +93,31 @@ func (s *ClusterMutatingAdmissionWebhook) MutateUpdate(old aerospikev1alpha1.Aer
 		return webhook.Denied(err.Error())
 	}
 
-	return webhook.Patched("Patched aerospike spec with updated spec", webhook.JSONPatchOp{Operation: "replace", Path: "/spec", Value: s.obj.Spec})
+	return webhook.Patched("Patched aerospike spec with updated spec", s.patches()...)
+}
+
+// patches returns the json patches for the mutated spec and annotations.
+func (s *ClusterMutatingAdmissionWebhook) patches() []webhook.JSONPatchOp {
+	patches := []webhook.JSONPatchOp{{Operation: "replace", Path: "/spec", Value: s.obj.Spec}}
+	if s.obj.Annotations != nil {
+		patches = append(patches, webhook.JSONPatchOp{Operation: "add", Path: "/metadata/annotations", Value: s.obj.Annotations})
+	}
+	return patches
 }
 
 func (s *ClusterMutatingAdmissionWebhook) setDefaults() error {
 	s.logger.Info("Set defaults for AerospikeCluster", log.Ctx{"obj.Spec": s.obj.Spec})
 
+	// Parse the aerospike.conf file before merging the config templates into the parsed config.
+	if err := s.applyConfigFile(); err != nil {
+		return err
+	}
+
+	// Merge config templates before the defaults and the racks since they use the merged spec.
+	if err := s.applyConfigTemplates(); err != nil {
+		return err
+	}
+
 	// Set network defaults
 	s.obj.Spec.AerospikeNetworkPolicy.SetDefaults()
 
@@ -209,15 +232,29 @@ func (s *ClusterMutatingAdmissionWebhook) updateRacksAerospikeConfigFromGlobal()
 		if err := s.setDefaultAerospikeConfigs(m); err != nil {
 			return err
 		}
+
+		// Resolve the relative sizes with the rack storage.
+		m, err = resolveRelativeSizes(m, &s.obj.Spec.RackConfig.Racks[i].Storage, s.obj.Spec.Resources)
+		if err != nil {
+			return fmt.Errorf("Failed to resolve rack %d aerospikeConfig sizes: %v", rack.ID, err)
+		}
 		s.obj.Spec.RackConfig.Racks[i].AerospikeConfig = m
 	}
 	return nil
 }
 
 func (s *ClusterMutatingAdmissionWebhook) setDefaultAerospikeConfigs(config aerospikev1alpha1.Values) error {
+	version, err := getImageVersion(s.obj.Spec.Image)
+	if err != nil {
+		return err
+	}
+	layout, err := getConfigLayout(version)
+	if err != nil {
+		return err
+	}
 
 	// namespace conf
-	if err := setDefaultNsConf(s.logger, config, s.obj.Spec.RackConfig.Namespaces); err != nil {
+	if err := setDefaultNsConf(s.logger, layout, config, s.obj.Spec.RackConfig.Namespaces); err != nil {
 		return err
 	}
 
@@ -231,6 +268,20 @@ func (s *ClusterMutatingAdmissionWebhook) setDefaultAerospikeConfigs(config aero
 		return err
 	}
 
+	// tls conf
+	if s.obj.Spec.CertManager != nil {
+		if err := setDefaultTLSConf(s.logger, config); err != nil {
+			return err
+		}
+	}
+
+	// ldap conf
+	if ldap := utils.GetLDAPSpec(&s.obj.Spec); ldap != nil {
+		if err := setLDAPConf(s.logger, config, ldap); err != nil {
+			return err
+		}
+	}
+
 	// logging conf
 	if err := setDefaultLoggingConf(s.logger, config); err != nil {
 		return err
@@ -9,7 +9,7 @@ import (
 	log "github.com/inconshreveable/log15"
 )
 
-func setDefaultNsConf(logger log.Logger, config aerospikev1alpha1.Values, rackEnabledNsList []string) error {
+func setDefaultNsConf(logger log.Logger, layout *configLayout, config aerospikev1alpha1.Values, rackEnabledNsList []string) error {
 	// namespace conf
 	nsConf, ok := config["namespaces"]
 	if !ok {
@@ -51,12 +51,35 @@ func setDefaultNsConf(logger log.Logger, config aerospikev1alpha1.Values, rackEn
 			}
 		}
 		// If namespace map doesn't have valid name, it will fail in validation layer
+
+		if !layout.namespaceMemorySize {
+			setDefaultNsMemoryConf(logger, nsMap)
+		}
 	}
 	logger.Info("Set default template values in aerospikeConfig.namespace")
 
 	return nil
 }
 
+// setDefaultNsMemoryConf moves the memory-size of an in-memory namespace to the storage-engine data-size, for versions
+// where namespace memory is configured in storage-engine memory. Other uses of memory-size fail in validation.
+func setDefaultNsMemoryConf(logger log.Logger, nsMap map[string]interface{}) {
+	memorySize, ok := nsMap["memory-size"]
+	if !ok || !isInMemoryNamespace(nsMap) {
+		return
+	}
+
+	nsStorage := nsMap["storage-engine"].(map[string]interface{})
+	if _, ok := nsStorage["data-size"]; ok {
+		return
+	}
+
+	logger.Info("Set aerospikeConfig.namespaces storage-engine data-size from memory-size", log.Ctx{"nsName": nsMap["name"], "memory-size": memorySize})
+
+	nsStorage["data-size"] = memorySize
+	delete(nsMap, "memory-size")
+}
+
 func setDefaultServiceConf(logger log.Logger, config aerospikev1alpha1.Values, crObjName string) error {
 	if _, ok := config["service"]; !ok {
 		config["service"] = map[string]interface{}{}
@@ -0,0 +1,71 @@
+package admission
+
+import (
+	"net"
+
+	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// validateKubernetesNetworkPolicy validates the sources of the NetworkPolicies created by the operator, so that a
+// cluster is not admitted with NetworkPolicies the API server rejects.
+func validateKubernetesNetworkPolicy(networkPolicy *aerospikev1alpha1.AerospikeKubernetesNetworkPolicySpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if networkPolicy == nil {
+		return allErrs
+	}
+
+	for i := range networkPolicy.ServiceFrom {
+		allErrs = append(allErrs, validateNetworkPolicyPeer(&networkPolicy.ServiceFrom[i], fldPath.Child("serviceFrom").Index(i))...)
+	}
+	if networkPolicy.OperatorFrom != nil {
+		allErrs = append(allErrs, validateNetworkPolicyPeer(networkPolicy.OperatorFrom, fldPath.Child("operatorFrom"))...)
+	}
+	return allErrs
+}
+
+// validateNetworkPolicyPeer validates a NetworkPolicy peer like the API server.
+func validateNetworkPolicyPeer(peer *networkingv1.NetworkPolicyPeer, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	selectors := 0
+	if peer.PodSelector != nil {
+		selectors++
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(peer.PodSelector, fldPath.Child("podSelector"))...)
+	}
+	if peer.NamespaceSelector != nil {
+		selectors++
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(peer.NamespaceSelector, fldPath.Child("namespaceSelector"))...)
+	}
+
+	if peer.IPBlock == nil {
+		if selectors == 0 {
+			allErrs = append(allErrs, field.Required(fldPath, "must specify a podSelector, namespaceSelector or ipBlock"))
+		}
+		return allErrs
+	}
+
+	if selectors != 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "ipBlock cannot be combined with podSelector or namespaceSelector"))
+	}
+	ipBlockPath := fldPath.Child("ipBlock")
+	_, cidr, err := net.ParseCIDR(peer.IPBlock.CIDR)
+	if err != nil {
+		return append(allErrs, field.Invalid(ipBlockPath.Child("cidr"), peer.IPBlock.CIDR, "must be a valid CIDR"))
+	}
+	for i, except := range peer.IPBlock.Except {
+		exceptIP, exceptCIDR, err := net.ParseCIDR(except)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(ipBlockPath.Child("except").Index(i), except, "must be a valid CIDR"))
+			continue
+		}
+		cidrMaskLen, _ := cidr.Mask.Size()
+		exceptMaskLen, _ := exceptCIDR.Mask.Size()
+		if !cidr.Contains(exceptIP) || cidrMaskLen >= exceptMaskLen {
+			allErrs = append(allErrs, field.Invalid(ipBlockPath.Child("except").Index(i), except, "must be a strict subset of cidr"))
+		}
+	}
+	return allErrs
+}
@@ -0,0 +1,78 @@
+package admission
+
+import (
+	"reflect"
+	"testing"
+
+	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestValidateKubernetesNetworkPolicy(t *testing.T) {
+	appSelector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}
+
+	tests := []struct {
+		name          string
+		networkPolicy *aerospikev1alpha1.AerospikeKubernetesNetworkPolicySpec
+		want          []string
+	}{
+		{name: "no network policy", want: []string{}},
+		{
+			name: "valid",
+			networkPolicy: &aerospikev1alpha1.AerospikeKubernetesNetworkPolicySpec{
+				ServiceFrom: []networkingv1.NetworkPolicyPeer{
+					{PodSelector: appSelector, NamespaceSelector: &metav1.LabelSelector{}},
+					{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/16", Except: []string{"10.0.1.0/24"}}},
+				},
+				OperatorFrom: &networkingv1.NetworkPolicyPeer{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"name": "aerospike"}}},
+			},
+			want: []string{},
+		},
+		{
+			name: "empty peer",
+			networkPolicy: &aerospikev1alpha1.AerospikeKubernetesNetworkPolicySpec{
+				ServiceFrom: []networkingv1.NetworkPolicyPeer{{PodSelector: appSelector}, {}},
+			},
+			want: []string{"spec.kubernetesNetworkPolicy.serviceFrom[1]"},
+		},
+		{
+			name: "ip block with selector",
+			networkPolicy: &aerospikev1alpha1.AerospikeKubernetesNetworkPolicySpec{
+				OperatorFrom: &networkingv1.NetworkPolicyPeer{PodSelector: appSelector, IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/16"}},
+			},
+			want: []string{"spec.kubernetesNetworkPolicy.operatorFrom"},
+		},
+		{
+			name: "invalid ip blocks",
+			networkPolicy: &aerospikev1alpha1.AerospikeKubernetesNetworkPolicySpec{
+				ServiceFrom: []networkingv1.NetworkPolicyPeer{
+					{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0"}},
+					{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/16", Except: []string{"10.1.0.0/24", "10.0.0.0/16"}}},
+				},
+			},
+			want: []string{
+				"spec.kubernetesNetworkPolicy.serviceFrom[0].ipBlock.cidr",
+				"spec.kubernetesNetworkPolicy.serviceFrom[1].ipBlock.except[0]",
+				"spec.kubernetesNetworkPolicy.serviceFrom[1].ipBlock.except[1]",
+			},
+		},
+		{
+			name: "invalid selector",
+			networkPolicy: &aerospikev1alpha1.AerospikeKubernetesNetworkPolicySpec{
+				ServiceFrom: []networkingv1.NetworkPolicyPeer{
+					{NamespaceSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: metav1.LabelSelectorOpIn}}}},
+				},
+			},
+			want: []string{"spec.kubernetesNetworkPolicy.serviceFrom[0].namespaceSelector.matchExpressions[0].values"},
+		},
+	}
+
+	for _, test := range tests {
+		errs := validateKubernetesNetworkPolicy(test.networkPolicy, field.NewPath("spec", "kubernetesNetworkPolicy"))
+		if got := errorFields(errs); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got error fields %v, want %v", test.name, got, test.want)
+		}
+	}
+}
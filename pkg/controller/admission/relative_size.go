@@ -0,0 +1,147 @@
+package admission
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+
+	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// Relative sizes are aerospikeConfig sizes given as a percentage of the aerospike container memory, e.g. "60%memory",
+// or of the storage volume of the namespace files or index mounts, e.g. "90%volume". They are resolved in the effective
+// rack configs, since racks can have their own storage, and are resolved again when the resources or storage change.
+var relativeSizeRe = regexp.MustCompile(`^(\d+(?:\.\d+)?)%(memory|volume)$`)
+
+const (
+	relativeToMemory = "memory"
+	relativeToVolume = "volume"
+
+	// relativeSizeAlignment is the alignment of the resolved relative sizes.
+	relativeSizeAlignment = 1 << 20
+)
+
+// resolveRelativeSizes returns a copy of the config with the relative sizes resolved to bytes, rounded down to a MiB.
+// The namespace memory-size and storage-engine data-size can be relative to memory, the storage-engine filesize to the
+// volume of the namespace files and the index-type mounts-size-limit and mounts-budget to the volume of the mounts.
+func resolveRelativeSizes(config aerospikev1alpha1.Values, storage *aerospikev1alpha1.AerospikeStorageSpec, resources *corev1.ResourceRequirements) (aerospikev1alpha1.Values, error) {
+	nsList, ok := config["namespaces"].([]interface{})
+	if !ok {
+		return config, nil
+	}
+
+	res := aerospikev1alpha1.Values{}
+	for k, v := range config {
+		res[k] = v
+	}
+
+	resolvedNsList := make([]interface{}, 0, len(nsList))
+	for _, nsInterface := range nsList {
+		nsConf, ok := nsInterface.(map[string]interface{})
+		if !ok {
+			resolvedNsList = append(resolvedNsList, nsInterface)
+			continue
+		}
+		nsConf = copyMap(nsConf)
+
+		if err := resolveRelativeSize(nsConf, "memory-size", relativeToMemory, nil, storage, resources); err != nil {
+			return nil, fmt.Errorf("namespace %v: %v", nsConf["name"], err)
+		}
+
+		if nsStorage, ok := nsConf["storage-engine"].(map[string]interface{}); ok {
+			nsStorage = copyMap(nsStorage)
+			var fileDirs []string
+			files, _ := nsStorage["files"].([]interface{})
+			for _, file := range files {
+				fileDirs = append(fileDirs, filepath.Dir(fmt.Sprintf("%v", file)))
+			}
+			if err := resolveRelativeSize(nsStorage, "data-size", relativeToMemory, nil, storage, resources); err != nil {
+				return nil, fmt.Errorf("namespace %v storage-engine: %v", nsConf["name"], err)
+			}
+			if err := resolveRelativeSize(nsStorage, "filesize", relativeToVolume, fileDirs, storage, resources); err != nil {
+				return nil, fmt.Errorf("namespace %v storage-engine: %v", nsConf["name"], err)
+			}
+			nsConf["storage-engine"] = nsStorage
+		}
+
+		if indexType, ok := nsConf["index-type"].(map[string]interface{}); ok {
+			indexType = copyMap(indexType)
+			var mountDirs []string
+			mounts, _ := indexType["mounts"].([]interface{})
+			for _, mount := range mounts {
+				mountDirs = append(mountDirs, fmt.Sprintf("%v", mount))
+			}
+			for _, key := range []string{"mounts-size-limit", "mounts-budget"} {
+				if err := resolveRelativeSize(indexType, key, relativeToVolume, mountDirs, storage, resources); err != nil {
+					return nil, fmt.Errorf("namespace %v index-type: %v", nsConf["name"], err)
+				}
+			}
+			nsConf["index-type"] = indexType
+		}
+
+		resolvedNsList = append(resolvedNsList, nsConf)
+	}
+	res["namespaces"] = resolvedNsList
+	return res, nil
+}
+
+// resolveRelativeSize resolves the relative size of the key in conf. Sizes relative to the volume use the smallest
+// volume of the dirs.
+func resolveRelativeSize(conf map[string]interface{}, key, relativeTo string, dirs []string, storage *aerospikev1alpha1.AerospikeStorageSpec, resources *corev1.ResourceRequirements) error {
+	value, ok := conf[key].(string)
+	if !ok {
+		return nil
+	}
+	match := relativeSizeRe.FindStringSubmatch(value)
+	if match == nil {
+		return nil
+	}
+	if match[2] != relativeTo {
+		return fmt.Errorf("%s %s can only be relative to %s", key, value, relativeTo)
+	}
+	percent, _ := strconv.ParseFloat(match[1], 64)
+	if percent > 100 {
+		return fmt.Errorf("%s %s is more than 100%%", key, value)
+	}
+
+	var total *resource.Quantity
+	switch relativeTo {
+	case relativeToMemory:
+		if total = getContainerMemory(resources); total == nil {
+			return fmt.Errorf("%s %s needs a memory limit or request in resources", key, value)
+		}
+
+	case relativeToVolume:
+		for _, dir := range dirs {
+			volume := getVolumeForDir(storage, dir)
+			if volume == nil {
+				return fmt.Errorf("%s %s needs a filesystem storage volume for %s", key, value, dir)
+			}
+			size := getVolumeSize(volume)
+			if size == nil {
+				return fmt.Errorf("%s %s needs a sized storage volume for %s", key, value, dir)
+			}
+			if total == nil || size.Cmp(*total) < 0 {
+				total = size
+			}
+		}
+		if total == nil {
+			return fmt.Errorf("%s %s needs files or mounts on a storage volume", key, value)
+		}
+	}
+
+	size := int64(float64(total.Value()) * percent / 100)
+	conf[key] = size - size%relativeSizeAlignment
+	return nil
+}
+
+func copyMap(m map[string]interface{}) map[string]interface{} {
+	res := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		res[k] = v
+	}
+	return res
+}
@@ -0,0 +1,71 @@
+package admission
+
+import (
+	"testing"
+
+	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestResolveRelativeSizes(t *testing.T) {
+	resources := &corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+		Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+	}
+	ns := fileNamespace("test", "50%volume", "/opt/aerospike/data/test.dat")
+	ns["memory-size"] = "25%memory"
+	config := aerospikev1alpha1.Values{"namespaces": []interface{}{ns}}
+
+	resolved, err := resolveRelativeSizes(config, &sizeStorage, resources)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resolvedNs := resolved["namespaces"].([]interface{})[0].(map[string]interface{})
+	if resolvedNs["memory-size"] != int64(1<<30) {
+		t.Errorf("got memory-size %v, want 25%% of the memory limit %d", resolvedNs["memory-size"], 1<<30)
+	}
+	if filesize := resolvedNs["storage-engine"].(map[string]interface{})["filesize"]; filesize != int64(1<<30) {
+		t.Errorf("got filesize %v, want 50%% of the data volume %d", filesize, 1<<30)
+	}
+
+	// The input config keeps the relative sizes.
+	if ns["memory-size"] != "25%memory" || ns["storage-engine"].(map[string]interface{})["filesize"] != "50%volume" {
+		t.Errorf("input config changed %v", ns)
+	}
+
+	// Resolved sizes are rounded down to a MiB.
+	config = aerospikev1alpha1.Values{"namespaces": []interface{}{map[string]interface{}{"name": "test", "memory-size": "33.3%memory"}}}
+	resolved, err = resolveRelativeSizes(config, &sizeStorage, resources)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if memorySize := resolved["namespaces"].([]interface{})[0].(map[string]interface{})["memory-size"].(int64); memorySize%(1<<20) != 0 || memorySize > 4<<30/3 {
+		t.Errorf("got memory-size %d, want 33.3%% of 4Gi rounded down to a MiB", memorySize)
+	}
+}
+
+func TestResolveRelativeSizesErrors(t *testing.T) {
+	resources := &corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+	}
+
+	tests := map[string]map[string]interface{}{
+		"memory-size relative to volume": {"name": "test", "memory-size": "50%volume"},
+		"more than 100%":                 {"name": "test", "memory-size": "150%memory"},
+		"filesize relative to memory":    fileNamespace("test", "50%memory", "/opt/aerospike/data/test.dat"),
+		"file outside volumes":           fileNamespace("test", "50%volume", "/tmp/test.dat"),
+	}
+	for name, ns := range tests {
+		config := aerospikev1alpha1.Values{"namespaces": []interface{}{ns}}
+		if _, err := resolveRelativeSizes(config, &sizeStorage, resources); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+
+	config := aerospikev1alpha1.Values{"namespaces": []interface{}{map[string]interface{}{"name": "test", "memory-size": "50%memory"}}}
+	if _, err := resolveRelativeSizes(config, &sizeStorage, nil); err == nil {
+		t.Errorf("no resources: expected error")
+	}
+}
@@ -29,11 +29,13 @@ var (
 )
 
 const (
-	aerospikeCluster = "aerospikeclusters"
+	aerospikeCluster        = "aerospikeclusters"
+	aerospikeConfigTemplate = "aerospikeconfigtemplates"
 )
 
 var (
-	aerospikeClusterCRDName = fmt.Sprintf("%s.%s", aerospikeCluster, aerospikev1alpha1.SchemeGroupVersion.Group)
+	aerospikeClusterCRDName        = fmt.Sprintf("%s.%s", aerospikeCluster, aerospikev1alpha1.SchemeGroupVersion.Group)
+	aerospikeConfigTemplateCRDName = fmt.Sprintf("%s.%s", aerospikeConfigTemplate, aerospikev1alpha1.SchemeGroupVersion.Group)
 )
 
 var (
@@ -41,8 +43,10 @@ var (
 	AerospikeClusterValidationWebhookPath = "/admission/reviews/aerospikeclusters/validating"
 	// AerospikeClusterMutationWebhookPath mutation webhook path
 	AerospikeClusterMutationWebhookPath = "/admission/reviews/aerospikeclusters/mutating"
-	aerospikeOperatorWebhookName        = fmt.Sprintf("aerospike-cluster-webhook.%s", aerospikeGroupName)
-	failurePolicy                       = admissionregistrationv1beta1.Fail
+	// AerospikeConfigTemplateValidationWebhookPath config template validation webhook path
+	AerospikeConfigTemplateValidationWebhookPath = "/admission/reviews/aerospikeconfigtemplates/validating"
+	aerospikeOperatorWebhookName                 = fmt.Sprintf("aerospike-cluster-webhook.%s", aerospikeGroupName)
+	failurePolicy                                = admissionregistrationv1beta1.Fail
 )
 
 const (
@@ -113,3 +117,13 @@ func SetupSecret(certDir, namespace string) error {
 	}
 	return nil
 }
+
+// fieldValue returns the value to report in a field error. Maps and lists are reported by their type to keep the
+// error short.
+func fieldValue(value interface{}) interface{} {
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		return fmt.Sprintf("%T", value)
+	}
+	return value
+}
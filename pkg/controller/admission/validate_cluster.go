@@ -7,11 +7,16 @@ import (
 
 	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
 	accessControl "github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/asconfig"
+	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/configschema"
+	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/featurekey"
 	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/utils"
 	"github.com/aerospike/aerospike-management-lib/asconfig"
 	"github.com/aerospike/aerospike-management-lib/deployment"
 	log "github.com/inconshreveable/log15"
 	av1beta1 "k8s.io/api/admission/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
@@ -19,11 +24,12 @@ import (
 // ClusterValidatingAdmissionWebhook admission validation webhook
 type ClusterValidatingAdmissionWebhook struct {
 	obj    aerospikev1alpha1.AerospikeCluster
+	client client.Reader
 	logger log.Logger
 }
 
-// ValidateAerospikeCluster validate cluster operation
-func ValidateAerospikeCluster(req webhook.AdmissionRequest) webhook.AdmissionResponse {
+// ValidateAerospikeCluster validate cluster operation. The client is used to read the kubernetes nodes.
+func ValidateAerospikeCluster(k8sClient client.Reader, req webhook.AdmissionRequest) webhook.AdmissionResponse {
 
 	decoder, _ := admission.NewDecoder(scheme)
 
@@ -37,189 +43,286 @@ func ValidateAerospikeCluster(req webhook.AdmissionRequest) webhook.AdmissionRes
 
 	s := ClusterValidatingAdmissionWebhook{
 		obj:    *newAeroCluster,
+		client: k8sClient,
 		logger: logger,
 	}
 
 	// validate the new AerospikeCluster
 	if req.Operation == av1beta1.Create {
-		err := s.ValidateCreate()
-		if err != nil {
-			s.logger.Error("Validate AerospikeCluster create failed", log.Ctx{"err": err})
-			return webhook.Denied(err.Error())
+		if allErrs := s.ValidateCreate(); len(allErrs) != 0 {
+			s.logger.Error("Validate AerospikeCluster create failed", log.Ctx{"err": allErrs.ToAggregate()})
+			return invalidResponse("AerospikeCluster", newAeroCluster.Name, allErrs)
 		}
 	}
 
 	// if this is an update, validate that the transition from old to new
 	if req.Operation == av1beta1.Update {
-		err := s.ValidateUpdate(*oldAeroCluster)
-		if err != nil {
-			s.logger.Error("Validate AerospikeCluster update failed", log.Ctx{"err": err})
-			return webhook.Denied(err.Error())
+		if allErrs := s.ValidateUpdate(*oldAeroCluster); len(allErrs) != 0 {
+			s.logger.Error("Validate AerospikeCluster update failed", log.Ctx{"err": allErrs.ToAggregate()})
+			return invalidResponse("AerospikeCluster", newAeroCluster.Name, allErrs)
 		}
 	}
 	return webhook.Allowed("Validation passed. No create or update")
 }
 
+// invalidResponse denies the request with an Invalid status listing all the field errors, like the api server does for
+// built in types.
+func invalidResponse(kind string, name string, allErrs field.ErrorList) webhook.AdmissionResponse {
+	groupKind := aerospikev1alpha1.SchemeGroupVersion.WithKind(kind).GroupKind()
+	status := apierrors.NewInvalid(groupKind, name, allErrs).ErrStatus
+	return webhook.AdmissionResponse{
+		AdmissionResponse: av1beta1.AdmissionResponse{
+			Allowed: false,
+			Result:  &status,
+		},
+	}
+}
+
 // ValidateCreate validate create
-func (s *ClusterValidatingAdmissionWebhook) ValidateCreate() error {
+func (s *ClusterValidatingAdmissionWebhook) ValidateCreate() field.ErrorList {
 	s.logger.Info("Validate AerospikeCluster create")
 
-	return s.validate()
+	allErrs := s.validate()
+	specPath := field.NewPath("spec")
+
+	// Namespace memory is summed across the namespaces, only check it for a valid spec.
+	if len(allErrs) == 0 {
+		allErrs = append(allErrs, s.validateNamespaceMemory(specPath)...)
+	}
+
+	// Validate that the kubernetes nodes can host the pods
+	return append(allErrs, s.validateScheduling(specPath)...)
 }
 
 // ValidateUpdate validate update
-func (s *ClusterValidatingAdmissionWebhook) ValidateUpdate(old aerospikev1alpha1.AerospikeCluster) error {
+func (s *ClusterValidatingAdmissionWebhook) ValidateUpdate(old aerospikev1alpha1.AerospikeCluster) field.ErrorList {
 	s.logger.Info("Validate AerospikeCluster update")
-	if err := s.validate(); err != nil {
-		return err
+
+	allErrs := s.validate()
+	specPath := field.NewPath("spec")
+
+	// Validate the namespace memory only when it may have changed, so that clusters created before this check can still
+	// be updated.
+	if len(allErrs) == 0 && isNamespaceMemoryChanged(s.obj.Spec, old.Spec) {
+		allErrs = append(allErrs, s.validateNamespaceMemory(specPath)...)
 	}
 
-	// Jump version should not be allowed
-	newVersion := strings.Split(s.obj.Spec.Image, ":")[1]
+	// Jump version should not be allowed. An invalid new image version is reported by validate.
+	newVersion, _ := getImageVersion(s.obj.Spec.Image)
 	oldVersion := ""
 
 	if old.Spec.Image != "" {
-		oldVersion = strings.Split(old.Spec.Image, ":")[1]
+		oldVersion, _ = getImageVersion(old.Spec.Image)
 	}
-	if err := deployment.IsValidUpgrade(oldVersion, newVersion); err != nil {
-		return fmt.Errorf("Failed to start upgrade: %v", err)
+	if newVersion != "" {
+		if err := deployment.IsValidUpgrade(oldVersion, newVersion); err != nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("image"), s.obj.Spec.Image, fmt.Sprintf("Failed to start upgrade: %v", err)))
+		}
 	}
 
 	// Volume storage update is not allowed but cascadeDelete policy is allowed
 	if err := old.Spec.Storage.ValidateStorageSpecChange(s.obj.Spec.Storage); err != nil {
-		return fmt.Errorf("Storage config cannot be updated: %v", err)
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("storage"), fmt.Sprintf("Storage config cannot be updated: %v", err)))
 	}
 
 	// MultiPodPerHost can not be updated
 	if s.obj.Spec.MultiPodPerHost != old.Spec.MultiPodPerHost {
-		return fmt.Errorf("Cannot update MultiPodPerHost setting")
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("multiPodPerHost"), "cannot be updated"))
 	}
 
-	// Validate AerospikeConfig update
-	if err := validateAerospikeConfigUpdate(s.logger, s.obj.Spec.AerospikeConfig, old.Spec.AerospikeConfig); err != nil {
-		return err
+	// RestoreFromSnapshot only applies to new clusters
+	if s.obj.Spec.RestoreFromSnapshot != old.Spec.RestoreFromSnapshot {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("restoreFromSnapshot"), "cannot be updated"))
 	}
 
+	// Validate AerospikeConfig update
+	allErrs = append(allErrs, validateAerospikeConfigUpdate(s.logger, newVersion, oldVersion, s.obj.Spec.AerospikeConfig, old.Spec.AerospikeConfig, specPath.Child("aerospikeConfig"))...)
+
 	// Validate RackConfig update
-	if err := s.validateRackUpdate(old); err != nil {
-		return err
-	}
+	allErrs = append(allErrs, s.validateRackUpdate(old, newVersion, oldVersion, specPath.Child("rackConfig"))...)
 
 	// Validate changes to pod spec
 	if err := old.Spec.PodSpec.ValidatePodSpecChange(s.obj.Spec.PodSpec); err != nil {
-		return err
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("podSpec"), err.Error()))
 	}
 
-	return nil
+	// Validate that the kubernetes nodes can host the pods. Skipped for other updates so that they are not blocked by
+	// nodes removed after the cluster was placed.
+	if isRackPlacementChanged(s.obj.Spec, old.Spec) {
+		allErrs = append(allErrs, s.validateScheduling(specPath)...)
+	}
+
+	return allErrs
 }
 
-func (s *ClusterValidatingAdmissionWebhook) validate() error {
+func (s *ClusterValidatingAdmissionWebhook) validate() field.ErrorList {
 	s.logger.Debug("Validate AerospikeCluster spec", log.Ctx{"obj.Spec": s.obj.Spec})
 
+	allErrs := field.ErrorList{}
+	specPath := field.NewPath("spec")
+
 	// Validate obj name
+	namePath := field.NewPath("metadata", "name")
 	if s.obj.Name == "" {
-		return fmt.Errorf("AerospikeCluster name cannot be empty")
+		allErrs = append(allErrs, field.Required(namePath, "AerospikeCluster name cannot be empty"))
 	}
 	if strings.Contains(s.obj.Name, " ") {
 		// Few parsing logic depend on this
-		return fmt.Errorf("AerospikeCluster name cannot have spaces")
+		allErrs = append(allErrs, field.Invalid(namePath, s.obj.Name, "cannot have spaces"))
 	}
 
 	// Validate obj namespace
+	namespacePath := field.NewPath("metadata", "namespace")
 	if s.obj.Namespace == "" {
-		return fmt.Errorf("AerospikeCluster namespace name cannot be empty")
+		allErrs = append(allErrs, field.Required(namespacePath, "AerospikeCluster namespace name cannot be empty"))
 	}
 	if strings.Contains(s.obj.Namespace, " ") {
 		// Few parsing logic depend on this
-		return fmt.Errorf("AerospikeCluster name cannot have spaces")
+		allErrs = append(allErrs, field.Invalid(namespacePath, s.obj.Namespace, "cannot have spaces"))
 	}
 
-	// Validate image type. Only enterprise image allowed for now
-	if !isEnterprise(s.obj.Spec.Image) {
-		return fmt.Errorf("CommunityEdition Cluster not supported")
+	// Validate community edition restrictions
+	imagePath := specPath.Child("image")
+	if !utils.IsEnterprise(s.obj.Spec.Image) {
+		allErrs = append(allErrs, validateCommunityEdition(&s.obj.Spec, specPath)...)
 	}
 
 	// Validate size
 	if s.obj.Spec.Size == 0 {
-		return fmt.Errorf("Invalid cluster size 0")
+		allErrs = append(allErrs, field.Invalid(specPath.Child("size"), s.obj.Spec.Size, "Invalid cluster size 0"))
 	}
 
-	// TODO: Validate if multiPodPerHost is false then number of kubernetes host should be >= size
+	// Validate config template references, the templates are merged by the mutating webhook.
+	allErrs = append(allErrs, validateConfigTemplates(s.obj.Spec.ConfigTemplates, specPath.Child("configTemplates"))...)
+
+	// Validate the aerospike.conf file reference, the file is parsed by the mutating webhook.
+	allErrs = append(allErrs, validateConfigFile(s.obj.Spec.AerospikeConfigFile, specPath.Child("aerospikeConfigFile"))...)
 
 	// Validate for AerospikeConfigSecret.
 	// TODO: Should we validate mount path also. Config has tls info at different paths, fetching and validating that may be little complex
-	if isSecretNeeded(s.obj.Spec.AerospikeConfig) && s.obj.Spec.AerospikeConfigSecret.SecretName == "" {
-		return fmt.Errorf("aerospikeConfig has feature-key-file path or tls paths. User need to create a secret for these and provide its info in `aerospikeConfigSecret` field")
+	if isSecretNeeded(s.obj.Spec.AerospikeConfig, s.obj.Spec.CertManager) && s.obj.Spec.AerospikeConfigSecret.SecretName == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("aerospikeConfigSecret", "secretName"), "aerospikeConfig has feature-key-file path or tls paths. User need to create a secret for these and provide its info in `aerospikeConfigSecret` field"))
 	}
 
-	// Validate Image version
+	// Validate the cert-manager issuers, the network.tls files are set by the mutating webhook.
+	allErrs = append(allErrs, validateCertManager(&s.obj, specPath.Child("certManager"), specPath.Child("aerospikeConfig"))...)
+
+	// Validate the NetworkPolicy sources.
+	allErrs = append(allErrs, validateKubernetesNetworkPolicy(s.obj.Spec.KubernetesNetworkPolicy, specPath.Child("kubernetesNetworkPolicy"))...)
+
+	// Validate resource and limit
+	allErrs = append(allErrs, s.validateResourceAndLimits(specPath.Child("resources"))...)
+
+	// Validate access control
+	allErrs = append(allErrs, s.validateAccessControl(s.obj, specPath.Child("aerospikeAccessControl"))...)
+
+	// Validate storage
+	allErrs = append(allErrs, validateStorage(&s.obj.Spec.Storage, specPath.Child("storage"))...)
+
+	// Validate Sidecars
+	allErrs = append(allErrs, s.validatePodSpec(specPath)...)
+
+	// Validate Image version. The remaining checks depend on the version.
 	version, err := getImageVersion(s.obj.Spec.Image)
 	if err != nil {
-		return err
+		return append(allErrs, field.Invalid(imagePath, s.obj.Spec.Image, err.Error()))
 	}
 
 	val, err := asconfig.CompareVersions(version, baseVersion)
 	if err != nil {
-		return fmt.Errorf("Failed to check image version: %v", err)
+		return append(allErrs, field.Invalid(imagePath, s.obj.Spec.Image, fmt.Sprintf("Failed to check image version: %v", err)))
 	}
 	if val < 0 {
-		return fmt.Errorf("Image version %s not supported. Base version %s", version, baseVersion)
+		return append(allErrs, field.Invalid(imagePath, s.obj.Spec.Image, fmt.Sprintf("Image version %s not supported. Base version %s", version, baseVersion)))
 	}
 
-	err = validateClusterSize(version, int(s.obj.Spec.Size))
+	configschema.RLock()
+	_, err = configschema.SchemaVersion(version)
+	configschema.RUnlock()
 	if err != nil {
-		return err
+		return append(allErrs, field.Invalid(imagePath, s.obj.Spec.Image, fmt.Sprintf("Image version %s not supported: %v. Supported versions %v", version, err, configschema.SupportedVersions())))
 	}
 
-	// Validate common aerospike config
-	aeroConfig := s.obj.Spec.AerospikeConfig
-	if err := validateAerospikeConfig(s.logger, aeroConfig, &s.obj.Spec.Storage, int(s.obj.Spec.Size)); err != nil {
-		return err
+	allErrs = append(allErrs, validateClusterSize(version, int(s.obj.Spec.Size), specPath.Child("size"))...)
+
+	// Validate common aerospike config, with the relative sizes resolved like in the rack configs.
+	aeroConfigPath := specPath.Child("aerospikeConfig")
+	aeroConfig, err := resolveRelativeSizes(s.obj.Spec.AerospikeConfig, &s.obj.Spec.Storage, s.obj.Spec.Resources)
+	if err != nil {
+		return append(allErrs, field.Invalid(aeroConfigPath, fieldValue(map[string]interface{}(s.obj.Spec.AerospikeConfig)), err.Error()))
 	}
+	allErrs = append(allErrs, validateAerospikeConfig(s.logger, version, aeroConfig, &s.obj.Spec.Storage, int(s.obj.Spec.Size), aeroConfigPath)...)
 
 	// Validate if passed aerospikeConfig
-	if err := validateAerospikeConfigSchema(s.logger, version, s.obj.Spec.AerospikeConfig); err != nil {
-		return fmt.Errorf("AerospikeConfig not valid: %v", err)
-	}
+	allErrs = append(allErrs, validateAerospikeConfigSchema(s.logger, version, aeroConfig, aeroConfigPath)...)
+
+	allErrs = append(allErrs, validateRequiredFileStorage(s.logger, aeroConfig, &s.obj.Spec.Storage, s.obj.Spec.ValidationPolicy, version, aeroConfigPath)...)
+
+	// Validate rackConfig
+	allErrs = append(allErrs, s.validateRackConfig(version, specPath.Child("rackConfig"))...)
+
+	// Validate the config against the feature key
+	allErrs = append(allErrs, s.validateFeatureKey(specPath)...)
+
+	return allErrs
+}
+
+// validateNamespaceMemory validates that the namespaces of the common and the rack aerospike configs fit in the memory
+// of the aerospike container.
+func (s *ClusterValidatingAdmissionWebhook) validateNamespaceMemory(fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
 
-	err = validateRequiredFileStorage(s.logger, aeroConfig, &s.obj.Spec.Storage, s.obj.Spec.ValidationPolicy, version)
+	version, err := getImageVersion(s.obj.Spec.Image)
 	if err != nil {
-		return err
+		return allErrs
 	}
-
-	err = validateConfigMapVolumes(s.logger, aeroConfig, &s.obj.Spec.Storage, s.obj.Spec.ValidationPolicy, version)
+	layout, err := getConfigLayout(version)
 	if err != nil {
-		return err
+		return allErrs
 	}
 
-	// Validate resource and limit
-	if err := s.validateResourceAndLimits(); err != nil {
-		return err
+	aeroConfig, err := resolveRelativeSizes(s.obj.Spec.AerospikeConfig, &s.obj.Spec.Storage, s.obj.Spec.Resources)
+	if err != nil {
+		return allErrs
 	}
+	nsList, _ := aeroConfig["namespaces"].([]interface{})
+	allErrs = append(allErrs, validateNamespaceMemorySize(layout, nsList, &s.obj.Spec.Storage, s.obj.Spec.Resources, fldPath.Child("aerospikeConfig", "namespaces"))...)
 
-	// Validate access control
-	if err := s.validateAccessControl(s.obj); err != nil {
-		return err
+	for i, rack := range s.obj.Spec.RackConfig.Racks {
+		rackNsList, _ := rack.AerospikeConfig["namespaces"].([]interface{})
+		storage := rack.Storage
+		rackNsPath := fldPath.Child("rackConfig", "racks").Index(i).Child("effectiveAerospikeConfig", "namespaces")
+		allErrs = append(allErrs, validateNamespaceMemorySize(layout, rackNsList, &storage, s.obj.Spec.Resources, rackNsPath)...)
 	}
+	return allErrs
+}
 
-	// Validate rackConfig
-	if err := s.validateRackConfig(); err != nil {
-		return err
+// validateFeatureKey validates the cluster spec against the features licensed by the feature key in the
+// AerospikeConfigSecret.
+func (s *ClusterValidatingAdmissionWebhook) validateFeatureKey(fldPath *field.Path) field.ErrorList {
+	if s.client == nil || !utils.IsEnterprise(s.obj.Spec.Image) {
+		return nil
 	}
 
-	// Validate Sidecars
-	if err := s.validatePodSpec(); err != nil {
-		return err
+	featureKey, err := featurekey.Load(s.client, &s.obj)
+	if err != nil {
+		// The secret may be created after the cluster, the controller validates the feature key before creating pods.
+		s.logger.Warn("Failed to load feature key. Skipping feature key validation", log.Ctx{"err": err})
+		return nil
 	}
-
-	return nil
+	if featureKey == nil {
+		return nil
+	}
+	return featureKey.Validate(&s.obj.Spec, fldPath)
 }
 
-func (s *ClusterValidatingAdmissionWebhook) validateRackUpdate(old aerospikev1alpha1.AerospikeCluster) error {
+func (s *ClusterValidatingAdmissionWebhook) validateRackUpdate(old aerospikev1alpha1.AerospikeCluster, newVersion, oldVersion string, fldPath *field.Path) field.ErrorList {
 	s.logger.Info("Validate rack update")
 
+	allErrs := field.ErrorList{}
+
 	if reflect.DeepEqual(s.obj.Spec.RackConfig, old.Spec.RackConfig) {
-		return nil
+		return allErrs
 	}
 
 	// Allow updating namespace list to dynamically enable, disable rack on namespaces
@@ -230,17 +333,24 @@ func (s *ClusterValidatingAdmissionWebhook) validateRackUpdate(old aerospikev1al
 	// Old racks can not be updated
 	// Also need to exclude a default rack with default rack ID. No need to check here, user should not provide or update default rackID
 	// Also when user add new rackIDs old default will be removed by reconciler.
+	racksPath := fldPath.Child("racks")
 	for _, oldRack := range old.Spec.RackConfig.Racks {
-		for _, newRack := range s.obj.Spec.RackConfig.Racks {
+		for i, newRack := range s.obj.Spec.RackConfig.Racks {
 
 			if oldRack.ID == newRack.ID {
+				rackPath := racksPath.Index(i)
 
-				if oldRack.NodeName != newRack.NodeName ||
-					oldRack.RackLabel != newRack.RackLabel ||
-					oldRack.Region != newRack.Region ||
-					oldRack.Zone != newRack.Zone {
-
-					return fmt.Errorf("Old RackConfig (NodeName, RackLabel, Region, Zone) can not be updated. Old rack %v, new rack %v", oldRack, newRack)
+				if oldRack.NodeName != newRack.NodeName {
+					allErrs = append(allErrs, field.Forbidden(rackPath.Child("nodeName"), "cannot be updated"))
+				}
+				if oldRack.RackLabel != newRack.RackLabel {
+					allErrs = append(allErrs, field.Forbidden(rackPath.Child("rackLabel"), "cannot be updated"))
+				}
+				if oldRack.Region != newRack.Region {
+					allErrs = append(allErrs, field.Forbidden(rackPath.Child("region"), "cannot be updated"))
+				}
+				if oldRack.Zone != newRack.Zone {
+					allErrs = append(allErrs, field.Forbidden(rackPath.Child("zone"), "cannot be updated"))
 				}
 
 				if len(oldRack.AerospikeConfig) != 0 || len(newRack.AerospikeConfig) != 0 {
@@ -248,9 +358,7 @@ func (s *ClusterValidatingAdmissionWebhook) validateRackUpdate(old aerospikev1al
 					newConf := newRack.AerospikeConfig
 					oldConf := oldRack.AerospikeConfig
 					// Validate aerospikeConfig update
-					if err := validateAerospikeConfigUpdate(s.logger, newConf, oldConf); err != nil {
-						return fmt.Errorf("Invalid update in Rack(ID: %d) aerospikeConfig: %v", oldRack.ID, err)
-					}
+					allErrs = append(allErrs, validateAerospikeConfigUpdate(s.logger, newVersion, oldVersion, newConf, oldConf, rackPath.Child("effectiveAerospikeConfig"))...)
 				}
 
 				if len(oldRack.Storage.Volumes) != 0 || len(newRack.Storage.Volumes) != 0 {
@@ -259,7 +367,7 @@ func (s *ClusterValidatingAdmissionWebhook) validateRackUpdate(old aerospikev1al
 					newStorage := newRack.Storage
 					// Volume storage update is not allowed but cascadeDelete policy is allowed
 					if err := oldStorage.ValidateStorageSpecChange(newStorage); err != nil {
-						return fmt.Errorf("Rack storage config cannot be updated: %v", err)
+						allErrs = append(allErrs, field.Forbidden(rackPath.Child("effectiveStorage"), fmt.Sprintf("Rack storage config cannot be updated: %v", err)))
 					}
 				}
 
@@ -267,57 +375,69 @@ func (s *ClusterValidatingAdmissionWebhook) validateRackUpdate(old aerospikev1al
 			}
 		}
 	}
-	return nil
+	return allErrs
 }
 
-func (s *ClusterValidatingAdmissionWebhook) validateAccessControl(aeroCluster aerospikev1alpha1.AerospikeCluster) error {
-	_, err := accessControl.IsAerospikeAccessControlValid(&aeroCluster.Spec)
-	return err
+func (s *ClusterValidatingAdmissionWebhook) validateAccessControl(aeroCluster aerospikev1alpha1.AerospikeCluster, fldPath *field.Path) field.ErrorList {
+	allErrs := validateLDAPRoleMappings(aeroCluster.Spec.AerospikeAccessControl, fldPath)
+
+	if _, err := accessControl.IsAerospikeAccessControlValid(&aeroCluster.Spec); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath, nil, err.Error()))
+	}
+	return allErrs
 }
 
-func (s *ClusterValidatingAdmissionWebhook) validateResourceAndLimits() error {
+func (s *ClusterValidatingAdmissionWebhook) validateResourceAndLimits(fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
 	res := s.obj.Spec.Resources
 
 	if res == nil || res.Requests == nil {
-		return fmt.Errorf("Resources or Resources.Requests cannot be nil")
+		return append(allErrs, field.Required(fldPath.Child("requests"), "Resources or Resources.Requests cannot be nil"))
 	}
 
-	if res.Requests.Memory().IsZero() || res.Requests.Cpu().IsZero() {
-		return fmt.Errorf("Resources.Requests.Memory or Resources.Requests.Cpu cannot be zero")
+	if res.Requests.Memory().IsZero() {
+		allErrs = append(allErrs, field.Required(fldPath.Child("requests", "memory"), "cannot be zero"))
+	}
+	if res.Requests.Cpu().IsZero() {
+		allErrs = append(allErrs, field.Required(fldPath.Child("requests", "cpu"), "cannot be zero"))
 	}
 
-	if res.Limits != nil &&
-		((res.Limits.Cpu().Cmp(*res.Requests.Cpu()) < 0) ||
-			(res.Limits.Memory().Cmp(*res.Requests.Memory()) < 0)) {
-		return fmt.Errorf("Resource.Limits cannot be less than Resource.Requests. Resources %v", res)
+	if res.Limits != nil {
+		if res.Limits.Cpu().Cmp(*res.Requests.Cpu()) < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("limits", "cpu"), res.Limits.Cpu().String(), "cannot be less than Resource.Requests"))
+		}
+		if res.Limits.Memory().Cmp(*res.Requests.Memory()) < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("limits", "memory"), res.Limits.Memory().String(), "cannot be less than Resource.Requests"))
+		}
 	}
 
-	return nil
+	return allErrs
 }
 
-func (s *ClusterValidatingAdmissionWebhook) validateRackConfig() error {
+func (s *ClusterValidatingAdmissionWebhook) validateRackConfig(version string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	racksPath := fldPath.Child("racks")
 	if len(s.obj.Spec.RackConfig.Racks) != 0 && (int(s.obj.Spec.Size) < len(s.obj.Spec.RackConfig.Racks)) {
-		return fmt.Errorf("Cluster size can not be less than number of Racks")
+		allErrs = append(allErrs, field.Invalid(racksPath, len(s.obj.Spec.RackConfig.Racks), "Cluster size can not be less than number of Racks"))
 	}
 
 	// Validate namespace names
 	// TODO: Add more validation for namespace name
-	for _, nsName := range s.obj.Spec.RackConfig.Namespaces {
+	for i, nsName := range s.obj.Spec.RackConfig.Namespaces {
 		if strings.Contains(nsName, " ") {
-			return fmt.Errorf("Namespace name `%s` cannot have spaces, Namespaces %v", nsName, s.obj.Spec.RackConfig.Namespaces)
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("namespaces").Index(i), nsName, "Namespace name cannot have spaces"))
 		}
 	}
 
-	version, err := getImageVersion(s.obj.Spec.Image)
-	if err != nil {
-		return err
-	}
-
 	rackMap := map[int]bool{}
-	for _, rack := range s.obj.Spec.RackConfig.Racks {
+	for i, rack := range s.obj.Spec.RackConfig.Racks {
+		rackPath := racksPath.Index(i)
+
 		// Check for duplicate
 		if _, ok := rackMap[rack.ID]; ok {
-			return fmt.Errorf("Duplicate rackID %d not allowed, racks %v", rack.ID, s.obj.Spec.RackConfig.Racks)
+			allErrs = append(allErrs, field.Duplicate(rackPath.Child("id"), rack.ID))
 		}
 		rackMap[rack.ID] = true
 
@@ -325,7 +445,7 @@ func (s *ClusterValidatingAdmissionWebhook) validateRackConfig() error {
 		// Check for defaultRackID is in mutate (user can not use defaultRackID).
 		// Allow utils.DefaultRackID
 		if rack.ID > utils.MaxRackID {
-			return fmt.Errorf("Invalid rackID. RackID range (%d, %d)", utils.MinRackID, utils.MaxRackID)
+			allErrs = append(allErrs, field.Invalid(rackPath.Child("id"), rack.ID, fmt.Sprintf("Invalid rackID. RackID range (%d, %d)", utils.MinRackID, utils.MaxRackID)))
 		}
 
 		if len(rack.AerospikeConfig) != 0 || len(rack.Storage.Volumes) != 0 {
@@ -333,18 +453,15 @@ func (s *ClusterValidatingAdmissionWebhook) validateRackConfig() error {
 			// TODO:
 			// Replication-factor in rack and commonConfig can not be different
 			storage := rack.Storage
-			if err := validateAerospikeConfig(s.logger, config, &storage, int(s.obj.Spec.Size)); err != nil {
-				return err
-			}
+			allErrs = append(allErrs, validateStorage(&storage, rackPath.Child("effectiveStorage"))...)
+			allErrs = append(allErrs, validateAerospikeConfig(s.logger, version, config, &storage, int(s.obj.Spec.Size), rackPath.Child("effectiveAerospikeConfig"))...)
 		}
 
 		// Validate rack aerospike config
 		if len(rack.AerospikeConfig) != 0 {
-			if err := validateAerospikeConfigSchema(s.logger, version, rack.AerospikeConfig); err != nil {
-				return fmt.Errorf("AerospikeConfig not valid for rack %v", rack)
-			}
+			allErrs = append(allErrs, validateAerospikeConfigSchema(s.logger, version, rack.AerospikeConfig, rackPath.Child("effectiveAerospikeConfig"))...)
 		}
 	}
 
-	return nil
+	return allErrs
 }
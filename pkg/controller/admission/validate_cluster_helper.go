@@ -2,15 +2,21 @@ package admission
 
 import (
 	"fmt"
+	"math"
 	"path/filepath"
 	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
 	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
+	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/configschema"
 	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/utils"
 	"github.com/aerospike/aerospike-management-lib/asconfig"
 	log "github.com/inconshreveable/log15"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 // After 4.0, before 31
@@ -25,213 +31,605 @@ const maxEnterpriseClusterSzGT5_0 = 256
 
 const versionForSzCheck = "5.0.0"
 
-func validateClusterSize(version string, sz int) error {
+func validateClusterSize(version string, sz int, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
 	val, err := asconfig.CompareVersions(version, versionForSzCheck)
 	if err != nil {
-		return fmt.Errorf("Failed to validate cluster size limit from version: %v", err)
+		return append(allErrs, field.InternalError(fldPath, fmt.Errorf("Failed to validate cluster size limit from version: %v", err)))
 	}
 	if val < 0 && sz > maxEnterpriseClusterSzLT5_0 {
-		return fmt.Errorf("Cluster size cannot be more than %d", maxEnterpriseClusterSzLT5_0)
+		allErrs = append(allErrs, field.Invalid(fldPath, sz, fmt.Sprintf("cannot be more than %d", maxEnterpriseClusterSzLT5_0)))
 	}
 	if val > 0 && sz > maxEnterpriseClusterSzGT5_0 {
-		return fmt.Errorf("Cluster size cannot be more than %d", maxEnterpriseClusterSzGT5_0)
+		allErrs = append(allErrs, field.Invalid(fldPath, sz, fmt.Sprintf("cannot be more than %d", maxEnterpriseClusterSzGT5_0)))
 	}
-	return nil
+	return allErrs
 }
 
-func validateAerospikeConfig(logger log.Logger, config v1alpha1.Values, storage *aerospikev1alpha1.AerospikeStorageSpec, clSize int) error {
+func validateAerospikeConfig(logger log.Logger, version string, config v1alpha1.Values, storage *aerospikev1alpha1.AerospikeStorageSpec, clSize int, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
 	if config == nil {
-		return fmt.Errorf("aerospikeConfig cannot be empty")
+		return append(allErrs, field.Required(fldPath, "aerospikeConfig cannot be empty"))
 	}
 
-	// service conf
-	serviceConf, ok := config["service"].(map[string]interface{})
-	if !ok {
-		return fmt.Errorf("aerospikeConfig.service not a valid map %v", config["service"])
+	layout, err := getConfigLayout(version)
+	if err != nil {
+		return append(allErrs, field.InternalError(fldPath, err))
 	}
-	if _, ok := serviceConf["cluster-name"]; !ok {
-		return fmt.Errorf("AerospikeCluster name not found in config. Looks like object is not mutated by webhook")
+
+	// service conf
+	servicePath := fldPath.Child("service")
+	if serviceConf, ok := config["service"].(map[string]interface{}); !ok {
+		allErrs = append(allErrs, field.Invalid(servicePath, fieldValue(config["service"]), "not a valid map"))
+	} else if _, ok := serviceConf["cluster-name"]; !ok {
+		allErrs = append(allErrs, field.Required(servicePath.Child("cluster-name"), "not found in config. Looks like object is not mutated by webhook"))
 	}
 
 	// network conf
-	networkConf, ok := config["network"].(map[string]interface{})
-	if !ok {
-		return fmt.Errorf("aerospikeConfig.network not a valid map %v", config["network"])
-	}
-	if _, ok := networkConf["service"]; !ok {
-		return fmt.Errorf("Network.service section not found in config. Looks like object is not mutated by webhook")
-	}
+	networkPath := fldPath.Child("network")
+	if networkConf, ok := config["network"].(map[string]interface{}); !ok {
+		allErrs = append(allErrs, field.Invalid(networkPath, fieldValue(config["network"]), "not a valid map"))
+	} else {
+		if _, ok := networkConf["service"]; !ok {
+			allErrs = append(allErrs, field.Required(networkPath.Child("service"), "not found in config. Looks like object is not mutated by webhook"))
+		}
 
-	// network.tls conf
-	if _, ok := networkConf["tls"]; ok {
-		tlsConfList := networkConf["tls"].([]interface{})
-		for _, tlsConfInt := range tlsConfList {
-			tlsConf := tlsConfInt.(map[string]interface{})
+		// network.tls conf
+		tlsConfList, _ := networkConf["tls"].([]interface{})
+		for i, tlsConfInt := range tlsConfList {
+			tlsConf, ok := tlsConfInt.(map[string]interface{})
+			if !ok {
+				allErrs = append(allErrs, field.Invalid(networkPath.Child("tls").Index(i), fieldValue(tlsConfInt), "not a valid map"))
+				continue
+			}
 			if _, ok := tlsConf["ca-path"]; ok {
-				return fmt.Errorf("ca-path not allowed, please use ca-file. tlsConf %v", tlsConf)
+				allErrs = append(allErrs, field.Forbidden(networkPath.Child("tls").Index(i).Child("ca-path"), "ca-path not allowed, please use ca-file"))
 			}
 		}
 	}
 
 	// namespace conf
+	nsPath := fldPath.Child("namespaces")
 	nsListInterface, ok := config["namespaces"]
+	if !ok || nsListInterface == nil {
+		allErrs = append(allErrs, field.Required(nsPath, ""))
+	} else if nsList, ok := nsListInterface.([]interface{}); !ok {
+		allErrs = append(allErrs, field.Invalid(nsPath, fieldValue(nsListInterface), "not a valid namespace list"))
+	} else {
+		allErrs = append(allErrs, validateNamespaceConfig(logger, layout, version, nsList, storage, clSize, nsPath)...)
+	}
+
+	// xdr conf
+	allErrs = append(allErrs, validateXdrConfig(layout, version, config, fldPath.Child("xdr"))...)
+
+	return allErrs
+}
+
+// validateXdrConfig validates the xdr section against the xdr layout of the server version. Pre-5.0 xdr is validated
+// with the storage in validateRequiredFileStorage.
+func validateXdrConfig(layout *configLayout, version string, config v1alpha1.Values, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if !utils.IsXdrEnabled(config) || layout.xdrDigestLog {
+		return allErrs
+	}
+
+	xdrConf, ok := config["xdr"].(map[string]interface{})
 	if !ok {
-		return fmt.Errorf("aerospikeConfig.namespace not a present. aerospikeConfig %v", config)
-	} else if nsListInterface == nil {
-		return fmt.Errorf("aerospikeConfig.namespace cannot be nil")
+		return append(allErrs, field.Invalid(fldPath, fieldValue(config["xdr"]), "not a valid map"))
 	}
-	if nsList, ok := nsListInterface.([]interface{}); !ok {
-		return fmt.Errorf("aerospikeConfig.namespace not valid namespace list %v", nsListInterface)
-	} else if err := validateNamespaceConfig(logger, nsList, storage, clSize); err != nil {
-		return err
+	if _, ok := xdrConf["xdr-digestlog-path"]; ok {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("xdr-digestlog-path"), fmt.Sprintf("not supported for version %s. Use xdr dcs", version)))
 	}
 
-	return nil
+	dcs, ok := xdrConf["dcs"]
+	if !ok {
+		return allErrs
+	}
+	dcsPath := fldPath.Child("dcs")
+	dcList, ok := dcs.([]interface{})
+	if !ok {
+		return append(allErrs, field.Invalid(dcsPath, fieldValue(dcs), "not a valid list"))
+	}
+	for i, dcInterface := range dcList {
+		dcConf, ok := dcInterface.(map[string]interface{})
+		if !ok {
+			allErrs = append(allErrs, field.Invalid(dcsPath.Index(i), fieldValue(dcInterface), "not a valid map"))
+			continue
+		}
+		if name, ok := dcConf["name"].(string); !ok || name == "" {
+			allErrs = append(allErrs, field.Required(dcsPath.Index(i).Child("name"), ""))
+		}
+	}
+	return allErrs
 }
 
-func validateNamespaceConfig(logger log.Logger, nsConfInterfaceList []interface{}, storage *aerospikev1alpha1.AerospikeStorageSpec, clSize int) error {
+func validateNamespaceConfig(logger log.Logger, layout *configLayout, version string, nsConfInterfaceList []interface{}, storage *aerospikev1alpha1.AerospikeStorageSpec, clSize int, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
 	if len(nsConfInterfaceList) == 0 {
-		return fmt.Errorf("aerospikeConfig.namespace list cannot be empty")
+		return append(allErrs, field.Required(fldPath, "namespace list cannot be empty"))
 	}
 
 	// Get list of all devices used in namespace. match it with namespace device list
+	// Storage errors are reported by validateStorage.
 	blockStorageDeviceList, fileStorageList, err := storage.GetStorageList()
 	if err != nil {
-		return err
+		return allErrs
 	}
 
-	for _, nsConfInterface := range nsConfInterfaceList {
+	for i, nsConfInterface := range nsConfInterfaceList {
+		nsPath := fldPath.Index(i)
+
 		// Validate new namespace conf
 		nsConf, ok := nsConfInterface.(map[string]interface{})
 		if !ok {
-			return fmt.Errorf("namespace conf not in valid format %v", nsConfInterface)
+			allErrs = append(allErrs, field.Invalid(nsPath, fieldValue(nsConfInterface), "namespace conf not in valid format"))
+			continue
 		}
 
-		if err := validateNamespaceReplicationFactor(logger, nsConf, clSize); err != nil {
-			return err
+		allErrs = append(allErrs, validateNamespaceReplicationFactor(logger, nsConf, clSize, nsPath.Child("replication-factor"))...)
+		allErrs = append(allErrs, validateNamespaceLayout(layout, version, nsConf, nsPath)...)
+		allErrs = append(allErrs, validateNamespaceStorage(nsConf, blockStorageDeviceList, fileStorageList, nsPath.Child("storage-engine"))...)
+		allErrs = append(allErrs, validateNamespaceIndexType(nsConf, fileStorageList, nsPath.Child("index-type"))...)
+	}
+
+	// Sizes are summed across namespaces, only check these for well formed namespaces.
+	if len(allErrs) != 0 {
+		return allErrs
+	}
+
+	return append(allErrs, validateNamespaceFileSize(nsConfInterfaceList, storage, fldPath)...)
+}
+
+// validateNamespaceStorage validates that the devices and files of the namespace storage-engine are in the storage
+// volumes.
+func validateNamespaceStorage(nsConf map[string]interface{}, blockStorageDeviceList, fileStorageList []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	nsStorage, ok := nsConf["storage-engine"]
+	if !ok || nsStorage == nil {
+		return append(allErrs, field.Required(fldPath, "storage-engine config is required for namespace"))
+	}
+	storageConf, ok := nsStorage.(map[string]interface{})
+	if !ok {
+		return append(allErrs, field.Invalid(fldPath, fieldValue(nsStorage), "not a valid map"))
+	}
+
+	if isInMemoryNamespace(nsConf) {
+		// storage-engine memory
+		return allErrs
+	}
+
+	if !isDeviceNamespace(nsConf) {
+		// storage-engine pmem
+		// storage-engine memory backed by devices or files is handled like a device namespace
+		return append(allErrs, field.NotSupported(fldPath.Child("type"), storageConf["type"], []string{"memory", "device"}))
+	}
+
+	// TODO: worry about pmem.
+	if devices, ok := storageConf["devices"]; ok {
+		devicesPath := fldPath.Child("devices")
+		deviceList, ok := devices.([]interface{})
+		if devices == nil || ok && len(deviceList) == 0 {
+			allErrs = append(allErrs, field.Required(devicesPath, "no devices for namespace storage"))
+		} else if !ok {
+			allErrs = append(allErrs, field.Invalid(devicesPath, fieldValue(devices), "not a valid list"))
 		}
 
-		if nsStorage, ok := nsConf["storage-engine"]; ok {
-			if nsStorage == nil {
-				return fmt.Errorf("storage-engine cannot be nil for namespace %v", nsConf)
+		for j, device := range deviceList {
+			deviceStr, ok := device.(string)
+			if !ok {
+				allErrs = append(allErrs, field.Invalid(devicesPath.Index(j), fieldValue(device), "not a valid string"))
+				continue
+			}
+
+			// device list Fields cannot be more that 2 in single line. Two in shadow device case. validate.
+			dList := strings.Fields(deviceStr)
+			if len(dList) > 2 {
+				allErrs = append(allErrs, field.Invalid(devicesPath.Index(j), deviceStr, "max 2 device can be mentioned in single line (Shadow device config)"))
+				continue
 			}
 
-			if isInMemoryNamespace(nsConf) {
-				// storage-engine memory
+			for _, dev := range dList {
+				// Namespace device should be present in BlockStorage config section
+				if !utils.ContainsString(blockStorageDeviceList, dev) {
+					allErrs = append(allErrs, field.Invalid(devicesPath.Index(j), deviceStr, fmt.Sprintf("device %s not found in block storage volumes", dev)))
+				}
+			}
+		}
+	}
+
+	if files, ok := storageConf["files"]; ok {
+		filesPath := fldPath.Child("files")
+		fileList, ok := files.([]interface{})
+		if files == nil || ok && len(fileList) == 0 {
+			allErrs = append(allErrs, field.Required(filesPath, "no files for namespace storage"))
+		} else if !ok {
+			allErrs = append(allErrs, field.Invalid(filesPath, fieldValue(files), "not a valid list"))
+		}
+
+		for j, file := range fileList {
+			fileStr, ok := file.(string)
+			if !ok {
+				allErrs = append(allErrs, field.Invalid(filesPath.Index(j), fieldValue(file), "not a valid string"))
 				continue
 			}
 
-			if !isDeviceNamespace(nsConf) {
-				// storage-engine pmem
-				return fmt.Errorf("storage-engine not supported for namespace %v", nsConf)
+			dirPath := filepath.Dir(fileStr)
+			if !isFileStorageConfiguredForDir(fileStorageList, dirPath) {
+				allErrs = append(allErrs, field.Invalid(filesPath.Index(j), fileStr, fmt.Sprintf("mount path %s not found in filesystem storage volumes", dirPath)))
 			}
+		}
+	}
 
-			// TODO: worry about pmem.
-			if devices, ok := nsStorage.(map[string]interface{})["devices"]; ok {
-				if devices == nil {
-					return fmt.Errorf("namespace storage devices cannot be nil %v", nsStorage)
-				}
+	return allErrs
+}
 
-				if _, ok := devices.([]interface{}); !ok {
-					return fmt.Errorf("namespace storage device format not valid %v", nsStorage)
-				}
+// validateNamespaceIndexType validates that the namespace index-type mounts are in the filesystem storage volumes.
+func validateNamespaceIndexType(nsConf map[string]interface{}, fileStorageList []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
 
-				if len(devices.([]interface{})) == 0 {
-					return fmt.Errorf("No devices for namespace storage %v", nsStorage)
-				}
+	if isShmemIndexTypeNamespace(nsConf) {
+		return allErrs
+	}
 
-				for _, device := range devices.([]interface{}) {
-					if _, ok := device.(string); !ok {
-						return fmt.Errorf("namespace storage device not valid string %v", device)
-					}
-
-					// device list Fields cannot be more that 2 in single line. Two in shadow device case. validate.
-					if len(strings.Fields(device.(string))) > 2 {
-						return fmt.Errorf("Invalid device name %v. Max 2 device can be mentioned in single line (Shadow device config)", device)
-					}
-
-					dList := strings.Fields(device.(string))
-					for _, dev := range dList {
-						// Namespace device should be present in BlockStorage config section
-						if !utils.ContainsString(blockStorageDeviceList, dev) {
-							return fmt.Errorf("Namespace storage device related devicePath %v not found in Storage config %v", dev, storage)
-						}
-					}
+	nsIndexStorage, _ := nsConf["index-type"].(map[string]interface{})
+	mounts, ok := nsIndexStorage["mounts"]
+	if !ok {
+		return allErrs
+	}
+
+	mountsPath := fldPath.Child("mounts")
+	mountList, ok := mounts.([]interface{})
+	if mounts == nil || ok && len(mountList) == 0 {
+		allErrs = append(allErrs, field.Required(mountsPath, "no mounts for namespace index-type"))
+	} else if !ok {
+		allErrs = append(allErrs, field.Invalid(mountsPath, fieldValue(mounts), "not a valid list"))
+	}
+
+	for j, mount := range mountList {
+		mountStr, ok := mount.(string)
+		if !ok {
+			allErrs = append(allErrs, field.Invalid(mountsPath.Index(j), fieldValue(mount), "not a valid string"))
+			continue
+		}
+
+		// Namespace index-type mount should be present in filesystem config section
+		if !utils.ContainsString(fileStorageList, mountStr) {
+			allErrs = append(allErrs, field.Invalid(mountsPath.Index(j), mountStr, "not found in filesystem storage volumes"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateNamespaceLayout validates that the namespace memory is configured as per the config layout of the server
+// version.
+func validateNamespaceLayout(layout *configLayout, version string, nsConf map[string]interface{}, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	nsStorage, _ := nsConf["storage-engine"].(map[string]interface{})
+
+	if layout.namespaceMemorySize {
+		if nsStorage != nil && nsStorage["type"] == "memory" {
+			for _, key := range []string{"data-size", "devices", "files"} {
+				if _, ok := nsStorage[key]; ok {
+					allErrs = append(allErrs, field.Forbidden(fldPath.Child("storage-engine", key), fmt.Sprintf("storage-engine memory %s not supported for version %s", key, version)))
 				}
 			}
+		}
+		return allErrs
+	}
 
-			if files, ok := nsStorage.(map[string]interface{})["files"]; ok {
-				if files == nil {
-					return fmt.Errorf("namespace storage files cannot be nil %v", nsStorage)
-				}
+	if _, ok := nsConf["memory-size"]; ok {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("memory-size"), fmt.Sprintf("not supported for version %s. Use storage-engine memory data-size", version)))
+	}
+	if nsStorage != nil {
+		if _, ok := nsStorage["data-in-memory"]; ok {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("storage-engine", "data-in-memory"), fmt.Sprintf("not supported for version %s. Use storage-engine memory with devices or files", version)))
+		}
+	}
+	return allErrs
+}
 
-				if _, ok := files.([]interface{}); !ok {
-					return fmt.Errorf("namespace storage files format not valid %v", nsStorage)
-				}
+// validateNamespaceFileSize validates that the files of all namespaces fit in the filesystem volumes they are stored on.
+// An error is returned for the filesize of each namespace claiming space on a volume that is too small.
+func validateNamespaceFileSize(nsConfInterfaceList []interface{}, storage *aerospikev1alpha1.AerospikeStorageSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
 
-				if len(files.([]interface{})) == 0 {
-					return fmt.Errorf("No files for namespace storage %v", nsStorage)
-				}
+	// Total filesize claimed on each volume, keyed by volume path.
+	claimedSizes := map[string]int64{}
+	// Filesize fields claiming space on each volume.
+	claimedBy := map[string][]*field.Error{}
+
+	for i, nsConfInterface := range nsConfInterfaceList {
+		nsConf := nsConfInterface.(map[string]interface{})
+		if isInMemoryNamespace(nsConf) {
+			continue
+		}
 
-				for _, file := range files.([]interface{}) {
-					if _, ok := file.(string); !ok {
-						return fmt.Errorf("namespace storage file not valid string %v", file)
-					}
+		nsStorage, ok := nsConf["storage-engine"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		files, ok := nsStorage["files"].([]interface{})
+		if !ok {
+			continue
+		}
 
-					dirPath := filepath.Dir(file.(string))
-					if !isFileStorageConfiguredForDir(fileStorageList, dirPath) {
-						return fmt.Errorf("Namespace storage file related mountPath %v not found in storage config %v", dirPath, storage)
-					}
-				}
+		filesizePath := fldPath.Index(i).Child("storage-engine", "filesize")
+		fileSizeInterface, ok := nsStorage["filesize"]
+		if !ok {
+			continue
+		}
+		fileSize, err := getSizeInBytes(fileSizeInterface)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(filesizePath, fileSizeInterface, err.Error()))
+			continue
+		}
+
+		volumePaths := map[string]bool{}
+		for _, file := range files {
+			volume := getVolumeForFile(storage, file.(string))
+			if volume == nil {
+				continue
+			}
+			claimedSizes[volume.Path] += fileSize
+			if !volumePaths[volume.Path] {
+				volumePaths[volume.Path] = true
+				claimedBy[volume.Path] = append(claimedBy[volume.Path], field.Invalid(filesizePath, fileSizeInterface, ""))
 			}
-		} else {
-			return fmt.Errorf("storage-engine config is required for namespace")
 		}
 	}
 
-	// Vaidate index-type
-	for _, nsConfInterface := range nsConfInterfaceList {
-		nsConf, ok := nsConfInterface.(map[string]interface{})
+	for _, volume := range storage.Volumes {
+		claimedSize, ok := claimedSizes[volume.Path]
 		if !ok {
-			return fmt.Errorf("namespace conf not in valid format %v", nsConfInterface)
+			continue
 		}
+		volumeSize := getVolumeSize(&volume)
+		if volumeSize != nil && claimedSize > volumeSize.Value() {
+			for _, err := range claimedBy[volume.Path] {
+				err.Detail = fmt.Sprintf("total filesize %d of namespace files exceeds size %s of volume %s", claimedSize, volumeSize.String(), volume.Path)
+				allErrs = append(allErrs, err)
+			}
+		}
+	}
+	return allErrs
+}
+
+// validateNamespaceMemorySize validates that the memory of all namespaces fits in the memory available to the
+// aerospike container. The memory limit is used if set, else the memory request. Namespace memory is the memory-size
+// before 7.0, else the storage-engine memory data-size or the size of its devices or files. An error is returned for
+// each namespace when the total memory does not fit.
+func validateNamespaceMemorySize(layout *configLayout, nsConfInterfaceList []interface{}, storage *aerospikev1alpha1.AerospikeStorageSpec, resources *corev1.ResourceRequirements, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	memory := getContainerMemory(resources)
+	if memory == nil {
+		return allErrs
+	}
 
-		if isShmemIndexTypeNamespace(nsConf) {
+	var totalMemorySize int64
+	memoryErrs := field.ErrorList{}
+
+	for i, nsConfInterface := range nsConfInterfaceList {
+		nsConf := nsConfInterface.(map[string]interface{})
+
+		var memorySize int64
+		var memoryPath *field.Path
+		var memoryValue interface{}
+		var err error
+		if layout.namespaceMemorySize {
+			memorySizeInterface, ok := nsConf["memory-size"]
+			if !ok {
+				continue
+			}
+			memoryPath = fldPath.Index(i).Child("memory-size")
+			memoryValue = memorySizeInterface
+			memorySize, err = getSizeInBytes(memorySizeInterface)
+		} else {
+			nsStorage, ok := nsConf["storage-engine"].(map[string]interface{})
+			if !ok || nsStorage["type"] != "memory" {
+				continue
+			}
+			memoryPath = fldPath.Index(i).Child("storage-engine")
+			memoryValue = "memory"
+			memorySize, err = getStorageEngineMemorySize(nsStorage, storage)
+		}
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(memoryPath, memoryValue, err.Error()))
 			continue
 		}
+		totalMemorySize += memorySize
+		memoryErrs = append(memoryErrs, field.Invalid(memoryPath, memoryValue, ""))
+	}
 
-		if nsIndexStorage, ok := nsConf["index-type"]; ok {
-			if mounts, ok := nsIndexStorage.(map[string]interface{})["mounts"]; ok {
-				if mounts == nil {
-					return fmt.Errorf("namespace index-type mounts cannot be nil %v", nsIndexStorage)
-				}
+	if totalMemorySize > memory.Value() {
+		for _, err := range memoryErrs {
+			err.Detail = fmt.Sprintf("total namespace memory %d exceeds container memory %s", totalMemorySize, memory.String())
+			allErrs = append(allErrs, err)
+		}
+	}
+	return allErrs
+}
 
-				if _, ok := mounts.([]interface{}); !ok {
-					return fmt.Errorf("namespace index-type mounts format not valid %v", nsIndexStorage)
-				}
+// isNamespaceMemoryChanged returns true if a spec field the namespace memory validation depends on has changed.
+func isNamespaceMemoryChanged(newSpec, oldSpec aerospikev1alpha1.AerospikeClusterSpec) bool {
+	if newSpec.Image != oldSpec.Image || !reflect.DeepEqual(newSpec.Resources, oldSpec.Resources) ||
+		!reflect.DeepEqual(newSpec.Storage, oldSpec.Storage) || !reflect.DeepEqual(newSpec.AerospikeConfig, oldSpec.AerospikeConfig) {
+		return true
+	}
+	if len(newSpec.RackConfig.Racks) != len(oldSpec.RackConfig.Racks) {
+		return true
+	}
+	for idx := range newSpec.RackConfig.Racks {
+		newRack, oldRack := newSpec.RackConfig.Racks[idx], oldSpec.RackConfig.Racks[idx]
+		if !reflect.DeepEqual(newRack.AerospikeConfig, oldRack.AerospikeConfig) || !reflect.DeepEqual(newRack.Storage, oldRack.Storage) {
+			return true
+		}
+	}
+	return false
+}
 
-				if len(mounts.([]interface{})) == 0 {
-					return fmt.Errorf("No mounts for namespace index-type %v", nsIndexStorage)
-				}
+// getContainerMemory returns the memory available to the aerospike container, the memory limit if set, else the memory
+// request. Returns nil if neither is set.
+func getContainerMemory(resources *corev1.ResourceRequirements) *resource.Quantity {
+	if resources == nil {
+		return nil
+	}
+	if limit, ok := resources.Limits[corev1.ResourceMemory]; ok && !limit.IsZero() {
+		return &limit
+	}
+	if request, ok := resources.Requests[corev1.ResourceMemory]; ok && !request.IsZero() {
+		return &request
+	}
+	return nil
+}
 
-				for _, mount := range mounts.([]interface{}) {
-					if _, ok := mount.(string); !ok {
-						return fmt.Errorf("namespace index-type mount not valid string %v", mount)
-					}
+// getStorageEngineMemorySize returns the memory used by a 7.0+ storage-engine memory. It is the data-size if set,
+// else the size of the files or devices backing the namespace. Devices without a storage volume are not counted.
+func getStorageEngineMemorySize(nsStorage map[string]interface{}, storage *aerospikev1alpha1.AerospikeStorageSpec) (int64, error) {
+	if dataSize, ok := nsStorage["data-size"]; ok {
+		return getSizeInBytes(dataSize)
+	}
 
-					// Namespace index-type mount should be present in filesystem config section
-					if !utils.ContainsString(fileStorageList, mount.(string)) {
-						return fmt.Errorf("Namespace index-type mount %v not found in Storage config %v", mount, storage)
-					}
-				}
+	if files, ok := nsStorage["files"].([]interface{}); ok {
+		fileSizeInterface, ok := nsStorage["filesize"]
+		if !ok {
+			return 0, nil
+		}
+		fileSize, err := getSizeInBytes(fileSizeInterface)
+		if err != nil {
+			return 0, err
+		}
+		return fileSize * int64(len(files)), nil
+	}
+
+	var memorySize int64
+	devices, _ := nsStorage["devices"].([]interface{})
+	for _, device := range devices {
+		deviceStr, ok := device.(string)
+		if !ok || len(strings.Fields(deviceStr)) == 0 {
+			continue
+		}
+		// Memory mirrors the primary device, not the shadow device.
+		devicePath := strings.Fields(deviceStr)[0]
+		for _, volume := range storage.Volumes {
+			if volume.VolumeMode == aerospikev1alpha1.AerospikeVolumeModeBlock && volume.Path == devicePath {
+				size := volume.GetSize()
+				memorySize += size.Value()
+				break
 			}
 		}
 	}
+	return memorySize, nil
+}
+
+// getVolumeForFile returns the filesystem volume with the deepest path containing the file, nil if none.
+func getVolumeForFile(storage *aerospikev1alpha1.AerospikeStorageSpec, file string) *aerospikev1alpha1.AerospikePersistentVolumeSpec {
+	return getVolumeForDir(storage, filepath.Dir(file))
+}
+
+// getVolumeForDir returns the filesystem, emptyDir or hostPath volume having the dir, nil if none.
+func getVolumeForDir(storage *aerospikev1alpha1.AerospikeStorageSpec, dir string) *aerospikev1alpha1.AerospikePersistentVolumeSpec {
+	var match *aerospikev1alpha1.AerospikePersistentVolumeSpec
+	for i := range storage.Volumes {
+		volume := &storage.Volumes[i]
+		switch volume.VolumeMode {
+		case aerospikev1alpha1.AerospikeVolumeModeFilesystem, aerospikev1alpha1.AerospikeVolumeModeEmptyDir, aerospikev1alpha1.AerospikeVolumeModeHostPath:
+		default:
+			continue
+		}
+		if !isPathParentOrSame(volume.Path, dir) {
+			continue
+		}
+		if match == nil || len(volume.Path) > len(match.Path) {
+			match = volume
+		}
+	}
+	return match
+}
 
+// getVolumeSize returns the size of a filesystem volume, nil if the volume size is not bounded.
+func getVolumeSize(volume *aerospikev1alpha1.AerospikePersistentVolumeSpec) *resource.Quantity {
+	switch volume.VolumeMode {
+	case aerospikev1alpha1.AerospikeVolumeModeFilesystem:
+		size := volume.GetSize()
+		return &size
+	case aerospikev1alpha1.AerospikeVolumeModeEmptyDir:
+		if volume.EmptyDir != nil && volume.EmptyDir.SizeLimit != nil && !volume.EmptyDir.SizeLimit.IsZero() {
+			return volume.EmptyDir.SizeLimit
+		}
+	}
 	return nil
 }
 
-func validateNamespaceReplicationFactor(logger log.Logger, nsConf map[string]interface{}, clSize int) error {
+// getSizeInBytes parses an aerospike config size value. Sizes are numbers of bytes or strings with an optional
+// K, M, G, T or P unit suffix (base 1024). Negative sizes and sizes that do not fit in an int64 are rejected.
+func getSizeInBytes(sizeInterface interface{}) (int64, error) {
+	switch size := sizeInterface.(type) {
+	case int:
+		if size < 0 {
+			return 0, fmt.Errorf("size %v cannot be negative", sizeInterface)
+		}
+		return int64(size), nil
+	case int64:
+		if size < 0 {
+			return 0, fmt.Errorf("size %v cannot be negative", sizeInterface)
+		}
+		return size, nil
+	case float64:
+		if size < 0 {
+			return 0, fmt.Errorf("size %v cannot be negative", sizeInterface)
+		}
+		if size >= math.MaxInt64 {
+			return 0, fmt.Errorf("size %v is too large", sizeInterface)
+		}
+		return int64(size), nil
+	case string:
+		if size == "" {
+			return 0, fmt.Errorf("size cannot be empty")
+		}
+
+		multiplier := int64(1)
+		switch size[len(size)-1] {
+		case 'K', 'k':
+			multiplier = 1 << 10
+		case 'M', 'm':
+			multiplier = 1 << 20
+		case 'G', 'g':
+			multiplier = 1 << 30
+		case 'T', 't':
+			multiplier = 1 << 40
+		case 'P', 'p':
+			multiplier = 1 << 50
+		}
+		if multiplier != 1 {
+			size = size[:len(size)-1]
+		}
+
+		n, err := strconv.ParseInt(size, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid size %v", sizeInterface)
+		}
+		if n < 0 {
+			return 0, fmt.Errorf("size %v cannot be negative", sizeInterface)
+		}
+		if n > math.MaxInt64/multiplier {
+			return 0, fmt.Errorf("size %v is too large", sizeInterface)
+		}
+		return n * multiplier, nil
+	}
+	return 0, fmt.Errorf("invalid size %v", sizeInterface)
+}
+
+func validateNamespaceReplicationFactor(logger log.Logger, nsConf map[string]interface{}, clSize int, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
 	// Validate replication-factor with cluster size only at the time of deployment
 	rfInterface, ok := nsConf["replication-factor"]
 	if !ok {
@@ -240,196 +638,228 @@ func validateNamespaceReplicationFactor(logger log.Logger, nsConf map[string]int
 
 	if rf, ok := rfInterface.(int64); ok {
 		if int64(clSize) < rf {
-			return fmt.Errorf("namespace replication-factor %v cannot be more than cluster size %d", rf, clSize)
+			allErrs = append(allErrs, field.Invalid(fldPath, rf, fmt.Sprintf("cannot be more than cluster size %d", clSize)))
 		}
 	} else if rf, ok := rfInterface.(int); ok {
 		if clSize < rf {
-			return fmt.Errorf("namespace replication-factor %v cannot be more than cluster size %d", rf, clSize)
+			allErrs = append(allErrs, field.Invalid(fldPath, rf, fmt.Sprintf("cannot be more than cluster size %d", clSize)))
 		}
 	} else {
-		return fmt.Errorf("namespace replication-factor %v not valid int or int64", rfInterface)
+		allErrs = append(allErrs, field.Invalid(fldPath, fieldValue(rfInterface), "not valid int or int64"))
 	}
 
-	return nil
+	return allErrs
 }
 
-func validateAerospikeConfigUpdate(logger log.Logger, newConf, oldConf aerospikev1alpha1.Values) error {
+func validateAerospikeConfigUpdate(logger log.Logger, newVersion, oldVersion string, newConf, oldConf aerospikev1alpha1.Values, fldPath *field.Path) field.ErrorList {
 	logger.Info("Validate AerospikeConfig update")
 
-	// Security can not be updated dynamically
-	// TODO: How to enable dynamic security update, need to pass policy for individual nodes.
-	// auth-enabled and auth-disabled node can co-exist
-	oldSec, ok1 := oldConf["security"]
-	newSec, ok2 := newConf["security"]
-	if ok1 != ok2 ||
-		ok1 && ok2 && (!reflect.DeepEqual(oldSec, newSec)) {
-		return fmt.Errorf("Cannot update cluster security config")
-	}
+	allErrs := field.ErrorList{}
 
-	// TLS can not be updated dynamically
-	// TODO: How to enable dynamic tls update, need to pass policy for individual nodes.
-	oldtls, ok11 := oldConf["network"].(map[string]interface{})["tls"]
-	newtls, ok22 := newConf["network"].(map[string]interface{})["tls"]
-	if ok11 != ok22 ||
-		ok11 && ok22 && (!reflect.DeepEqual(oldtls, newtls)) {
-		return fmt.Errorf("Cannot update cluster network.tls config")
-	}
+	// Security can be enabled or disabled. The pods are rolled to the new security config and the controller uses
+	// per node client policies while auth-enabled and auth-disabled nodes co-exist.
 
-	// network.service
-	if isValueUpdated(oldConf["network"].(map[string]interface{})["service"].(map[string]interface{}), newConf["network"].(map[string]interface{})["service"].(map[string]interface{}), "tls-name") {
-		return fmt.Errorf("Cannot update tls-name for network.service")
-	}
-	if isValueUpdated(oldConf["network"].(map[string]interface{})["service"].(map[string]interface{}), newConf["network"].(map[string]interface{})["service"].(map[string]interface{}), "tls-authenticate-client") {
-		return fmt.Errorf("Cannot update tls-authenticate-client for network.service")
-	}
+	oldNetwork, _ := oldConf["network"].(map[string]interface{})
+	newNetwork, _ := newConf["network"].(map[string]interface{})
+	networkPath := fldPath.Child("network")
 
-	// network.heartbeat
-	if isValueUpdated(oldConf["network"].(map[string]interface{})["heartbeat"].(map[string]interface{}), newConf["network"].(map[string]interface{})["heartbeat"].(map[string]interface{}), "tls-name") {
-		return fmt.Errorf("Cannot update tls-name for network.heartbeat")
+	// TLS can be added to the service and the certificates rotated with a rolling restart. The clear service port is
+	// always configured, so clients and the operator keep using it till all the pods are rolled to the TLS config.
+	// Nodes with and without heartbeat and fabric TLS cannot form a cluster, so those tls-names cannot be updated.
+	immutableNetworkKeys := []struct {
+		section string
+		keys    []string
+	}{
+		{"heartbeat", []string{"tls-name"}},
+		{"fabric", []string{"tls-name"}},
 	}
-
-	// network.fabric
-	if isValueUpdated(oldConf["network"].(map[string]interface{})["fabric"].(map[string]interface{}), newConf["network"].(map[string]interface{})["fabric"].(map[string]interface{}), "tls-name") {
-		return fmt.Errorf("Cannot update tls-name for network.fabric")
+	for _, section := range immutableNetworkKeys {
+		oldSection, _ := oldNetwork[section.section].(map[string]interface{})
+		newSection, _ := newNetwork[section.section].(map[string]interface{})
+		for _, key := range section.keys {
+			if isValueUpdated(oldSection, newSection, key) {
+				allErrs = append(allErrs, field.Forbidden(networkPath.Child(section.section, key), "cannot be updated"))
+			}
+		}
 	}
 
-	if err := validateNsConfUpdate(logger, newConf, oldConf); err != nil {
-		return err
-	}
+	allErrs = append(allErrs, validateNsConfUpdate(logger, newVersion, oldVersion, newConf, oldConf, fldPath.Child("namespaces"))...)
 
-	return nil
+	return allErrs
 }
 
-func validateNsConfUpdate(logger log.Logger, newConf, oldConf aerospikev1alpha1.Values) error {
+func validateNsConfUpdate(logger log.Logger, newVersion, oldVersion string, newConf, oldConf aerospikev1alpha1.Values, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
 
-	newNsConfList := newConf["namespaces"].([]interface{})
+	// The storage-engine has to be rewritten when upgrading to a version with a different namespace layout.
+	layoutChanged := isConfigLayoutChanged(newVersion, oldVersion)
+
+	newNsConfList, _ := newConf["namespaces"].([]interface{})
+	oldNsConfList, _ := oldConf["namespaces"].([]interface{})
+
+	for i, singleConfInterface := range newNsConfList {
+		nsPath := fldPath.Index(i)
 
-	for _, singleConfInterface := range newNsConfList {
 		// Validate new namespaceonf
 		singleConf, ok := singleConfInterface.(map[string]interface{})
 		if !ok {
-			return fmt.Errorf("Namespace conf not in valid format %v", singleConfInterface)
+			allErrs = append(allErrs, field.Invalid(nsPath, fieldValue(singleConfInterface), "namespace conf not in valid format"))
+			continue
 		}
 
 		// Validate new namespace conf from old namespace conf. Few filds cannot be updated
 		var found bool
-		oldNsConfList := oldConf["namespaces"].([]interface{})
 
 		for _, oldSingleConfInterface := range oldNsConfList {
-
 			oldSingleConf, ok := oldSingleConfInterface.(map[string]interface{})
-			if !ok {
-				return fmt.Errorf("Namespace conf not in valid format %v", oldSingleConfInterface)
+			if !ok || singleConf["name"] != oldSingleConf["name"] {
+				continue
 			}
+			found = true
 
-			if singleConf["name"] == oldSingleConf["name"] {
-				found = true
-
-				// replication-factor update not allowed
-				if isValueUpdated(oldSingleConf, singleConf, "replication-factor") {
-					return fmt.Errorf("replication-factor cannot be update. old nsconf %v, new nsconf %v", oldSingleConf, singleConf)
-				}
-				if isValueUpdated(oldSingleConf, singleConf, "tls-name") {
-					return fmt.Errorf("tls-name cannot be update. old nsconf %v, new nsconf %v", oldSingleConf, singleConf)
-				}
-				if isValueUpdated(oldSingleConf, singleConf, "tls-authenticate-client") {
-					return fmt.Errorf("tls-authenticate-client cannot be update. old nsconf %v, new nsconf %v", oldSingleConf, singleConf)
+			// replication-factor, tls-name, tls-authenticate-client update not allowed
+			for _, key := range []string{"replication-factor", "tls-name", "tls-authenticate-client"} {
+				if isValueUpdated(oldSingleConf, singleConf, key) {
+					allErrs = append(allErrs, field.Forbidden(nsPath.Child(key), "cannot be updated"))
 				}
+			}
 
-				// storage-engine update not allowed for now
-				storage, ok1 := singleConf["storage-engine"]
-				oldStorage, ok2 := oldSingleConf["storage-engine"]
-				if ok1 && !ok2 || !ok1 && ok2 {
-					return fmt.Errorf("storage-engine config cannot be added or removed from existing cluster. Old namespace config %v, new namespace config %v", oldSingleConf, singleConf)
-				}
-				if ok1 && ok2 && !reflect.DeepEqual(storage, oldStorage) {
-					return fmt.Errorf("storage-engine config cannot be changed. Old namespace config %v, new namespace config %v", oldSingleConf, singleConf)
-				}
+			// storage-engine update not allowed for now
+			storagePath := nsPath.Child("storage-engine")
+			storage, ok1 := singleConf["storage-engine"]
+			oldStorage, ok2 := oldSingleConf["storage-engine"]
+			if ok1 && !ok2 || !ok1 && ok2 {
+				allErrs = append(allErrs, field.Forbidden(storagePath, "cannot be added or removed from existing cluster"))
+			}
+			if ok1 && ok2 && !layoutChanged && !reflect.DeepEqual(storage, oldStorage) {
+				allErrs = append(allErrs, field.Forbidden(storagePath, "cannot be changed"))
+			}
+			if layoutChanged && isInMemoryNamespace(singleConf) != isInMemoryNamespace(oldSingleConf) {
+				allErrs = append(allErrs, field.Forbidden(storagePath, "persistence cannot be changed"))
 			}
 		}
 
 		// Cannot add new persistent namespaces.
 		if !found && !isInMemoryNamespace(singleConf) {
-			return fmt.Errorf("New persistent storage namespace %s cannot be added. Old namespace list %v, new namespace list %v", singleConf["name"], oldNsConfList, newNsConfList)
+			allErrs = append(allErrs, field.Forbidden(nsPath, fmt.Sprintf("new persistent storage namespace %v cannot be added", singleConf["name"])))
 		}
 	}
 	// Check for namespace name len
-	return nil
+	return allErrs
 }
 
-func validateAerospikeConfigSchema(logger log.Logger, version string, config aerospikev1alpha1.Values) error {
+func validateAerospikeConfigSchema(logger log.Logger, version string, config aerospikev1alpha1.Values, fldPath *field.Path) field.ErrorList {
 	logger = logger.New(log.Ctx{"version": version})
 
-	asConf, err := asconfig.NewMapAsConfig(version, config)
+	allErrs := field.ErrorList{}
+
+	configschema.RLock()
+	defer configschema.RUnlock()
+
+	schemaVersion, err := configschema.SchemaVersion(version)
 	if err != nil {
-		return fmt.Errorf("Failed to load config map by lib: %v", err)
+		return append(allErrs, field.InternalError(fldPath, err))
 	}
 
-	valid, validationErr, err := asConf.IsValid(version)
-	if !valid {
-		errStrs := []string{}
-		for _, e := range validationErr {
-			errStrs = append(errStrs, fmt.Sprintf("\t%v\n", *e))
-		}
+	asConf, err := asconfig.NewMapAsConfig(schemaVersion, config)
+	if err != nil {
+		return append(allErrs, field.InternalError(fldPath, fmt.Errorf("Failed to load config map by lib: %v", err)))
+	}
 
-		return fmt.Errorf("Generated config not valid for version %s: %v", version, errStrs)
+	valid, validationErrs, err := asConf.IsValid(schemaVersion)
+	if valid {
+		return allErrs
 	}
 
-	return nil
+	for _, e := range validationErrs {
+		allErrs = append(allErrs, schemaFieldError(fldPath, e))
+	}
+	if len(allErrs) == 0 {
+		allErrs = append(allErrs, field.InternalError(fldPath, fmt.Errorf("Generated config not valid for version %s: %v", version, err)))
+	}
+	return allErrs
+}
+
+// schemaFieldError converts a config schema validation error to a field error. The schema field, e.g.
+// namespaces.0.storage-engine, is converted to a path under fldPath.
+func schemaFieldError(fldPath *field.Path, e *asconfig.ValidationErr) *field.Error {
+	path := fldPath
+	if e.Field != "" && e.Field != "(root)" {
+		for _, name := range strings.Split(e.Field, ".") {
+			if index, err := strconv.Atoi(name); err == nil {
+				path = path.Index(index)
+			} else {
+				path = path.Child(name)
+			}
+		}
+	}
+
+	switch e.ErrType {
+	case "required":
+		return field.Required(path, e.Description)
+	case "additional_property_not_allowed":
+		return field.Forbidden(path, e.Description)
+	default:
+		return field.Invalid(path, fieldValue(e.Value), e.Description)
+	}
 }
 
-func validateRequiredFileStorage(logger log.Logger, config aerospikev1alpha1.Values, storage *aerospikev1alpha1.AerospikeStorageSpec, validationPolicy *aerospikev1alpha1.ValidationPolicySpec, version string) error {
+func validateRequiredFileStorage(logger log.Logger, config aerospikev1alpha1.Values, storage *aerospikev1alpha1.AerospikeStorageSpec, validationPolicy *aerospikev1alpha1.ValidationPolicySpec, version string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
 
+	// Storage errors are reported by validateStorage.
 	_, fileStorageList, err := storage.GetStorageList()
 	if err != nil {
-		return err
+		return allErrs
 	}
 
 	// Validate work directory.
 	if !validationPolicy.SkipWorkDirValidate {
 		workDirPath := utils.GetWorkDirectory(config)
+		workDirField := fldPath.Child("service", "work-directory")
 
 		if !filepath.IsAbs(workDirPath) {
-			return fmt.Errorf("Aerospike work directory path %s must be absolute in storage config %v", workDirPath, storage)
-		}
-
-		if !isFileStorageConfiguredForDir(fileStorageList, workDirPath) {
-			return fmt.Errorf("Aerospike work directory path %s not mounted on a filesystem in storage config %v", workDirPath, storage)
+			allErrs = append(allErrs, field.Invalid(workDirField, workDirPath, "must be absolute"))
+		} else if !isFileStorageConfiguredForDir(fileStorageList, workDirPath) {
+			allErrs = append(allErrs, field.Invalid(workDirField, workDirPath, "not mounted on a filesystem storage volume"))
 		}
 	}
 
 	if !validationPolicy.SkipXdrDlogFileValidate {
-		val, err := asconfig.CompareVersions(version, "5.0.0")
+		layout, err := getConfigLayout(version)
 		if err != nil {
-			return fmt.Errorf("Failed to check image version: %v", err)
+			return append(allErrs, field.InternalError(fldPath, err))
 		}
-		if val < 0 {
+		if layout.xdrDigestLog {
 			// Validate xdr-digestlog-path for pre-5.0.0 versions.
 			if utils.IsXdrEnabled(config) {
+				dglogField := fldPath.Child("xdr", "xdr-digestlog-path")
 				dglogFilePath, err := utils.GetDigestLogFile(config)
 				if err != nil {
-					return err
-				}
-
-				if !filepath.IsAbs(*dglogFilePath) {
-					return fmt.Errorf("xdr digestlog path %v must be absolute in storage config %v", dglogFilePath, storage)
-				}
-
-				dglogDirPath := filepath.Dir(*dglogFilePath)
-
-				if !isFileStorageConfiguredForDir(fileStorageList, dglogDirPath) {
-					return fmt.Errorf("xdr digestlog path %v not mounted in Storage config %v", dglogFilePath, storage)
+					allErrs = append(allErrs, field.Invalid(dglogField, nil, err.Error()))
+				} else if !filepath.IsAbs(*dglogFilePath) {
+					allErrs = append(allErrs, field.Invalid(dglogField, *dglogFilePath, "must be absolute"))
+				} else if !isFileStorageConfiguredForDir(fileStorageList, filepath.Dir(*dglogFilePath)) {
+					allErrs = append(allErrs, field.Invalid(dglogField, *dglogFilePath, "not mounted on a filesystem storage volume"))
 				}
 			}
 		}
 	}
 
-	return nil
+	return allErrs
 }
 
-func validateConfigMapVolumes(logger log.Logger, config aerospikev1alpha1.Values, storage *aerospikev1alpha1.AerospikeStorageSpec, validationPolicy *aerospikev1alpha1.ValidationPolicySpec, version string) error {
-	_, err := storage.GetConfigMaps()
-	return err
+// validateStorage validates the storage volumes.
+func validateStorage(storage *aerospikev1alpha1.AerospikeStorageSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if _, _, err := storage.GetStorageList(); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("volumes"), nil, err.Error()))
+	}
+	if _, err := storage.GetConfigMaps(); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("volumes"), nil, err.Error()))
+	}
+	return allErrs
 }
 
 func getImageVersion(imageStr string) (string, error) {
@@ -442,7 +872,8 @@ func getImageVersion(imageStr string) (string, error) {
 	return version, nil
 }
 
-// isInMemoryNamespace returns true if this namespace config uses memory for storage.
+// isInMemoryNamespace returns true if this namespace config uses memory for storage. From 7.0 a storage-engine memory
+// may be backed by devices or files, such a namespace is persistent and not in memory only.
 func isInMemoryNamespace(namespaceConf map[string]interface{}) bool {
 	storage, ok := namespaceConf["storage-engine"]
 	if !ok {
@@ -452,10 +883,11 @@ func isInMemoryNamespace(namespaceConf map[string]interface{}) bool {
 	storageConf := storage.(map[string]interface{})
 	typeStr, ok := storageConf["type"]
 
-	return ok && typeStr == "memory"
+	return ok && typeStr == "memory" && !hasStorageEngineDevices(storageConf)
 }
 
-// isDeviceNamespace returns true if this namespace config uses device for storage.
+// isDeviceNamespace returns true if this namespace config uses device for storage. This includes a storage-engine
+// memory backed by devices or files.
 func isDeviceNamespace(namespaceConf map[string]interface{}) bool {
 	storage, ok := namespaceConf["storage-engine"]
 	if !ok {
@@ -465,7 +897,14 @@ func isDeviceNamespace(namespaceConf map[string]interface{}) bool {
 	storageConf := storage.(map[string]interface{})
 	typeStr, ok := storageConf["type"]
 
-	return ok && typeStr == "device"
+	return ok && (typeStr == "device" || typeStr == "memory" && hasStorageEngineDevices(storageConf))
+}
+
+// hasStorageEngineDevices returns true if the storage-engine config has devices or files.
+func hasStorageEngineDevices(storageConf map[string]interface{}) bool {
+	_, hasDevices := storageConf["devices"]
+	_, hasFiles := storageConf["files"]
+	return hasDevices || hasFiles
 }
 
 // isShmemIndexTypeNamespace returns true if this namespace index type is shmem.
@@ -482,13 +921,70 @@ func isShmemIndexTypeNamespace(namespaceConf map[string]interface{}) bool {
 	return ok && typeStr == "shmem"
 }
 
-// isEnterprise indicates if aerospike image is enterprise
-func isEnterprise(image string) bool {
-	return strings.Contains(strings.ToLower(image), "enterprise")
+// validateCommunityEdition validates that the cluster does not use enterprise only features.
+func validateCommunityEdition(spec *aerospikev1alpha1.AerospikeClusterSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if spec.Size > maxCommunityClusterSz {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("size"), spec.Size, fmt.Sprintf("cannot be more than %d for community edition", maxCommunityClusterSz)))
+	}
+	if spec.AerospikeAccessControl != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("aerospikeAccessControl"), "access control is not supported by community edition"))
+	}
+
+	allErrs = append(allErrs, validateCommunityConfig(spec.AerospikeConfig, fldPath.Child("aerospikeConfig"))...)
+
+	// Rack effective configs include the common config, hence only check the rack overrides.
+	racksPath := fldPath.Child("rackConfig", "racks")
+	for i, rack := range spec.RackConfig.Racks {
+		if rack.InputAerospikeConfig != nil {
+			allErrs = append(allErrs, validateCommunityConfig(utils.GetRackConfigOverride(rack), racksPath.Index(i).Child("aerospikeConfig"))...)
+		}
+	}
+	return allErrs
+}
+
+// validateCommunityConfig validates that aerospikeConfig does not have enterprise only sections and namespace settings.
+func validateCommunityConfig(config aerospikev1alpha1.Values, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for _, key := range []string{"security", "xdr"} {
+		if _, ok := config[key]; ok {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child(key), "not supported by community edition"))
+		}
+	}
+	if serviceConf, ok := config["service"].(map[string]interface{}); ok {
+		if _, ok := serviceConf["feature-key-file"]; ok {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("service", "feature-key-file"), "not supported by community edition"))
+		}
+	}
+	if utils.IsTLS(config) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("network", "tls"), "not supported by community edition"))
+	}
+
+	nsConfList, _ := config["namespaces"].([]interface{})
+	for i, nsConfInterface := range nsConfList {
+		nsConf, ok := nsConfInterface.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		nsPath := fldPath.Child("namespaces").Index(i)
+
+		if sc, ok := nsConf["strong-consistency"].(bool); ok && sc {
+			allErrs = append(allErrs, field.Forbidden(nsPath.Child("strong-consistency"), "not supported by community edition"))
+		}
+		if indexType, ok := nsConf["index-type"].(map[string]interface{}); ok && indexType["type"] != "shmem" {
+			allErrs = append(allErrs, field.Forbidden(nsPath.Child("index-type"), fmt.Sprintf("index-type %v not supported by community edition", indexType["type"])))
+		}
+		if storage, ok := nsConf["storage-engine"].(map[string]interface{}); ok && storage["type"] == "pmem" {
+			allErrs = append(allErrs, field.Forbidden(nsPath.Child("storage-engine"), "storage-engine pmem not supported by community edition"))
+		}
+	}
+	return allErrs
 }
 
-// isSecretNeeded indicates if aerospikeConfig needs secret
-func isSecretNeeded(aerospikeConfig aerospikev1alpha1.Values) bool {
+// isSecretNeeded indicates if aerospikeConfig needs secret. TLS files are not needed in the secret with cert-manager.
+func isSecretNeeded(aerospikeConfig aerospikev1alpha1.Values, certManager *aerospikev1alpha1.AerospikeCertManagerSpec) bool {
 	// feature-key-file needs secret
 	if svc, ok := aerospikeConfig["service"]; ok {
 		if _, ok := svc.(map[string]interface{})["feature-key-file"]; ok {
@@ -497,7 +993,7 @@ func isSecretNeeded(aerospikeConfig aerospikev1alpha1.Values) bool {
 	}
 
 	// tls needs secret
-	if utils.IsTLS(aerospikeConfig) {
+	if utils.IsTLS(aerospikeConfig) && certManager == nil {
 		return true
 	}
 	return false
@@ -525,27 +1021,50 @@ func isPathParentOrSame(dir1 string, dir2 string) bool {
 	return false
 }
 
-func (s *ClusterValidatingAdmissionWebhook) validatePodSpec() error {
+func (s *ClusterValidatingAdmissionWebhook) validatePodSpec(fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
 	sidecarNames := map[string]int{}
+	sidecarsPath := fldPath.Child("podSpec", "sidecars")
 
-	for _, sidecar := range s.obj.Spec.PodSpec.Sidecars {
+	for i, sidecar := range s.obj.Spec.PodSpec.Sidecars {
 		// Check for reserved sidecar name
 		if sidecar.Name == utils.AerospikeServerContainerName || sidecar.Name == utils.AerospikeServerInitContainerName {
-			return fmt.Errorf("Cannot use reserved sidecar name: %v", sidecar.Name)
+			allErrs = append(allErrs, field.Invalid(sidecarsPath.Index(i).Child("name"), sidecar.Name, "cannot use reserved sidecar name"))
 		}
 
 		// Check for duplicate names
 		if _, ok := sidecarNames[sidecar.Name]; ok {
-			return fmt.Errorf("Connot have duplicate names of sidecars: %v", sidecar.Name)
+			allErrs = append(allErrs, field.Duplicate(sidecarsPath.Index(i).Child("name"), sidecar.Name))
 		}
 		sidecarNames[sidecar.Name] = 1
 
-		_, err := getImageVersion(sidecar.Image)
-
-		if err != nil {
-			return err
+		if _, err := getImageVersion(sidecar.Image); err != nil {
+			allErrs = append(allErrs, field.Invalid(sidecarsPath.Index(i).Child("image"), sidecar.Image, err.Error()))
 		}
 	}
 
-	return nil
+	// Validate volumes attached to sidecars
+	allErrs = append(allErrs, validateVolumeAttachments(&s.obj.Spec.Storage, sidecarNames, fldPath.Child("storage"))...)
+
+	racksPath := fldPath.Child("rackConfig", "racks")
+	for i, rack := range s.obj.Spec.RackConfig.Racks {
+		allErrs = append(allErrs, validateVolumeAttachments(&rack.Storage, sidecarNames, racksPath.Index(i).Child("effectiveStorage"))...)
+	}
+
+	return allErrs
+}
+
+// validateVolumeAttachments validates that the volumes are attached to existing sidecars.
+func validateVolumeAttachments(storage *aerospikev1alpha1.AerospikeStorageSpec, sidecarNames map[string]int, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, volume := range storage.Volumes {
+		for j, attachment := range volume.Sidecars {
+			if _, ok := sidecarNames[attachment.ContainerName]; !ok {
+				allErrs = append(allErrs, field.NotFound(fldPath.Child("volumes").Index(i).Child("sidecars").Index(j).Child("containerName"), attachment.ContainerName))
+			}
+		}
+	}
+	return allErrs
 }
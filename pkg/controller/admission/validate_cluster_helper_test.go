@@ -0,0 +1,713 @@
+package admission
+
+import (
+	"reflect"
+	"testing"
+
+	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
+	"github.com/aerospike/aerospike-management-lib/asconfig"
+	log15 "github.com/inconshreveable/log15"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var sizeStorage = aerospikev1alpha1.AerospikeStorageSpec{
+	Volumes: []aerospikev1alpha1.AerospikePersistentVolumeSpec{
+		{
+			Path:         "/opt/aerospike",
+			SizeInGB:     1,
+			StorageClass: "ssd",
+			VolumeMode:   aerospikev1alpha1.AerospikeVolumeModeFilesystem,
+		},
+		{
+			Path:         "/opt/aerospike/data",
+			SizeInGB:     2,
+			StorageClass: "ssd",
+			VolumeMode:   aerospikev1alpha1.AerospikeVolumeModeFilesystem,
+		},
+	},
+}
+
+var nsPath = field.NewPath("spec", "aerospikeConfig", "namespaces")
+
+func fileNamespace(name string, filesize interface{}, files ...interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"name":        name,
+		"memory-size": "512M",
+		"storage-engine": map[string]interface{}{
+			"type":     "device",
+			"files":    files,
+			"filesize": filesize,
+		},
+	}
+}
+
+func TestGetSizeInBytes(t *testing.T) {
+	tests := []struct {
+		in   interface{}
+		want int64
+		err  bool
+	}{
+		{in: 1024, want: 1024},
+		{in: int64(2048), want: 2048},
+		{in: float64(4096), want: 4096},
+		{in: "100", want: 100},
+		{in: "4K", want: 4 << 10},
+		{in: "2g", want: 2 << 30},
+		{in: "1T", want: 1 << 40},
+		{in: "", err: true},
+		{in: "1.5G", err: true},
+		{in: true, err: true},
+		{in: -1, err: true},
+		{in: int64(-1), err: true},
+		{in: float64(-1), err: true},
+		{in: float64(1 << 63), err: true},
+		{in: "-4K", err: true},
+		{in: "8191P", want: 8191 << 50},
+		{in: "8192P", err: true},
+		{in: "9223372036854775807K", err: true},
+	}
+
+	for _, test := range tests {
+		got, err := getSizeInBytes(test.in)
+		if test.err {
+			if err == nil {
+				t.Errorf("getSizeInBytes(%v) expected error", test.in)
+			}
+			continue
+		}
+		if err != nil || got != test.want {
+			t.Errorf("getSizeInBytes(%v) = %d, %v, want %d", test.in, got, err, test.want)
+		}
+	}
+}
+
+func TestIsNamespaceMemoryChanged(t *testing.T) {
+	mem := resource.MustParse("2Gi")
+	newSpec := func() aerospikev1alpha1.AerospikeClusterSpec {
+		return aerospikev1alpha1.AerospikeClusterSpec{
+			Size:  2,
+			Image: "aerospike/aerospike-server-enterprise:5.5.0.3",
+			Resources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: mem},
+			},
+			AerospikeConfig: aerospikev1alpha1.Values{
+				"namespaces": []interface{}{map[string]interface{}{"name": "test", "memory-size": 1000955200}},
+			},
+			RackConfig: aerospikev1alpha1.RackConfig{
+				Racks: []aerospikev1alpha1.Rack{{ID: 1}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		update func(spec *aerospikev1alpha1.AerospikeClusterSpec)
+		want   bool
+	}{
+		{
+			name:   "unchanged",
+			update: func(spec *aerospikev1alpha1.AerospikeClusterSpec) {},
+		},
+		{
+			name:   "size",
+			update: func(spec *aerospikev1alpha1.AerospikeClusterSpec) { spec.Size = 3 },
+		},
+		{
+			name: "memory-size",
+			update: func(spec *aerospikev1alpha1.AerospikeClusterSpec) {
+				spec.AerospikeConfig["namespaces"] = []interface{}{map[string]interface{}{"name": "test", "memory-size": 2000955200}}
+			},
+			want: true,
+		},
+		{
+			name: "resources",
+			update: func(spec *aerospikev1alpha1.AerospikeClusterSpec) {
+				spec.Resources.Requests[corev1.ResourceMemory] = resource.MustParse("1Gi")
+			},
+			want: true,
+		},
+		{
+			name: "image",
+			update: func(spec *aerospikev1alpha1.AerospikeClusterSpec) {
+				spec.Image = "aerospike/aerospike-server-enterprise:7.0.0.0"
+			},
+			want: true,
+		},
+		{
+			name: "rack config",
+			update: func(spec *aerospikev1alpha1.AerospikeClusterSpec) {
+				spec.RackConfig.Racks[0].AerospikeConfig = aerospikev1alpha1.Values{"namespaces": []interface{}{}}
+			},
+			want: true,
+		},
+		{
+			name: "rack added",
+			update: func(spec *aerospikev1alpha1.AerospikeClusterSpec) {
+				spec.RackConfig.Racks = append(spec.RackConfig.Racks, aerospikev1alpha1.Rack{ID: 2})
+			},
+			want: true,
+		},
+	}
+
+	for _, test := range tests {
+		spec := newSpec()
+		test.update(&spec)
+		if got := isNamespaceMemoryChanged(spec, newSpec()); got != test.want {
+			t.Errorf("%s: got changed %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestValidateNamespaceFileSize(t *testing.T) {
+	tests := []struct {
+		name   string
+		nsList []interface{}
+		valid  bool
+	}{
+		{
+			name:   "fits in deepest volume",
+			nsList: []interface{}{fileNamespace("test", "2G", "/opt/aerospike/data/test.dat")},
+			valid:  true,
+		},
+		{
+			name:   "exceeds parent volume",
+			nsList: []interface{}{fileNamespace("test", "2G", "/opt/aerospike/test.dat")},
+		},
+		{
+			name: "sum across namespaces exceeds volume",
+			nsList: []interface{}{
+				fileNamespace("test", "1G", "/opt/aerospike/data/test.dat"),
+				fileNamespace("bar", 1073741825, "/opt/aerospike/data/bar.dat"),
+			},
+		},
+		{
+			name:   "sum across files exceeds volume",
+			nsList: []interface{}{fileNamespace("test", "1G", "/opt/aerospike/data/t1.dat", "/opt/aerospike/data/t2.dat", "/opt/aerospike/data/t3.dat")},
+		},
+		{
+			name:   "file outside volumes",
+			nsList: []interface{}{fileNamespace("test", "4G", "/tmp/test.dat")},
+			valid:  true,
+		},
+	}
+
+	for _, test := range tests {
+		errs := validateNamespaceFileSize(test.nsList, &sizeStorage, nsPath)
+		if test.valid && len(errs) != 0 {
+			t.Errorf("%s: unexpected errors %v", test.name, errs)
+		} else if !test.valid && len(errs) == 0 {
+			t.Errorf("%s: expected error", test.name)
+		}
+	}
+}
+
+func TestValidateNamespaceMemorySize(t *testing.T) {
+	nsList := []interface{}{
+		fileNamespace("test", "1G", "/opt/aerospike/data/test.dat"),
+		fileNamespace("bar", "1G", "/opt/aerospike/data/bar.dat"),
+	}
+
+	tests := []struct {
+		name      string
+		resources *corev1.ResourceRequirements
+		valid     bool
+	}{
+		{
+			name:  "no resources",
+			valid: true,
+		},
+		{
+			name: "fits in request",
+			resources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+			},
+			valid: true,
+		},
+		{
+			name: "exceeds request",
+			resources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1000Mi")},
+			},
+		},
+		{
+			name: "exceeds limit",
+			resources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+				Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1000Mi")},
+			},
+		},
+	}
+
+	layout, err := getConfigLayout("5.5.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range tests {
+		errs := validateNamespaceMemorySize(layout, nsList, &sizeStorage, test.resources, nsPath)
+		if test.valid && len(errs) != 0 {
+			t.Errorf("%s: unexpected errors %v", test.name, errs)
+		} else if !test.valid && len(errs) == 0 {
+			t.Errorf("%s: expected error", test.name)
+		}
+	}
+}
+
+func memoryNamespace(name string, storageEngine map[string]interface{}) map[string]interface{} {
+	storageEngine["type"] = "memory"
+	return map[string]interface{}{
+		"name":           name,
+		"storage-engine": storageEngine,
+	}
+}
+
+func TestGetConfigLayout(t *testing.T) {
+	tests := []struct {
+		version             string
+		xdrDigestLog        bool
+		namespaceMemorySize bool
+		err                 bool
+	}{
+		{version: "4.9.0.11", xdrDigestLog: true, namespaceMemorySize: true},
+		{version: "5.0.0", namespaceMemorySize: true},
+		{version: "6.4.0.7", namespaceMemorySize: true},
+		{version: "7.0.0.2"},
+		{version: "7.1.0"},
+		{version: "4.5.0", err: true},
+		{version: "a.b.c", err: true},
+	}
+
+	for _, test := range tests {
+		layout, err := getConfigLayout(test.version)
+		if test.err {
+			if err == nil {
+				t.Errorf("getConfigLayout(%s) expected error", test.version)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("getConfigLayout(%s) unexpected error %v", test.version, err)
+			continue
+		}
+		if layout.xdrDigestLog != test.xdrDigestLog || layout.namespaceMemorySize != test.namespaceMemorySize {
+			t.Errorf("getConfigLayout(%s) = %+v", test.version, *layout)
+		}
+	}
+
+	if isConfigLayoutChanged("6.4.0.7", "6.0.0") {
+		t.Errorf("layout changed from 6.0.0 to 6.4.0.7")
+	}
+	if !isConfigLayoutChanged("7.0.0", "6.4.0.7") {
+		t.Errorf("layout not changed from 6.4.0.7 to 7.0.0")
+	}
+}
+
+func TestValidateNamespaceLayout(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		nsConf  map[string]interface{}
+		valid   bool
+	}{
+		{
+			name:    "memory-size before 7.0",
+			version: "6.4.0",
+			nsConf:  fileNamespace("test", "1G", "/opt/aerospike/data/test.dat"),
+			valid:   true,
+		},
+		{
+			name:    "memory-size from 7.0",
+			version: "7.0.0",
+			nsConf:  fileNamespace("test", "1G", "/opt/aerospike/data/test.dat"),
+		},
+		{
+			name:    "data-in-memory from 7.0",
+			version: "7.0.0",
+			nsConf: map[string]interface{}{
+				"name":           "test",
+				"storage-engine": map[string]interface{}{"type": "device", "files": []interface{}{"/opt/aerospike/data/test.dat"}, "data-in-memory": true},
+			},
+		},
+		{
+			name:    "memory data-size from 7.0",
+			version: "7.0.0",
+			nsConf:  memoryNamespace("test", map[string]interface{}{"data-size": "1G"}),
+			valid:   true,
+		},
+		{
+			name:    "memory data-size before 7.0",
+			version: "6.4.0",
+			nsConf:  memoryNamespace("test", map[string]interface{}{"data-size": "1G"}),
+		},
+		{
+			name:    "memory files before 7.0",
+			version: "5.5.0",
+			nsConf:  memoryNamespace("test", map[string]interface{}{"files": []interface{}{"/opt/aerospike/data/test.dat"}}),
+		},
+	}
+
+	for _, test := range tests {
+		layout, err := getConfigLayout(test.version)
+		if err != nil {
+			t.Fatal(err)
+		}
+		errs := validateNamespaceLayout(layout, test.version, test.nsConf, nsPath.Index(0))
+		if test.valid && len(errs) != 0 {
+			t.Errorf("%s: unexpected errors %v", test.name, errs)
+		} else if !test.valid && len(errs) == 0 {
+			t.Errorf("%s: expected error", test.name)
+		}
+	}
+}
+
+func TestValidateStorageEngineMemorySize(t *testing.T) {
+	storage := sizeStorage
+	storage.Volumes = append(append([]aerospikev1alpha1.AerospikePersistentVolumeSpec{}, sizeStorage.Volumes...), aerospikev1alpha1.AerospikePersistentVolumeSpec{
+		Path:         "/dev/nvme0n1",
+		SizeInGB:     4,
+		StorageClass: "ssd",
+		VolumeMode:   aerospikev1alpha1.AerospikeVolumeModeBlock,
+	})
+
+	tests := []struct {
+		name   string
+		nsList []interface{}
+		valid  bool
+	}{
+		{
+			name:   "data-size fits",
+			nsList: []interface{}{memoryNamespace("test", map[string]interface{}{"data-size": "1G"})},
+			valid:  true,
+		},
+		{
+			name: "data-size sum exceeds",
+			nsList: []interface{}{
+				memoryNamespace("test", map[string]interface{}{"data-size": "2G"}),
+				memoryNamespace("bar", map[string]interface{}{"data-size": "2G"}),
+			},
+		},
+		{
+			name: "files exceed",
+			nsList: []interface{}{
+				memoryNamespace("test", map[string]interface{}{"filesize": "2G", "files": []interface{}{"/opt/aerospike/data/t1.dat", "/opt/aerospike/data/t2.dat"}}),
+			},
+		},
+		{
+			name:   "device exceeds",
+			nsList: []interface{}{memoryNamespace("test", map[string]interface{}{"devices": []interface{}{"/dev/nvme0n1"}})},
+		},
+		{
+			name:   "device storage not counted",
+			nsList: []interface{}{fileNamespace("test", "4G", "/opt/aerospike/data/test.dat")},
+			valid:  true,
+		},
+	}
+
+	layout, err := getConfigLayout("7.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resources := &corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("3Gi")},
+	}
+
+	for _, test := range tests {
+		errs := validateNamespaceMemorySize(layout, test.nsList, &storage, resources, nsPath)
+		if test.valid && len(errs) != 0 {
+			t.Errorf("%s: unexpected errors %v", test.name, errs)
+		} else if !test.valid && len(errs) == 0 {
+			t.Errorf("%s: expected error", test.name)
+		}
+	}
+}
+
+func TestValidateXdrConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		xdrConf map[string]interface{}
+		valid   bool
+	}{
+		{
+			name:    "digest log before 5.0",
+			version: "4.9.0",
+			xdrConf: map[string]interface{}{"xdr-digestlog-path": "/opt/aerospike/xdr/digestlog 5G"},
+			valid:   true,
+		},
+		{
+			name:    "digest log from 5.0",
+			version: "5.5.0",
+			xdrConf: map[string]interface{}{"xdr-digestlog-path": "/opt/aerospike/xdr/digestlog 5G"},
+		},
+		{
+			name:    "dcs",
+			version: "7.0.0",
+			xdrConf: map[string]interface{}{"dcs": []interface{}{map[string]interface{}{"name": "dc1"}}},
+			valid:   true,
+		},
+		{
+			name:    "dc without name",
+			version: "6.0.0",
+			xdrConf: map[string]interface{}{"dcs": []interface{}{map[string]interface{}{"node-address-ports": []interface{}{"1.2.3.4 3000"}}}},
+		},
+	}
+
+	for _, test := range tests {
+		layout, err := getConfigLayout(test.version)
+		if err != nil {
+			t.Fatal(err)
+		}
+		errs := validateXdrConfig(layout, test.version, aerospikev1alpha1.Values{"xdr": test.xdrConf}, field.NewPath("spec", "aerospikeConfig", "xdr"))
+		if test.valid && len(errs) != 0 {
+			t.Errorf("%s: unexpected errors %v", test.name, errs)
+		} else if !test.valid && len(errs) == 0 {
+			t.Errorf("%s: expected error", test.name)
+		}
+	}
+}
+
+func errorFields(errs field.ErrorList) []string {
+	fields := []string{}
+	for _, err := range errs {
+		fields = append(fields, err.Field)
+	}
+	return fields
+}
+
+func TestValidateAerospikeConfigFieldPaths(t *testing.T) {
+	config := aerospikev1alpha1.Values{
+		"service": map[string]interface{}{"cluster-name": "test"},
+		"network": map[string]interface{}{
+			"service": map[string]interface{}{"port": 3000},
+			"tls":     []interface{}{map[string]interface{}{"name": "tls1", "ca-path": "/etc/certs"}},
+		},
+		"namespaces": []interface{}{
+			fileNamespace("test", "1G", "/opt/aerospike/data/test.dat"),
+			map[string]interface{}{
+				"name":               "bar",
+				"replication-factor": 3,
+				"storage-engine":     map[string]interface{}{"type": "device", "files": []interface{}{"/tmp/bar.dat"}},
+			},
+		},
+	}
+
+	storage := sizeStorage
+	storage.Volumes = append([]aerospikev1alpha1.AerospikePersistentVolumeSpec{}, sizeStorage.Volumes...)
+	storage.SetDefaults()
+
+	errs := validateAerospikeConfig(log15.New(), "5.5.0", config, &storage, 2, field.NewPath("spec", "aerospikeConfig"))
+	want := []string{
+		"spec.aerospikeConfig.network.tls[0].ca-path",
+		"spec.aerospikeConfig.namespaces[1].replication-factor",
+		"spec.aerospikeConfig.namespaces[1].storage-engine.files[0]",
+	}
+	if !reflect.DeepEqual(errorFields(errs), want) {
+		t.Errorf("got error fields %v, want %v", errorFields(errs), want)
+	}
+}
+
+func TestValidateNsConfUpdateFieldPaths(t *testing.T) {
+	oldConf := aerospikev1alpha1.Values{
+		"namespaces": []interface{}{
+			map[string]interface{}{"name": "test", "replication-factor": 2, "storage-engine": map[string]interface{}{"type": "memory"}},
+		},
+	}
+	newConf := aerospikev1alpha1.Values{
+		"namespaces": []interface{}{
+			fileNamespace("bar", "1G", "/opt/aerospike/data/bar.dat"),
+			map[string]interface{}{"name": "test", "replication-factor": 3, "storage-engine": map[string]interface{}{"type": "memory"}},
+		},
+	}
+
+	errs := validateNsConfUpdate(log15.New(), "5.5.0", "5.5.0", newConf, oldConf, nsPath)
+	want := []string{
+		"spec.aerospikeConfig.namespaces[0]",
+		"spec.aerospikeConfig.namespaces[1].replication-factor",
+	}
+	if !reflect.DeepEqual(errorFields(errs), want) {
+		t.Errorf("got error fields %v, want %v", errorFields(errs), want)
+	}
+}
+
+func TestValidateAerospikeConfigUpdateSecurity(t *testing.T) {
+	fldPath := field.NewPath("spec", "aerospikeConfig")
+	oldConf := aerospikev1alpha1.Values{"service": map[string]interface{}{"cluster-name": "test"}}
+	newConf := aerospikev1alpha1.Values{
+		"service":  map[string]interface{}{"cluster-name": "test"},
+		"security": map[string]interface{}{"enable-security": true},
+	}
+
+	// Security is enabled and disabled with a rolling restart.
+	if errs := validateAerospikeConfigUpdate(log15.New(), "5.5.0", "5.5.0", newConf, oldConf, fldPath); len(errs) != 0 {
+		t.Errorf("enable security: got errors %v", errs)
+	}
+	if errs := validateAerospikeConfigUpdate(log15.New(), "5.5.0", "5.5.0", oldConf, newConf, fldPath); len(errs) != 0 {
+		t.Errorf("disable security: got errors %v", errs)
+	}
+
+	// Service TLS is added with a rolling restart, heartbeat TLS cannot be updated.
+	newConf["network"] = map[string]interface{}{
+		"service": map[string]interface{}{"tls-name": "tls", "tls-authenticate-client": "false"},
+		"tls":     []interface{}{map[string]interface{}{"name": "tls"}},
+	}
+	if errs := validateAerospikeConfigUpdate(log15.New(), "5.5.0", "5.5.0", newConf, oldConf, fldPath); len(errs) != 0 {
+		t.Errorf("enable service TLS: got errors %v", errs)
+	}
+	newConf["network"].(map[string]interface{})["heartbeat"] = map[string]interface{}{"tls-name": "tls"}
+	errs := validateAerospikeConfigUpdate(log15.New(), "5.5.0", "5.5.0", newConf, oldConf, fldPath)
+	if want := []string{"spec.aerospikeConfig.network.heartbeat.tls-name"}; !reflect.DeepEqual(errorFields(errs), want) {
+		t.Errorf("got error fields %v, want %v", errorFields(errs), want)
+	}
+}
+
+func TestSchemaFieldError(t *testing.T) {
+	fldPath := field.NewPath("spec", "aerospikeConfig")
+	tests := []struct {
+		err       asconfig.ValidationErr
+		wantField string
+		wantType  field.ErrorType
+	}{
+		{
+			err:       asconfig.ValidationErr{ErrType: "required", Field: "namespaces.1", Description: "memory-size is required"},
+			wantField: "spec.aerospikeConfig.namespaces[1]",
+			wantType:  field.ErrorTypeRequired,
+		},
+		{
+			err:       asconfig.ValidationErr{ErrType: "additional_property_not_allowed", Field: "namespaces.0.storage-engine", Description: "Additional property foo is not allowed"},
+			wantField: "spec.aerospikeConfig.namespaces[0].storage-engine",
+			wantType:  field.ErrorTypeForbidden,
+		},
+		{
+			err:       asconfig.ValidationErr{ErrType: "invalid_type", Field: "service.proto-fd-max", Description: "Invalid type", Value: "many"},
+			wantField: "spec.aerospikeConfig.service.proto-fd-max",
+			wantType:  field.ErrorTypeInvalid,
+		},
+		{
+			err:       asconfig.ValidationErr{ErrType: "required", Field: "(root)", Description: "namespaces is required"},
+			wantField: "spec.aerospikeConfig",
+			wantType:  field.ErrorTypeRequired,
+		},
+	}
+
+	for _, test := range tests {
+		err := schemaFieldError(fldPath, &test.err)
+		if err.Field != test.wantField || err.Type != test.wantType {
+			t.Errorf("schemaFieldError(%v) = %s %s, want %s %s", test.err, err.Field, err.Type, test.wantField, test.wantType)
+		}
+	}
+}
+
+func k8sNode(name string, nodeLabels map[string]string, taints ...corev1.Taint) corev1.Node {
+	node := corev1.Node{}
+	node.Name = name
+	node.Labels = map[string]string{"kubernetes.io/hostname": name}
+	for k, v := range nodeLabels {
+		node.Labels[k] = v
+	}
+	node.Spec.Taints = taints
+	return node
+}
+
+func TestValidateNodeScheduling(t *testing.T) {
+	zoneA := map[string]string{"failure-domain.beta.kubernetes.io/zone": "a"}
+	zoneB := map[string]string{"failure-domain.beta.kubernetes.io/zone": "b"}
+	cordoned := k8sNode("cordoned", zoneA)
+	cordoned.Spec.Unschedulable = true
+	tainted := k8sNode("tainted", zoneA, corev1.Taint{Key: "dedicated", Value: "db", Effect: corev1.TaintEffectNoSchedule})
+	nodes := []corev1.Node{k8sNode("a1", zoneA), k8sNode("a2", zoneA), k8sNode("b1", zoneB), cordoned, tainted}
+
+	zoneRacks := []aerospikev1alpha1.Rack{{ID: 1, Zone: "a"}, {ID: 2, Zone: "b"}}
+	tests := []struct {
+		name            string
+		size            int
+		racks           []aerospikev1alpha1.Rack
+		multiPodPerHost bool
+		want            []string
+	}{
+		{name: "enough nodes", size: 3, racks: []aerospikev1alpha1.Rack{{ID: 0}}, want: []string{}},
+		{name: "too few nodes", size: 4, racks: []aerospikev1alpha1.Rack{{ID: 0}}, want: []string{"spec.rackConfig.racks[0]"}},
+		{name: "multiple pods per host", size: 10, racks: []aerospikev1alpha1.Rack{{ID: 0}}, multiPodPerHost: true, want: []string{}},
+		{name: "racks by zone", size: 3, racks: zoneRacks, want: []string{}},
+		{name: "rack zone too small", size: 4, racks: zoneRacks, want: []string{"spec.rackConfig.racks[1]"}},
+		{name: "no node in zone", size: 2, racks: []aerospikev1alpha1.Rack{{ID: 1, Zone: "c"}}, multiPodPerHost: true, want: []string{"spec.rackConfig.racks[0]"}},
+		{name: "node name", size: 1, racks: []aerospikev1alpha1.Rack{{ID: 1, NodeName: "b1"}}, want: []string{}},
+		{name: "cordoned node name", size: 1, racks: []aerospikev1alpha1.Rack{{ID: 1, NodeName: "cordoned"}}, want: []string{"spec.rackConfig.racks[0]"}},
+		{name: "racks share nodes", size: 4, racks: []aerospikev1alpha1.Rack{{ID: 1}, {ID: 2}}, want: []string{"spec.size"}},
+	}
+
+	for _, test := range tests {
+		errs := validateNodeScheduling(nodes, test.size, test.racks, test.multiPodPerHost, field.NewPath("spec"))
+		if got := errorFields(errs); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got error fields %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestValidateCommunityEdition(t *testing.T) {
+	ceConfig := func() aerospikev1alpha1.Values {
+		return aerospikev1alpha1.Values{
+			"service": map[string]interface{}{"cluster-name": "test"},
+			"network": map[string]interface{}{"service": map[string]interface{}{"port": 3000}},
+			"namespaces": []interface{}{
+				map[string]interface{}{"name": "test", "storage-engine": map[string]interface{}{"type": "memory"}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(spec *aerospikev1alpha1.AerospikeClusterSpec)
+		want   []string
+	}{
+		{name: "valid", mutate: func(spec *aerospikev1alpha1.AerospikeClusterSpec) {}, want: []string{}},
+		{
+			name:   "size",
+			mutate: func(spec *aerospikev1alpha1.AerospikeClusterSpec) { spec.Size = 9 },
+			want:   []string{"spec.size"},
+		},
+		{
+			name: "security",
+			mutate: func(spec *aerospikev1alpha1.AerospikeClusterSpec) {
+				spec.AerospikeConfig["security"] = map[string]interface{}{"enable-security": true}
+				spec.AerospikeAccessControl = &aerospikev1alpha1.AerospikeAccessControlSpec{}
+			},
+			want: []string{"spec.aerospikeAccessControl", "spec.aerospikeConfig.security"},
+		},
+		{
+			name: "tls and feature key",
+			mutate: func(spec *aerospikev1alpha1.AerospikeClusterSpec) {
+				spec.AerospikeConfig["service"].(map[string]interface{})["feature-key-file"] = "/etc/aerospike/secret/features.conf"
+				spec.AerospikeConfig["network"].(map[string]interface{})["tls"] = []interface{}{map[string]interface{}{"name": "tls"}}
+			},
+			want: []string{"spec.aerospikeConfig.service.feature-key-file", "spec.aerospikeConfig.network.tls"},
+		},
+		{
+			name: "namespace features",
+			mutate: func(spec *aerospikev1alpha1.AerospikeClusterSpec) {
+				nsConf := spec.AerospikeConfig["namespaces"].([]interface{})[0].(map[string]interface{})
+				nsConf["strong-consistency"] = true
+				nsConf["index-type"] = map[string]interface{}{"type": "flash"}
+			},
+			want: []string{"spec.aerospikeConfig.namespaces[0].strong-consistency", "spec.aerospikeConfig.namespaces[0].index-type"},
+		},
+		{
+			name: "rack xdr",
+			mutate: func(spec *aerospikev1alpha1.AerospikeClusterSpec) {
+				rackConfig := aerospikev1alpha1.Values{"xdr": map[string]interface{}{}}
+				spec.RackConfig.Racks = []aerospikev1alpha1.Rack{{ID: 1}, {ID: 2, InputAerospikeConfig: &rackConfig}}
+			},
+			want: []string{"spec.rackConfig.racks[1].aerospikeConfig.xdr"},
+		},
+	}
+
+	for _, test := range tests {
+		spec := &aerospikev1alpha1.AerospikeClusterSpec{Size: 8, Image: "aerospike/aerospike-server:5.5.0.3", AerospikeConfig: ceConfig()}
+		test.mutate(spec)
+		errs := validateCommunityEdition(spec, field.NewPath("spec"))
+		if got := errorFields(errs); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got error fields %v, want %v", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package admission
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
+	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/utils"
+	log "github.com/inconshreveable/log15"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// validateScheduling checks that the kubernetes nodes can host the cluster pods. Without enough matching nodes the pods
+// stay pending and the cluster never becomes ready.
+func (s *ClusterValidatingAdmissionWebhook) validateScheduling(fldPath *field.Path) field.ErrorList {
+	if s.client == nil || s.obj.Spec.ValidationPolicy != nil && s.obj.Spec.ValidationPolicy.SkipSchedulingValidate {
+		return nil
+	}
+
+	nodeList := &corev1.NodeList{}
+	if err := s.client.List(context.TODO(), nodeList); err != nil {
+		// Do not block the request if nodes cannot be read, the controller reports unschedulable pods.
+		s.logger.Warn("Failed to list kubernetes nodes. Skipping scheduling validation", log.Ctx{"err": err})
+		return nil
+	}
+
+	return validateNodeScheduling(nodeList.Items, int(s.obj.Spec.Size), s.obj.Spec.RackConfig.Racks, s.obj.Spec.MultiPodPerHost, fldPath)
+}
+
+// validateNodeScheduling checks that every rack has schedulable nodes matching its affinity. If multiPodPerHost is false
+// each pod needs its own node, so the racks and the cluster need at least as many matching nodes as pods.
+func validateNodeScheduling(nodes []corev1.Node, size int, racks []aerospikev1alpha1.Rack, multiPodPerHost bool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if size <= 0 || len(racks) == 0 {
+		return allErrs
+	}
+
+	schedulable := []corev1.Node{}
+	for _, node := range nodes {
+		if isNodeSchedulable(node) {
+			schedulable = append(schedulable, node)
+		}
+	}
+
+	racksPath := fldPath.Child("rackConfig", "racks")
+	topology := utils.SplitRacks(size, len(racks))
+	clusterNodes := map[string]bool{}
+
+	for idx, rack := range racks {
+		rackNodes := 0
+		for _, node := range schedulable {
+			if nodeMatchesRack(node, rack) {
+				rackNodes++
+				clusterNodes[node.Name] = true
+			}
+		}
+
+		rackPath := racksPath.Index(idx)
+		if rackNodes == 0 && topology[idx] > 0 {
+			allErrs = append(allErrs, field.Invalid(rackPath, rackNodeSelector(rack), "No schedulable kubernetes node matches the rack"))
+			continue
+		}
+		if !multiPodPerHost && rackNodes < topology[idx] {
+			allErrs = append(allErrs, field.Invalid(rackPath, rackNodeSelector(rack), fmt.Sprintf("Rack has %d pods but %d schedulable kubernetes nodes match. Each pod needs its own node with multiPodPerHost false", topology[idx], rackNodes)))
+		}
+	}
+
+	// Racks may share nodes, so check the cluster as a whole as well.
+	if !multiPodPerHost && len(allErrs) == 0 && len(clusterNodes) < size {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("size"), size, fmt.Sprintf("Cluster needs %d nodes with multiPodPerHost false, %d schedulable kubernetes nodes match the racks", size, len(clusterNodes))))
+	}
+
+	return allErrs
+}
+
+// isNodeSchedulable returns true if new aerospike pods can be placed on the node. Aerospike pods do not have tolerations,
+// hence nodes with NoSchedule or NoExecute taints are skipped.
+func isNodeSchedulable(node corev1.Node) bool {
+	if node.Spec.Unschedulable {
+		return false
+	}
+	for _, taint := range node.Spec.Taints {
+		if taint.Effect == corev1.TaintEffectNoSchedule || taint.Effect == corev1.TaintEffectNoExecute {
+			return false
+		}
+	}
+	return true
+}
+
+// nodeMatchesRack returns true if the node matches the rack node affinity set by the controller.
+func nodeMatchesRack(node corev1.Node, rack aerospikev1alpha1.Rack) bool {
+	for label, value := range rackNodeLabels(rack) {
+		if node.Labels[label] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// rackNodeLabels returns the node labels required by the rack.
+func rackNodeLabels(rack aerospikev1alpha1.Rack) map[string]string {
+	nodeLabels := map[string]string{}
+	if rack.Zone != "" {
+		nodeLabels[utils.ZoneLabel] = rack.Zone
+	}
+	if rack.Region != "" {
+		nodeLabels[utils.RegionLabel] = rack.Region
+	}
+	if rack.RackLabel != "" {
+		nodeLabels[utils.RackLabel] = rack.RackLabel
+	}
+	if rack.NodeName != "" {
+		nodeLabels[utils.HostnameLabel] = rack.NodeName
+	}
+	return nodeLabels
+}
+
+// rackNodeSelector returns the rack node labels as a selector string for error messages.
+func rackNodeSelector(rack aerospikev1alpha1.Rack) string {
+	return labels.Set(rackNodeLabels(rack)).String()
+}
+
+// isRackPlacementChanged returns true if the cluster size or the rack node affinity has changed.
+func isRackPlacementChanged(newSpec, oldSpec aerospikev1alpha1.AerospikeClusterSpec) bool {
+	if newSpec.Size != oldSpec.Size || len(newSpec.RackConfig.Racks) != len(oldSpec.RackConfig.Racks) {
+		return true
+	}
+	for idx := range newSpec.RackConfig.Racks {
+		if !reflect.DeepEqual(rackNodeLabels(newSpec.RackConfig.Racks[idx]), rackNodeLabels(oldSpec.RackConfig.Racks[idx])) {
+			return true
+		}
+	}
+	return false
+}
@@ -105,6 +105,34 @@ func (s *ValidatingAdmissionWebhook) ensureWebhookConfig(caBundle []byte) error
 				},
 				FailurePolicy: &failurePolicy,
 			},
+			{
+				Name: aerospikeConfigTemplateCRDName,
+				Rules: []admissionregistrationv1beta1.RuleWithOperations{
+					{
+						Operations: []admissionregistrationv1beta1.OperationType{
+							admissionregistrationv1beta1.Update,
+						},
+						Rule: admissionregistrationv1beta1.Rule{
+							APIGroups: []string{
+								aerospikev1alpha1.SchemeGroupVersion.Group,
+							},
+							APIVersions: []string{
+								aerospikev1alpha1.SchemeGroupVersion.Version,
+							},
+							Resources: []string{aerospikeConfigTemplate},
+						},
+					},
+				},
+				ClientConfig: admissionregistrationv1beta1.WebhookClientConfig{
+					Service: &admissionregistrationv1beta1.ServiceReference{
+						Name:      serviceName,
+						Namespace: s.namespace,
+						Path:      &AerospikeConfigTemplateValidationWebhookPath,
+					},
+					CABundle: caBundle,
+				},
+				FailurePolicy: &failurePolicy,
+			},
 		},
 	}
 
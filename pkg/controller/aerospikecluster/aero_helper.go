@@ -44,7 +44,7 @@ func (r *ReconcileAerospikeCluster) getAerospikeServerVersionFromPod(aeroCluster
 		return "", err
 	}
 
-	res, err := deployment.RunInfo(r.getClientPolicy(aeroCluster), asConn, "build")
+	res, err := deployment.RunInfo(r.getClientPolicyForPod(aeroCluster, pod), asConn, "build")
 	if err != nil {
 		return "", err
 	}
@@ -71,7 +71,7 @@ func (r *ReconcileAerospikeCluster) waitForClusterStaefulSetsToBeReady(aeroClust
 			// Skip if a sts not found. It may have be deleted and status may not have been updated yet
 			continue
 		}
-		if err := r.waitForStatefulSetToBeReady(st); err != nil {
+		if err := r.waitForStatefulSetToBeReady(aeroCluster, st); err != nil {
 			return err
 		}
 	}
@@ -121,6 +121,10 @@ func (r *ReconcileAerospikeCluster) waitForNodeSafeStopReady(aeroCluster *aerosp
 		return reconcileRequeueAfter(60)
 	}
 
+	if !supportsQuiesce(aeroCluster) {
+		return reconcileSuccess()
+	}
+
 	// Quiesce node
 	selectedHostConn, err := r.newHostConn(aeroCluster, pod)
 	if err != nil {
@@ -132,12 +136,17 @@ func (r *ReconcileAerospikeCluster) waitForNodeSafeStopReady(aeroCluster *aerosp
 	return reconcileSuccess()
 }
 
+// supportsQuiesce indicates if the cluster nodes can be quiesced. Quiesce is an Enterprise Edition feature.
+func supportsQuiesce(aeroCluster *aerospikev1alpha1.AerospikeCluster) bool {
+	return utils.IsEnterprise(aeroCluster.Spec.Image)
+}
+
 func (r *ReconcileAerospikeCluster) tipClearHostname(aeroCluster *aerospikev1alpha1.AerospikeCluster, pod *v1.Pod, clearPodName string) error {
 	asConn, err := r.newAsConn(aeroCluster, pod)
 	if err != nil {
 		return err
 	}
-	return deployment.TipClearHostname(r.getClientPolicy(aeroCluster), asConn, getFQDNForPod(aeroCluster, clearPodName), utils.HeartbeatPort)
+	return deployment.TipClearHostname(r.getClientPolicyForPod(aeroCluster, pod), asConn, getFQDNForPod(aeroCluster, clearPodName), utils.HeartbeatPort)
 }
 
 func (r *ReconcileAerospikeCluster) tipHostname(aeroCluster *aerospikev1alpha1.AerospikeCluster, pod *v1.Pod, clearPod *v1.Pod) error {
@@ -145,7 +154,7 @@ func (r *ReconcileAerospikeCluster) tipHostname(aeroCluster *aerospikev1alpha1.A
 	if err != nil {
 		return err
 	}
-	return deployment.TipHostname(r.getClientPolicy(aeroCluster), asConn, getFQDNForPod(aeroCluster, clearPod.Name), utils.HeartbeatPort)
+	return deployment.TipHostname(r.getClientPolicyForPod(aeroCluster, pod), asConn, getFQDNForPod(aeroCluster, clearPod.Name), utils.HeartbeatPort)
 }
 
 func (r *ReconcileAerospikeCluster) alumniReset(aeroCluster *aerospikev1alpha1.AerospikeCluster, pod *v1.Pod) error {
@@ -153,7 +162,7 @@ func (r *ReconcileAerospikeCluster) alumniReset(aeroCluster *aerospikev1alpha1.A
 	if err != nil {
 		return err
 	}
-	return deployment.AlumniReset(r.getClientPolicy(aeroCluster), asConn)
+	return deployment.AlumniReset(r.getClientPolicyForPod(aeroCluster, pod), asConn)
 }
 
 // getIPs returns the pod IP, host internal IP and the host external IP unless there is an error.
@@ -281,7 +290,7 @@ func (r *ReconcileAerospikeCluster) newAsConn(aeroCluster *aerospikev1alpha1.Aer
 	// Use pod IP and direct service port from within the operator for info calls.
 	var port int32
 
-	tlsName := getServiceTLSName(aeroCluster)
+	tlsName := getClientTLSName(aeroCluster)
 	if tlsName == "" {
 		port = utils.ServicePort
 	} else {
@@ -299,13 +308,7 @@ func (r *ReconcileAerospikeCluster) newAsConn(aeroCluster *aerospikev1alpha1.Aer
 }
 
 func getServiceTLSName(aeroCluster *aerospikev1alpha1.AerospikeCluster) string {
-	if networkConfTmp, ok := aeroCluster.Spec.AerospikeConfig["network"]; ok {
-		networkConf := networkConfTmp.(map[string]interface{})
-		if tlsName, ok := networkConf["service"].(map[string]interface{})["tls-name"]; ok {
-			return tlsName.(string)
-		}
-	}
-	return ""
+	return getServiceTLSNameFromConfig(aeroCluster.Spec.AerospikeConfig)
 }
 
 func getFQDNForPod(aeroCluster *aerospikev1alpha1.AerospikeCluster, host string) string {
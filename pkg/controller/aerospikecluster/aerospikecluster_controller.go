@@ -26,7 +26,9 @@ import (
 	k8sRuntime "k8s.io/apimachinery/pkg/runtime"
 
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
@@ -61,7 +63,12 @@ func Add(mgr manager.Manager) error {
 
 // newReconciler returns a new reconcile.Reconciler
 func newReconciler(mgr manager.Manager) reconcile.Reconciler {
-	return &ReconcileAerospikeCluster{client: mgr.GetClient(), scheme: mgr.GetScheme()}
+	return &ReconcileAerospikeCluster{
+		client:    mgr.GetClient(),
+		apiReader: mgr.GetAPIReader(),
+		scheme:    mgr.GetScheme(),
+		recorder:  mgr.GetEventRecorderFor("aerospikecluster-controller"),
+	}
 }
 
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
@@ -82,6 +89,34 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
+	// Watch for changes to config templates and requeue the clusters referencing them
+	err = c.Watch(
+		&source.Kind{Type: &aerospikev1alpha1.AerospikeConfigTemplate{}},
+		&handler.EnqueueRequestsFromMapFunc{ToRequests: &configTemplateMapper{client: mgr.GetClient()}},
+		predicate.GenerationChangedPredicate{})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ConfigMaps and requeue the clusters reading their aerospike.conf file from them
+	err = c.Watch(
+		&source.Kind{Type: &corev1.ConfigMap{}},
+		&handler.EnqueueRequestsFromMapFunc{ToRequests: &configFileMapper{client: mgr.GetClient()}},
+		predicate.ResourceVersionChangedPredicate{})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to Secrets and requeue the clusters using them as their AerospikeConfigSecret, cert-manager
+	// certificate secret or user password secret
+	err = c.Watch(
+		&source.Kind{Type: &corev1.Secret{}},
+		&handler.EnqueueRequestsFromMapFunc{ToRequests: &secretMapper{client: mgr.GetClient()}},
+		predicate.ResourceVersionChangedPredicate{})
+	if err != nil {
+		return err
+	}
+
 	// TODO: Do we need to monitor this? Statefulset is updated many times in reconcile and this add new entry in
 	// update queue. If user will change only cr then we may not need to monitor statefulset.
 	// Think all possible situation
@@ -117,7 +152,10 @@ type ReconcileAerospikeCluster struct {
 	// This client, initialized using mgr.Client() above, is a split client
 	// that reads objects from the cache and writes to the apiserver
 	client client.Client
-	scheme *k8sRuntime.Scheme
+	// apiReader reads objects from the apiserver, for password secrets in namespaces that may not be cached.
+	apiReader client.Reader
+	scheme    *k8sRuntime.Scheme
+	recorder  record.EventRecorder
 }
 
 // RackState contains the rack configuration and rack size.
@@ -169,24 +207,81 @@ func (r *ReconcileAerospikeCluster) Reconcile(request reconcile.Request) (reconc
 		return reconcile.Result{}, err
 	}
 
+	// Parse the changed aerospike.conf file into the cluster spec
+	r.reconcileConfigFile(aeroCluster)
+
+	// Merge the changed config templates into the cluster spec
+	r.reconcileConfigTemplates(aeroCluster)
+
+	// Validate the feature key before changing any pod
+	if err := r.reconcileFeatureKey(aeroCluster); err != nil {
+		logger.Error("Failed to reconcile feature key", log.Ctx{"err": err})
+		return reconcile.Result{}, err
+	}
+
+	// Issue the cert-manager certificates before the pods mount them
+	if err := r.reconcileCertManager(aeroCluster); err != nil {
+		logger.Error("Failed to reconcile cert-manager certificates", log.Ctx{"err": err})
+		return reconcile.Result{}, err
+	}
+
+	// Report the TLS certificates expiry before rotating them
+	if err := r.reconcileTLSCertificates(aeroCluster); err != nil {
+		logger.Error("Failed to reconcile TLS certificates", log.Ctx{"err": err})
+		return reconcile.Result{}, err
+	}
+
+	// Create the admin bootstrap password before the first secured pod starts
+	if err := r.reconcileAdminSecret(aeroCluster); err != nil {
+		logger.Error("Failed to reconcile admin secret", log.Ctx{"err": err})
+		return reconcile.Result{}, err
+	}
+
+	// Create the NetworkPolicies of the racks before their pods
+	if err := r.reconcileNetworkPolicies(aeroCluster); err != nil {
+		logger.Error("Failed to reconcile NetworkPolicies", log.Ctx{"err": err})
+		return reconcile.Result{}, err
+	}
+
 	// Reconcile all racks
 	if res := r.reconcileRacks(aeroCluster); !res.isSuccess {
 		return res.result, res.err
 	}
 
-	// Check if there is any node with quiesce status. We need to undo that
-	// It may have been left from previous steps
-	allHostConns, err := r.newAllHostConn(aeroCluster)
-	if err != nil {
-		e := fmt.Errorf("Failed to get hostConn for aerospike cluster nodes: %v", err)
-		logger.Error("Failed to get hostConn for aerospike cluster nodes", log.Ctx{"err": err})
-		return reconcile.Result{}, e
+	// All the pods are scheduled and ready
+	if err := r.patchClusterCondition(aeroCluster, aerospikev1alpha1.AerospikeClusterPodsSchedulable, corev1.ConditionTrue, "PodsReady", "All the pods are scheduled and ready"); err != nil {
+		logger.Error("Failed to set PodsSchedulable condition", log.Ctx{"err": err})
+		return reconcile.Result{}, err
 	}
-	if err := deployment.InfoQuiesceUndo(r.getClientPolicy(aeroCluster), allHostConns); err != nil {
-		logger.Error("Failed to check for Quiesced nodes", log.Ctx{"err": err})
+
+	// Delete the NetworkPolicies of the removed racks after their pods
+	if err := r.deleteStaleNetworkPolicies(aeroCluster); err != nil {
+		logger.Error("Failed to delete stale NetworkPolicies", log.Ctx{"err": err})
 		return reconcile.Result{}, err
 	}
 
+	// Remove retained PVCs with expired retention duration
+	nextPVCExpiry, err := r.cleanupRetainedPVCs(aeroCluster)
+	if err != nil {
+		logger.Error("Failed to cleanup retained PVCs", log.Ctx{"err": err})
+		return reconcile.Result{}, err
+	}
+
+	// Check if there is any node with quiesce status. We need to undo that
+	// It may have been left from previous steps
+	if supportsQuiesce(aeroCluster) {
+		allHostConns, err := r.newAllHostConn(aeroCluster)
+		if err != nil {
+			e := fmt.Errorf("Failed to get hostConn for aerospike cluster nodes: %v", err)
+			logger.Error("Failed to get hostConn for aerospike cluster nodes", log.Ctx{"err": err})
+			return reconcile.Result{}, e
+		}
+		if err := deployment.InfoQuiesceUndo(r.getClientPolicy(aeroCluster), allHostConns); err != nil {
+			logger.Error("Failed to check for Quiesced nodes", log.Ctx{"err": err})
+			return reconcile.Result{}, err
+		}
+	}
+
 	// Setup access control.
 	if err := r.reconcileAccessControl(aeroCluster); err != nil {
 		logger.Error("Failed to reconcile access control", log.Ctx{"err": err})
@@ -199,6 +294,10 @@ func (r *ReconcileAerospikeCluster) Reconcile(request reconcile.Request) (reconc
 		return reconcile.Result{}, err
 	}
 
+	if nextPVCExpiry > 0 {
+		// Requeue to cleanup the retained PVCs when they expire.
+		return reconcile.Result{RequeueAfter: nextPVCExpiry}, nil
+	}
 	return reconcile.Result{}, nil
 }
 
@@ -228,6 +327,13 @@ func (r *ReconcileAerospikeCluster) handlePreviouslyFailedCluster(aeroCluster *a
 		if err := r.createStatus(aeroCluster); err != nil {
 			return err
 		}
+
+		// Create the PVCs from the snapshot before the statefulsets create empty ones.
+		if aeroCluster.Spec.RestoreFromSnapshot != "" {
+			if err := r.restorePVCsFromSnapshot(aeroCluster); err != nil {
+				return fmt.Errorf("Error restoring cluster from snapshot: %v", err)
+			}
+		}
 	} else {
 		logger.Debug("It's not a new cluster, check if it is failed and needs recovery")
 		hasFailed, err := r.hasClusterFailed(aeroCluster)
@@ -279,6 +385,11 @@ func (r *ReconcileAerospikeCluster) reconcileRacks(aeroCluster *aerospikev1alpha
 
 	logger.Info("Rack changes", log.Ctx{"racksToDelete": rackIDsToDelete, "ignorablePods": ignorablePodNames})
 
+	// Setup access control on the secured nodes while security is being enabled with a rolling restart
+	if err := r.bootstrapSecurity(aeroCluster); err != nil {
+		return reconcileError(err)
+	}
+
 	for _, state := range rackStateList {
 		found := &appsv1.StatefulSet{}
 		stsName := getNamespacedNameForStatefulSet(aeroCluster, state.Rack.ID)
@@ -534,7 +645,7 @@ func (r *ReconcileAerospikeCluster) cleanupDanglingPodsRack(aeroCluster *aerospi
 		}
 	}
 
-	err := r.cleanupPods(aeroCluster, danglingPods, rackState)
+	err := r.cleanupPods(aeroCluster, danglingPods, rackState, nil)
 	if err != nil {
 		return fmt.Errorf("Failed dangling pod cleanup: %v", err)
 	}
@@ -626,6 +737,11 @@ func (r *ReconcileAerospikeCluster) scaleUpRack(aeroCluster *aerospikev1alpha1.A
 		return found, reconcileError(fmt.Errorf("Failed scale up pre-check: %v", err))
 	}
 
+	// Reattach PVCs retained when these pods were removed earlier.
+	if err := r.reattachRetainedPVCs(aeroCluster, newPodNames, rackState.Rack.ID); err != nil {
+		return found, reconcileError(err)
+	}
+
 	if aeroCluster.Spec.MultiPodPerHost {
 		// Create services for each pod
 		for _, podName := range newPodNames {
@@ -640,10 +756,15 @@ func (r *ReconcileAerospikeCluster) scaleUpRack(aeroCluster *aerospikev1alpha1.A
 		return found, reconcileError(fmt.Errorf("Failed to update StatefulSet pods: %v", err))
 	}
 
-	if err := r.waitForStatefulSetToBeReady(found); err != nil {
+	if err := r.waitForStatefulSetToBeReady(aeroCluster, found); err != nil {
 		return found, reconcileError(fmt.Errorf("Failed to wait for statefulset to be ready: %v", err))
 	}
 
+	// New pods are up and have skipped initializing the reattached volumes.
+	if err := r.removeRetainedPVCStatusForPods(aeroCluster, newPodNames); err != nil {
+		return found, reconcileError(err)
+	}
+
 	// return a fresh copy
 	found, err = r.getStatefulSet(aeroCluster, rackState)
 	if err != nil {
@@ -840,6 +961,16 @@ func (r *ReconcileAerospikeCluster) rollingRestartRack(aeroCluster *aerospikev1a
 
 	updateStatefulSetConfigMapVolumes(aeroCluster, found, rackState)
 
+	updateStatefulSetCertManagerVolumes(aeroCluster, found)
+
+	updateStatefulSetLDAPVolumes(aeroCluster, found)
+
+	updateStatefulSetAdminSecurityMetadataVolumes(aeroCluster, found, rackState)
+
+	if err := r.updateStatefulSetTLSInfo(aeroCluster, found); err != nil {
+		return found, reconcileError(err)
+	}
+
 	logger.Info("Updating statefulset spec")
 
 	if err := r.client.Update(context.TODO(), found, updateOption); err != nil {
@@ -858,6 +989,13 @@ func (r *ReconcileAerospikeCluster) rollingRestartRack(aeroCluster *aerospikev1a
 			continue
 		}
 
+		// The pod service needs the TLS port once the pod is restarted with the TLS config
+		if aeroCluster.Spec.MultiPodPerHost {
+			if err := r.updateServiceForPodTLS(aeroCluster, &pod); err != nil {
+				return found, reconcileError(err)
+			}
+		}
+
 		res := r.rollingRestartPod(aeroCluster, rackState, pod, ignorablePods)
 		if !res.isSuccess {
 			return found, res
@@ -927,41 +1065,55 @@ func (r *ReconcileAerospikeCluster) needRollingRestartPod(aeroCluster *aerospike
 		logger.Info("AerospikeConfigSecret changed. Need rolling restart")
 	}
 
+	// Check if TLS certificates are updated
+	tlsUpdated, err := r.isTLSCertificatesUpdated(aeroCluster, pod)
+	if err != nil {
+		return false, err
+	}
+	if tlsUpdated {
+		needRollingRestartPod = true
+		logger.Info("TLS certificates changed. Need rolling restart")
+	}
+
 	// Check if resources are updated
 	if isResourceUpdatedInAeroCluster(aeroCluster, pod) {
 		needRollingRestartPod = true
 		logger.Info("Aerospike resources changed. Need rolling restart")
 	}
 
-	// Check if RACKSTORAGE/CONFIGMAP is updated
-	if isRackConfigMapsUpdatedInAeroCluster(aeroCluster, rackState, pod) {
+	// Check if RACKSTORAGE/POD VOLUMES are updated
+	if isRackPodVolumesUpdatedInAeroCluster(aeroCluster, rackState, pod) {
 		needRollingRestartPod = true
-		logger.Info("Aerospike rack storage configMaps changed. Need rolling restart")
+		logger.Info("Aerospike rack storage pod volumes changed. Need rolling restart")
+	}
+
+	// Check if RACKSTORAGE/SIDECAR mounts are updated
+	if isRackVolumeAttachmentsUpdatedInAeroCluster(aeroCluster, rackState, pod) {
+		needRollingRestartPod = true
+		logger.Info("Aerospike rack storage sidecar mounts changed. Need rolling restart")
 	}
 
 	return needRollingRestartPod, nil
 }
 
-func isRackConfigMapsUpdatedInAeroCluster(aeroCluster *aerospikev1alpha1.AerospikeCluster, rackState RackState, pod corev1.Pod) bool {
-	configMaps, _ := rackState.Rack.Storage.GetConfigMaps()
+func isRackPodVolumesUpdatedInAeroCluster(aeroCluster *aerospikev1alpha1.AerospikeCluster, rackState RackState, pod corev1.Pod) bool {
+	podVolumes := rackState.Rack.Storage.GetPodVolumes()
 
 	var volumesToBeMatched []corev1.Volume
 	for _, volume := range pod.Spec.Volumes {
-		if volume.ConfigMap == nil ||
-			volume.Name == confDirName ||
-			volume.Name == initConfDirName {
+		if !isPodStorageVolume(volume) {
 			continue
 		}
 		volumesToBeMatched = append(volumesToBeMatched, volume)
 	}
 
-	if len(configMaps) != len(volumesToBeMatched) {
+	if len(podVolumes) != len(volumesToBeMatched) {
 		return true
 	}
 
-	for _, configMapVolume := range configMaps {
+	for _, podVolume := range podVolumes {
 		// Ignore error since its not possible.
-		pvcName, _ := getPVCName(configMapVolume.Path)
+		pvcName, _ := getPVCName(podVolume.Path)
 		found := false
 		for _, volume := range volumesToBeMatched {
 			if volume.Name == pvcName {
@@ -978,6 +1130,48 @@ func isRackConfigMapsUpdatedInAeroCluster(aeroCluster *aerospikev1alpha1.Aerospi
 	return false
 }
 
+func isRackVolumeAttachmentsUpdatedInAeroCluster(aeroCluster *aerospikev1alpha1.AerospikeCluster, rackState RackState, pod corev1.Pod) bool {
+	// Storage volume mounts expected in the sidecars.
+	expectedMounts := map[string]bool{}
+	for _, volume := range rackState.Rack.Storage.Volumes {
+		// Ignore error since its not possible.
+		pvcName, _ := getPVCName(volume.Path)
+
+		if volume.VolumeMode == aerospikev1alpha1.AerospikeVolumeModeConfigMap {
+			// Config maps are mounted in all the containers.
+			for _, sidecar := range aeroCluster.Spec.PodSpec.Sidecars {
+				expectedMounts[getVolumeMountKey(sidecar.Name, pvcName, volume.Path, "", false)] = true
+			}
+		}
+
+		for _, attachment := range volume.Sidecars {
+			expectedMounts[getVolumeMountKey(attachment.ContainerName, pvcName, attachment.GetPath(volume), attachment.SubPath, attachment.ReadOnly)] = true
+		}
+	}
+
+	// Storage volume mounts in the pod sidecars.
+	podMounts := map[string]bool{}
+	for _, container := range pod.Spec.Containers[1:] {
+		for _, mount := range container.VolumeMounts {
+			if storageVolumeNameRegex.MatchString(mount.Name) {
+				podMounts[getVolumeMountKey(container.Name, mount.Name, mount.MountPath, mount.SubPath, mount.ReadOnly)] = true
+			}
+		}
+
+		for _, device := range container.VolumeDevices {
+			if storageVolumeNameRegex.MatchString(device.Name) {
+				podMounts[getVolumeMountKey(container.Name, device.Name, device.DevicePath, "", false)] = true
+			}
+		}
+	}
+
+	return !reflect.DeepEqual(expectedMounts, podMounts)
+}
+
+func getVolumeMountKey(containerName, volumeName, path, subPath string, readOnly bool) string {
+	return fmt.Sprintf("%s/%s/%s/%s/%v", containerName, volumeName, path, subPath, readOnly)
+}
+
 func isResourceUpdatedInAeroCluster(aeroCluster *aerospikev1alpha1.AerospikeCluster, pod corev1.Pod) bool {
 	res := aeroCluster.Spec.Resources
 	if res == nil {
@@ -1170,7 +1364,7 @@ func (r *ReconcileAerospikeCluster) scaleDownRack(aeroCluster *aerospikev1alpha1
 		}
 
 		// Wait for pods to get terminated
-		if err := r.waitForStatefulSetToBeReady(found); err != nil {
+		if err := r.waitForStatefulSetToBeReady(aeroCluster, found); err != nil {
 			return found, reconcileError(fmt.Errorf("Failed to wait for statefulset to be ready: %v", err))
 		}
 
@@ -1181,7 +1375,7 @@ func (r *ReconcileAerospikeCluster) scaleDownRack(aeroCluster *aerospikev1alpha1
 		}
 		found = nFound
 
-		err = r.cleanupPods(aeroCluster, []string{podName}, rackState)
+		err = r.cleanupPods(aeroCluster, []string{podName}, rackState, nil)
 		if err != nil {
 			return nFound, reconcileError(fmt.Errorf("Failed to cleanup pod %s: %v", podName, err))
 		}
@@ -1209,17 +1403,16 @@ func (r *ReconcileAerospikeCluster) reconcileAccessControl(aeroCluster *aerospik
 	if err != nil {
 		return fmt.Errorf("Failed to get host info: %v", err)
 	}
-	var hosts []*as.Host
-	for _, conn := range conns {
-		hosts = append(hosts, &as.Host{
-			Name:    conn.ASConn.AerospikeHostName,
-			TLSName: conn.ASConn.AerospikeTLSName,
-			Port:    conn.ASConn.AerospikePort,
-		})
-	}
+	return r.reconcileAccessControlOnHosts(aeroCluster, conns)
+}
+
+// reconcileAccessControlOnHosts reconciles the access control using a client for the hosts.
+func (r *ReconcileAerospikeCluster) reconcileAccessControlOnHosts(aeroCluster *aerospikev1alpha1.AerospikeCluster, conns []*deployment.HostConn) error {
+	logger := pkglog.New(log.Ctx{"AerospikeCluster": utils.ClusterNamespacedName(aeroCluster)})
+
 	// Create policy using status, status has current connection info
 	clientPolicy := r.getClientPolicy(aeroCluster)
-	aeroClient, err := as.NewClientWithPolicyAndHost(clientPolicy, hosts...)
+	aeroClient, err := as.NewClientWithPolicyAndHost(clientPolicy, getHostsForConns(conns)...)
 
 	if err != nil {
 		return fmt.Errorf("Failed to create aerospike cluster client: %v", err)
@@ -1227,9 +1420,29 @@ func (r *ReconcileAerospikeCluster) reconcileAccessControl(aeroCluster *aerospik
 
 	defer aeroClient.Close()
 
+	managed := aeroCluster.Status.ManagedAccessControl.DeepCopy()
+	if managed == nil {
+		managed = accessControl.NewManagedAccessControlStatus(&aeroCluster.Status.AerospikeClusterSpec)
+	}
+
+	// Keep the admin password to set, to access the cluster if the operator stops before the admin secret is updated.
+	if err := r.setPendingAdminPassword(aeroCluster); err != nil {
+		return err
+	}
+
 	pp := r.getPasswordProvider(aeroCluster)
-	err = accessControl.ReconcileAccessControl(&aeroCluster.Spec, &aeroCluster.Status.AerospikeClusterSpec, aeroClient, pp, logger)
-	return err
+	err = accessControl.ReconcileAccessControl(&aeroCluster.Spec, &aeroCluster.Status.AerospikeClusterSpec, managed, aeroClient, pp, logger)
+
+	// Record the users and roles created and dropped, also when the reconcile failed midway.
+	if patchErr := r.patchManagedAccessControlStatus(aeroCluster, managed); patchErr != nil && err == nil {
+		err = patchErr
+	}
+	if err != nil {
+		return err
+	}
+
+	// Keep the admin password set on the cluster, to access the cluster while the admin user secret is rotated.
+	return r.updateAdminSecret(aeroCluster)
 }
 
 func (r *ReconcileAerospikeCluster) updateStatus(aeroCluster *aerospikev1alpha1.AerospikeCluster) error {
@@ -1334,7 +1547,8 @@ func (r *ReconcileAerospikeCluster) patchStatus(oldAeroCluster, newAeroCluster *
 		// pods is updated only from 2 places
 		// 1: While pod init, it will add pod in pods
 		// 2: While pod cleanup, it will remove pod from pods
-		if strings.HasPrefix(operation.Path, "/status") && !strings.HasPrefix(operation.Path, "/status/pods") {
+		// retainedPVCs are similarly updated only while retaining, reattaching and cleaning up PVCs.
+		if strings.HasPrefix(operation.Path, "/status") && !strings.HasPrefix(operation.Path, "/status/pods") && !strings.HasPrefix(operation.Path, "/status/retainedPVCs") {
 			filteredPatch = append(filteredPatch, operation)
 		}
 	}
@@ -1432,7 +1646,9 @@ func (r *ReconcileAerospikeCluster) recoverFailedCreate(aeroCluster *aerospikev1
 			newPodNames = append(newPodNames, pods.Items[i].Name)
 		}
 
-		err = r.cleanupPods(aeroCluster, newPodNames, state)
+		// The PVCs restored from a snapshot or reattached after retention are kept with their retained status, so that
+		// the recreated pods do not initialize them.
+		err = r.cleanupPods(aeroCluster, newPodNames, state, getRetainedPVCNamesForPods(aeroCluster.Status.RetainedPVCs, newPodNames))
 		if err != nil {
 			return fmt.Errorf("Failed recover failed cluster: %v", err)
 		}
@@ -1441,17 +1657,24 @@ func (r *ReconcileAerospikeCluster) recoverFailedCreate(aeroCluster *aerospikev1
 	return fmt.Errorf("Forcing recreate of the cluster as status is nil")
 }
 
-// cleanupPods checks pods and status before scaleup to detect and fix any status anomalies.
-func (r *ReconcileAerospikeCluster) cleanupPods(aeroCluster *aerospikev1alpha1.AerospikeCluster, podNames []string, rackState RackState) error {
+// cleanupPods checks pods and status before scaleup to detect and fix any status anomalies. The PVCs in keepPVCs are
+// neither deleted nor retained.
+func (r *ReconcileAerospikeCluster) cleanupPods(aeroCluster *aerospikev1alpha1.AerospikeCluster, podNames []string, rackState RackState, keepPVCs map[string]bool) error {
 	logger := pkglog.New(log.Ctx{"AerospikeCluster": utils.ClusterNamespacedName(aeroCluster)})
 
 	logger.Info("Removing pvc for removed pods", log.Ctx{"pods": podNames})
 
-	// Delete PVCs if cascadeDelete
-	pvcItems, err := r.getPodsPVCList(aeroCluster, podNames, rackState.Rack.ID)
+	// Delete or retain PVCs as per the retention policy
+	podPVCItems, err := r.getPodsPVCList(aeroCluster, podNames, rackState.Rack.ID)
 	if err != nil {
 		return fmt.Errorf("Could not find pvc for pods %v: %v", podNames, err)
 	}
+	var pvcItems []corev1.PersistentVolumeClaim
+	for _, pvc := range podPVCItems {
+		if !keepPVCs[pvc.Name] {
+			pvcItems = append(pvcItems, pvc)
+		}
+	}
 	storage := rackState.Rack.Storage
 	if err := r.removePVCs(aeroCluster, &storage, pvcItems); err != nil {
 		return fmt.Errorf("Could not cleanup pod PVCs: %v", err)
@@ -1563,13 +1786,13 @@ func (r *ReconcileAerospikeCluster) deleteExternalResources(aeroCluster *aerospi
 		return fmt.Errorf("Could not find pvc for cluster: %v", err)
 	}
 
-	// removePVCs should be passed only filtered pvc otherwise rack pvc may be removed using global storage cascadeDelete
+	// removePVCs should be passed only filtered pvc otherwise rack pvc may be removed using global storage retention policy
 	var fileredPVCItems []corev1.PersistentVolumeClaim
 	for _, pvc := range pvcItems {
 		var found bool
 		for _, rack := range aeroCluster.Spec.RackConfig.Racks {
 			rackLables := utils.LabelsForAerospikeClusterRack(aeroCluster.Name, rack.ID)
-			if reflect.DeepEqual(pvc.Labels, rackLables) {
+			if labels.SelectorFromSet(rackLables).Matches(labels.Set(pvc.Labels)) {
 				found = true
 				break
 			}
@@ -1602,6 +1825,8 @@ func (r *ReconcileAerospikeCluster) removePVCsAsync(aeroCluster *aerospikev1alph
 	logger := pkglog.New(log.Ctx{"AerospikeCluster": aeroClusterNamespacedName})
 
 	deletedPVCs := []corev1.PersistentVolumeClaim{}
+	retainedPVCs := map[string]aerospikev1alpha1.AerospikeRetainedPVCStatus{}
+	clusterDeleted := !aeroCluster.ObjectMeta.DeletionTimestamp.IsZero()
 
 	for _, pvc := range pvcItems {
 		if utils.IsPVCTerminating(&pvc) {
@@ -1617,28 +1842,36 @@ func (r *ReconcileAerospikeCluster) removePVCsAsync(aeroCluster *aerospikev1alph
 			continue
 		}
 
-		var cascadeDelete bool
-		v := getVolumeConfigForPVC(storage, path)
-		if v == nil {
-			if *pvc.Spec.VolumeMode == corev1.PersistentVolumeBlock {
-				cascadeDelete = storage.BlockVolumePolicy.CascadeDelete
-			} else {
-				cascadeDelete = storage.FileSystemVolumePolicy.CascadeDelete
-			}
-			logger.Info("PVC path not found in configured storage volumes. Use storage level cascadeDelete policy", log.Ctx{"PVC": pvc.Name, "path": path, "cascadeDelete": cascadeDelete})
+		if getVolumeConfigForPVC(storage, path) == nil {
+			logger.Info("PVC path not found in configured storage volumes. Use storage level retention policy", log.Ctx{"PVC": pvc.Name, "path": path})
+		}
+		policy := getPVCRetentionPolicy(storage, &pvc, path)
 
-		} else {
-			cascadeDelete = v.CascadeDelete
+		deletePVC := policy.RetentionPolicy == aerospikev1alpha1.AerospikeVolumeRetentionPolicyDelete
+		if clusterDeleted {
+			deletePVC = isPVCDeletedWithCluster(&pvc, policy)
 		}
 
-		if cascadeDelete {
+		if deletePVC {
 			deletedPVCs = append(deletedPVCs, pvc)
 			if err := r.client.Delete(context.TODO(), &pvc); err != nil {
 				return nil, fmt.Errorf("Could not delete pvc %s: %v", pvc.Name, err)
 			}
-			logger.Info("PVC removed", log.Ctx{"PVC": pvc.Name, "PVCCascadeDelete": cascadeDelete})
-		} else {
-			logger.Info("PVC not removed", log.Ctx{"PVC": pvc.Name, "PVCCascadeDelete": cascadeDelete})
+			logger.Info("PVC removed", log.Ctx{"PVC": pvc.Name, "PVCRetentionPolicy": policy.RetentionPolicy})
+		} else if !isPVCRetained(&pvc) {
+			retainedStatus, err := r.retainPVC(aeroCluster, pvc, path, policy)
+			if err != nil {
+				return nil, err
+			}
+			retainedPVCs[pvc.Name] = *retainedStatus
+			logger.Info("PVC retained", log.Ctx{"PVC": pvc.Name, "PVCRetentionPolicy": policy.RetentionPolicy, "expiryTime": retainedStatus.ExpiryTime})
+		}
+	}
+
+	// The status of a deleted cluster is not updated.
+	if !clusterDeleted {
+		if err := r.addRetainedPVCStatus(aeroCluster, retainedPVCs); err != nil {
+			return nil, err
 		}
 	}
 
@@ -0,0 +1,484 @@
+package aerospikecluster
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
+	"github.com/aerospike/aerospike-management-lib/deployment"
+	log "github.com/inconshreveable/log15"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sRuntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	volumeSnapshotAPIGroup   = "snapshot.storage.k8s.io"
+	volumeSnapshotAPIVersion = volumeSnapshotAPIGroup + "/v1beta1"
+	volumeSnapshotKind       = "VolumeSnapshot"
+
+	// This label is added to the volume snapshots of a cluster snapshot.
+	clusterSnapshotLabelKey = "aerospike.com/cluster-snapshot"
+	// This annotation has the pod the snapshotted pvc was attached to.
+	podNameAnnotationKey = "aerospike.com/pod-name"
+
+	// Time to wait for the volume snapshots of a rack to be cut before undoing quiesce.
+	volumeSnapshotCreationTimeout = 2 * time.Minute
+	// Interval at which the volume snapshots of the current rack are checked for creation. Short since the rack may be
+	// quiesced.
+	volumeSnapshotCreationCheckInterval = 2 * time.Second
+	// Interval at which volume snapshots are checked for readiness.
+	volumeSnapshotReadyCheckInterval = 10 * time.Second
+)
+
+// AddSnapshot creates a new AerospikeClusterSnapshot Controller and adds it to the Manager. The Manager will set fields
+// on the Controller and Start it when the Manager is Started.
+func AddSnapshot(mgr manager.Manager) error {
+	r := &ReconcileAerospikeClusterSnapshot{
+		client:    mgr.GetClient(),
+		apiReader: mgr.GetAPIReader(),
+		scheme:    mgr.GetScheme(),
+		recorder:  mgr.GetEventRecorderFor("aerospikeclustersnapshot-controller"),
+	}
+
+	c, err := controller.New("aerospikeclustersnapshot-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to primary resource AerospikeClusterSnapshot
+	return c.Watch(
+		&source.Kind{Type: &aerospikev1alpha1.AerospikeClusterSnapshot{}},
+		&handler.EnqueueRequestForObject{},
+		predicate.GenerationChangedPredicate{})
+}
+
+// blank assignment to verify that ReconcileAerospikeClusterSnapshot implements reconcile.Reconciler
+var _ reconcile.Reconciler = &ReconcileAerospikeClusterSnapshot{}
+
+// ReconcileAerospikeClusterSnapshot reconciles a AerospikeClusterSnapshot object
+type ReconcileAerospikeClusterSnapshot struct {
+	client    client.Client
+	apiReader client.Reader
+	scheme    *k8sRuntime.Scheme
+	recorder  record.EventRecorder
+}
+
+// clusterReconciler returns a cluster reconciler to reuse the cluster pod and pvc helpers.
+func (r *ReconcileAerospikeClusterSnapshot) clusterReconciler() *ReconcileAerospikeCluster {
+	return &ReconcileAerospikeCluster{client: r.client, apiReader: r.apiReader, scheme: r.scheme, recorder: r.recorder}
+}
+
+// Reconcile AerospikeClusterSnapshot object
+func (r *ReconcileAerospikeClusterSnapshot) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	logger := pkglog.New(log.Ctx{"AerospikeClusterSnapshot": request.NamespacedName})
+	logger.Info("Reconciling AerospikeClusterSnapshot")
+
+	snapshot := &aerospikev1alpha1.AerospikeClusterSnapshot{}
+	if err := r.client.Get(context.TODO(), request.NamespacedName, snapshot); err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{Requeue: true}, err
+	}
+
+	switch snapshot.Status.Phase {
+	case aerospikev1alpha1.AerospikeClusterSnapshotCompleted, aerospikev1alpha1.AerospikeClusterSnapshotFailed:
+		// Snapshots are taken only once.
+		return reconcile.Result{}, nil
+	case "":
+		snapshot.Status.Phase = aerospikev1alpha1.AerospikeClusterSnapshotInProgress
+		if err := r.updateStatus(snapshot); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	aeroCluster := &aerospikev1alpha1.AerospikeCluster{}
+	clusterName := types.NamespacedName{Name: snapshot.Spec.ClusterName, Namespace: snapshot.Namespace}
+	if err := r.client.Get(context.TODO(), clusterName, aeroCluster); err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, r.markFailed(snapshot, fmt.Sprintf("AerospikeCluster %s not found", clusterName))
+		}
+		return reconcile.Result{}, err
+	}
+
+	if len(aeroCluster.Status.RackConfig.Racks) == 0 {
+		// Cluster is not deployed yet.
+		logger.Info("AerospikeCluster not deployed yet. Requeue", log.Ctx{"cluster": clusterName})
+		return reconcile.Result{RequeueAfter: volumeSnapshotReadyCheckInterval}, nil
+	}
+
+	quiesce := shouldQuiesceRacks(snapshot, aeroCluster, len(aeroCluster.Status.RackConfig.Racks))
+
+	// Racks are snapshotted one at a time, finish the current rack before starting the next one.
+	if snapshot.Status.CurrentRack != nil {
+		rackID := *snapshot.Status.CurrentRack
+		created, err := r.finishRackSnapshot(snapshot, aeroCluster, quiesce)
+		if err != nil {
+			logger.Error("Failed to snapshot rack", log.Ctx{"rackID": rackID, "err": err})
+			return reconcile.Result{}, err
+		}
+		if !created {
+			logger.Info("Waiting for volume snapshots to be created", log.Ctx{"rackID": rackID})
+			return reconcile.Result{RequeueAfter: volumeSnapshotCreationCheckInterval}, nil
+		}
+	}
+
+	var rackIDs []int
+	for _, rack := range aeroCluster.Status.RackConfig.Racks {
+		rackIDs = append(rackIDs, rack.ID)
+	}
+	sort.Ints(rackIDs)
+
+	for _, rackID := range rackIDs {
+		if isRackSnapshotted(snapshot, rackID) {
+			continue
+		}
+
+		// Pods and PVCs change while the cluster is rolling restarted or scaled.
+		if !isClusterReconciled(aeroCluster) {
+			logger.Info("AerospikeCluster is being updated. Requeue", log.Ctx{"cluster": clusterName})
+			return reconcile.Result{RequeueAfter: volumeSnapshotReadyCheckInterval}, nil
+		}
+
+		if err := r.startRackSnapshot(snapshot, aeroCluster, rackID, quiesce); err != nil {
+			logger.Error("Failed to snapshot rack", log.Ctx{"rackID": rackID, "err": err})
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{RequeueAfter: volumeSnapshotCreationCheckInterval}, nil
+	}
+
+	ready, err := r.updateVolumeSnapshotReadiness(snapshot)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if !ready {
+		logger.Info("Waiting for volume snapshots to be ready to use")
+		return reconcile.Result{RequeueAfter: volumeSnapshotReadyCheckInterval}, nil
+	}
+
+	now := metav1.Now()
+	snapshot.Status.Phase = aerospikev1alpha1.AerospikeClusterSnapshotCompleted
+	snapshot.Status.CompletionTime = &now
+	if err := r.updateStatus(snapshot); err != nil {
+		return reconcile.Result{}, err
+	}
+	logger.Info("AerospikeClusterSnapshot completed")
+	return reconcile.Result{}, nil
+}
+
+// shouldQuiesceRacks indicates if the racks are quiesced while snapshotted. Quiescing all nodes is not possible, hence
+// racks are not quiesced for single rack clusters, nor for Community Edition clusters which do not support quiesce.
+func shouldQuiesceRacks(snapshot *aerospikev1alpha1.AerospikeClusterSnapshot, aeroCluster *aerospikev1alpha1.AerospikeCluster, rackCount int) bool {
+	return rackCount > 1 && !snapshot.Spec.SkipQuiesce && supportsQuiesce(aeroCluster)
+}
+
+// isClusterReconciled returns true if the cluster status matches its spec, i.e. the cluster is not being rolling
+// restarted or scaled.
+func isClusterReconciled(aeroCluster *aerospikev1alpha1.AerospikeCluster) bool {
+	return reflect.DeepEqual(aeroCluster.Spec, aeroCluster.Status.AerospikeClusterSpec)
+}
+
+// startRackSnapshot creates volume snapshots for the PVCs of a rack's pods and makes it the current rack of the
+// snapshot. The volume snapshots are checked for creation by finishRackSnapshot.
+//
+// With quiesce the pods of the rack are quiesced till the volume snapshots are cut, so that clients write to the other
+// racks.
+func (r *ReconcileAerospikeClusterSnapshot) startRackSnapshot(snapshot *aerospikev1alpha1.AerospikeClusterSnapshot, aeroCluster *aerospikev1alpha1.AerospikeCluster, rackID int, quiesce bool) (err error) {
+	logger := pkglog.New(log.Ctx{"AerospikeClusterSnapshot": snapshot.Namespace + "/" + snapshot.Name, "rackID": rackID})
+	clusterReconciler := r.clusterReconciler()
+
+	pvcItems, err := clusterReconciler.getRackPVCList(aeroCluster, rackID)
+	if err != nil {
+		return fmt.Errorf("Could not find pvc for rack %d: %v", rackID, err)
+	}
+
+	if quiesce {
+		// Undo quiesce if the rack snapshot cannot be started so that the rack takes writes again.
+		defer func() {
+			if err != nil {
+				if undoErr := r.undoQuiesce(aeroCluster); undoErr != nil {
+					logger.Error("Failed to undo quiesce", log.Ctx{"err": undoErr})
+				}
+			}
+		}()
+
+		if err := r.quiesceRack(aeroCluster, rackID); err != nil {
+			return err
+		}
+	}
+
+	var volumeSnapshots []aerospikev1alpha1.AerospikeVolumeSnapshotStatus
+	for _, pvc := range pvcItems {
+		// Retained PVCs are not attached to cluster pods.
+		if isPVCRetained(&pvc) {
+			continue
+		}
+
+		volumeSnapshot, err := r.createVolumeSnapshot(snapshot, &pvc, rackID)
+		if err != nil {
+			return err
+		}
+		volumeSnapshots = append(volumeSnapshots, *volumeSnapshot)
+	}
+
+	now := metav1.Now()
+	snapshot.Status.VolumeSnapshots = append(snapshot.Status.VolumeSnapshots, volumeSnapshots...)
+	snapshot.Status.CurrentRack = &rackID
+	snapshot.Status.CurrentRackStartTime = &now
+	if err := r.updateStatus(snapshot); err != nil {
+		return err
+	}
+	logger.Info("Rack volume snapshots requested", log.Ctx{"count": len(volumeSnapshots)})
+	return nil
+}
+
+// finishRackSnapshot checks if the storage system has cut all the volume snapshots of the current rack. Once cut, or on
+// timeout, quiesce is undone. A rack which timed out is removed from the status to be snapshotted again.
+// Returns true if the rack is completed.
+func (r *ReconcileAerospikeClusterSnapshot) finishRackSnapshot(snapshot *aerospikev1alpha1.AerospikeClusterSnapshot, aeroCluster *aerospikev1alpha1.AerospikeCluster, quiesce bool) (bool, error) {
+	rackID := *snapshot.Status.CurrentRack
+	logger := pkglog.New(log.Ctx{"AerospikeClusterSnapshot": snapshot.Namespace + "/" + snapshot.Name, "rackID": rackID})
+
+	created, err := r.areVolumeSnapshotsCreated(snapshot, rackID)
+	if err != nil {
+		return false, err
+	}
+
+	timedOut := !created && snapshot.Status.CurrentRackStartTime != nil &&
+		time.Since(snapshot.Status.CurrentRackStartTime.Time) > volumeSnapshotCreationTimeout
+	if !created && !timedOut {
+		return false, nil
+	}
+
+	if quiesce {
+		if err := r.undoQuiesce(aeroCluster); err != nil {
+			return false, err
+		}
+	}
+
+	snapshot.Status.CurrentRack = nil
+	snapshot.Status.CurrentRackStartTime = nil
+	if timedOut {
+		snapshot.Status.VolumeSnapshots = removeRackVolumeSnapshots(snapshot.Status.VolumeSnapshots, rackID)
+	} else {
+		snapshot.Status.CompletedRacks = append(snapshot.Status.CompletedRacks, rackID)
+	}
+	if err := r.updateStatus(snapshot); err != nil {
+		return false, err
+	}
+
+	if timedOut {
+		return false, fmt.Errorf("Timed out waiting for the volume snapshots of rack %d to be created", rackID)
+	}
+	logger.Info("Rack volume snapshots created")
+	return true, nil
+}
+
+// quiesceRack quiesces the pods of a rack.
+func (r *ReconcileAerospikeClusterSnapshot) quiesceRack(aeroCluster *aerospikev1alpha1.AerospikeCluster, rackID int) error {
+	clusterReconciler := r.clusterReconciler()
+
+	allHostConns, err := clusterReconciler.newAllHostConn(aeroCluster)
+	if err != nil {
+		return fmt.Errorf("Failed to get hostConn for aerospike cluster nodes: %v", err)
+	}
+	policy := clusterReconciler.getClientPolicy(aeroCluster)
+
+	podList, err := clusterReconciler.getRackPodList(aeroCluster, rackID)
+	if err != nil {
+		return fmt.Errorf("Failed to list pods of rack %d: %v", rackID, err)
+	}
+	for _, pod := range podList.Items {
+		selectedHostConn, err := clusterReconciler.newHostConn(aeroCluster, &pod)
+		if err != nil {
+			return fmt.Errorf("Failed to get hostConn for aerospike cluster nodes %v: %v", pod.Name, err)
+		}
+		pkglog.Info("Quiesce pod", log.Ctx{"pod": pod.Name, "rackID": rackID})
+		if err := deployment.InfoQuiesce(policy, allHostConns, selectedHostConn); err != nil {
+			return fmt.Errorf("Failed to quiesce pod %s: %v", pod.Name, err)
+		}
+	}
+	return nil
+}
+
+// undoQuiesce undoes quiesce on all the cluster nodes.
+func (r *ReconcileAerospikeClusterSnapshot) undoQuiesce(aeroCluster *aerospikev1alpha1.AerospikeCluster) error {
+	clusterReconciler := r.clusterReconciler()
+
+	allHostConns, err := clusterReconciler.newAllHostConn(aeroCluster)
+	if err != nil {
+		return fmt.Errorf("Failed to get hostConn for aerospike cluster nodes: %v", err)
+	}
+	if err := deployment.InfoQuiesceUndo(clusterReconciler.getClientPolicy(aeroCluster), allHostConns); err != nil {
+		return fmt.Errorf("Failed to undo quiesce: %v", err)
+	}
+	return nil
+}
+
+// createVolumeSnapshot creates a VolumeSnapshot for the pvc if not already created and returns its status.
+func (r *ReconcileAerospikeClusterSnapshot) createVolumeSnapshot(snapshot *aerospikev1alpha1.AerospikeClusterSnapshot, pvc *corev1.PersistentVolumeClaim, rackID int) (*aerospikev1alpha1.AerospikeVolumeSnapshotStatus, error) {
+	path, ok := pvc.Annotations[storagePathAnnotationKey]
+	if !ok {
+		return nil, fmt.Errorf("PVC %s does not have %s annotation", pvc.Name, storagePathAnnotationKey)
+	}
+	podName, err := getPodNameForPVC(pvc, path)
+	if err != nil {
+		return nil, err
+	}
+
+	volumeSnapshot := newVolumeSnapshot(snapshot.Name+"-"+pvc.Name, snapshot.Namespace)
+	volumeSnapshot.SetLabels(map[string]string{clusterSnapshotLabelKey: snapshot.Name})
+	volumeSnapshot.SetAnnotations(map[string]string{
+		storagePathAnnotationKey: path,
+		podNameAnnotationKey:     podName,
+	})
+	if err := unstructured.SetNestedField(volumeSnapshot.Object, pvc.Name, "spec", "source", "persistentVolumeClaimName"); err != nil {
+		return nil, err
+	}
+	if snapshot.Spec.VolumeSnapshotClassName != "" {
+		if err := unstructured.SetNestedField(volumeSnapshot.Object, snapshot.Spec.VolumeSnapshotClassName, "spec", "volumeSnapshotClassName"); err != nil {
+			return nil, err
+		}
+	}
+
+	// Volume snapshots are deleted with the cluster snapshot.
+	if err := controllerutil.SetControllerReference(snapshot, volumeSnapshot, r.scheme); err != nil {
+		return nil, err
+	}
+
+	if err := r.client.Create(context.TODO(), volumeSnapshot, createOption); err != nil && !errors.IsAlreadyExists(err) {
+		return nil, fmt.Errorf("Failed to create VolumeSnapshot for pvc %s: %v", pvc.Name, err)
+	}
+
+	return &aerospikev1alpha1.AerospikeVolumeSnapshotStatus{
+		Name:    volumeSnapshot.GetName(),
+		PVCName: pvc.Name,
+		PodName: podName,
+		RackID:  rackID,
+		Path:    path,
+	}, nil
+}
+
+// areVolumeSnapshotsCreated returns true if the storage system has cut all the volume snapshots of the rack.
+func (r *ReconcileAerospikeClusterSnapshot) areVolumeSnapshotsCreated(snapshot *aerospikev1alpha1.AerospikeClusterSnapshot, rackID int) (bool, error) {
+	for _, status := range snapshot.Status.VolumeSnapshots {
+		if status.RackID != rackID {
+			continue
+		}
+
+		volumeSnapshot, err := r.getVolumeSnapshot(status.Name, snapshot.Namespace)
+		if err != nil {
+			return false, err
+		}
+
+		if creationTime, _, _ := unstructured.NestedString(volumeSnapshot.Object, "status", "creationTime"); creationTime == "" {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// updateVolumeSnapshotReadiness updates the readiness of the volume snapshots in the snapshot status.
+// Returns true if all volume snapshots are ready to use.
+func (r *ReconcileAerospikeClusterSnapshot) updateVolumeSnapshotReadiness(snapshot *aerospikev1alpha1.AerospikeClusterSnapshot) (bool, error) {
+	allReady := true
+	updated := false
+
+	for i := range snapshot.Status.VolumeSnapshots {
+		status := &snapshot.Status.VolumeSnapshots[i]
+		if status.ReadyToUse {
+			continue
+		}
+
+		volumeSnapshot, err := r.getVolumeSnapshot(status.Name, snapshot.Namespace)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return false, r.markFailed(snapshot, fmt.Sprintf("VolumeSnapshot %s not found", status.Name))
+			}
+			return false, err
+		}
+
+		if ready, _, _ := unstructured.NestedBool(volumeSnapshot.Object, "status", "readyToUse"); ready {
+			status.ReadyToUse = true
+			updated = true
+		} else {
+			allReady = false
+		}
+	}
+
+	if updated {
+		if err := r.updateStatus(snapshot); err != nil {
+			return false, err
+		}
+	}
+	return allReady, nil
+}
+
+func (r *ReconcileAerospikeClusterSnapshot) getVolumeSnapshot(name, namespace string) (*unstructured.Unstructured, error) {
+	volumeSnapshot := newVolumeSnapshot(name, namespace)
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, volumeSnapshot); err != nil {
+		return nil, err
+	}
+	return volumeSnapshot, nil
+}
+
+func (r *ReconcileAerospikeClusterSnapshot) markFailed(snapshot *aerospikev1alpha1.AerospikeClusterSnapshot, message string) error {
+	pkglog.Error("AerospikeClusterSnapshot failed", log.Ctx{"AerospikeClusterSnapshot": snapshot.Namespace + "/" + snapshot.Name, "message": message})
+	snapshot.Status.Phase = aerospikev1alpha1.AerospikeClusterSnapshotFailed
+	snapshot.Status.Message = message
+	return r.updateStatus(snapshot)
+}
+
+func (r *ReconcileAerospikeClusterSnapshot) updateStatus(snapshot *aerospikev1alpha1.AerospikeClusterSnapshot) error {
+	if err := r.client.Status().Update(context.TODO(), snapshot); err != nil {
+		return fmt.Errorf("Error updating AerospikeClusterSnapshot status: %v", err)
+	}
+	return nil
+}
+
+// newVolumeSnapshot returns an empty VolumeSnapshot. The snapshot CRDs are not part of the k8s API, hence unstructured.
+func newVolumeSnapshot(name, namespace string) *unstructured.Unstructured {
+	volumeSnapshot := &unstructured.Unstructured{}
+	volumeSnapshot.SetAPIVersion(volumeSnapshotAPIVersion)
+	volumeSnapshot.SetKind(volumeSnapshotKind)
+	volumeSnapshot.SetName(name)
+	volumeSnapshot.SetNamespace(namespace)
+	return volumeSnapshot
+}
+
+func isRackSnapshotted(snapshot *aerospikev1alpha1.AerospikeClusterSnapshot, rackID int) bool {
+	for _, id := range snapshot.Status.CompletedRacks {
+		if id == rackID {
+			return true
+		}
+	}
+	return false
+}
+
+// removeRackVolumeSnapshots returns the volume snapshots not of the rack.
+func removeRackVolumeSnapshots(volumeSnapshots []aerospikev1alpha1.AerospikeVolumeSnapshotStatus, rackID int) []aerospikev1alpha1.AerospikeVolumeSnapshotStatus {
+	var res []aerospikev1alpha1.AerospikeVolumeSnapshotStatus
+	for _, volumeSnapshot := range volumeSnapshots {
+		if volumeSnapshot.RackID != rackID {
+			res = append(res, volumeSnapshot)
+		}
+	}
+	return res
+}
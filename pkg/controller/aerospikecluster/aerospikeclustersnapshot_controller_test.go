@@ -0,0 +1,95 @@
+package aerospikecluster
+
+import (
+	"reflect"
+	"testing"
+
+	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
+)
+
+func TestShouldQuiesceRacks(t *testing.T) {
+	const (
+		enterpriseImage = "aerospike/aerospike-server-enterprise:5.5.0.3"
+		communityImage  = "aerospike/aerospike-server:5.5.0.3"
+	)
+
+	tests := []struct {
+		name        string
+		image       string
+		rackCount   int
+		skipQuiesce bool
+		want        bool
+	}{
+		{name: "enterprise multi rack", image: enterpriseImage, rackCount: 2, want: true},
+		{name: "enterprise single rack", image: enterpriseImage, rackCount: 1, want: false},
+		{name: "enterprise skip quiesce", image: enterpriseImage, rackCount: 2, skipQuiesce: true, want: false},
+		{name: "community multi rack", image: communityImage, rackCount: 2, want: false},
+		{name: "community single rack", image: communityImage, rackCount: 1, want: false},
+	}
+
+	for _, test := range tests {
+		snapshot := &aerospikev1alpha1.AerospikeClusterSnapshot{
+			Spec: aerospikev1alpha1.AerospikeClusterSnapshotSpec{SkipQuiesce: test.skipQuiesce},
+		}
+		aeroCluster := &aerospikev1alpha1.AerospikeCluster{
+			Spec: aerospikev1alpha1.AerospikeClusterSpec{Image: test.image},
+		}
+		if got := shouldQuiesceRacks(snapshot, aeroCluster, test.rackCount); got != test.want {
+			t.Errorf("%s: got quiesce %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestSupportsQuiesce(t *testing.T) {
+	for image, want := range map[string]bool{
+		"aerospike/aerospike-server-enterprise:5.5.0.3": true,
+		"aerospike/aerospike-server:5.5.0.3":            false,
+	} {
+		aeroCluster := &aerospikev1alpha1.AerospikeCluster{Spec: aerospikev1alpha1.AerospikeClusterSpec{Image: image}}
+		if got := supportsQuiesce(aeroCluster); got != want {
+			t.Errorf("image %s: got supportsQuiesce %v, want %v", image, got, want)
+		}
+	}
+}
+
+func TestIsClusterReconciled(t *testing.T) {
+	spec := aerospikev1alpha1.AerospikeClusterSpec{Size: 2, Image: "aerospike/aerospike-server-enterprise:5.5.0.3"}
+
+	tests := []struct {
+		name   string
+		status aerospikev1alpha1.AerospikeClusterSpec
+		want   bool
+	}{
+		{name: "reconciled", status: spec, want: true},
+		{name: "scaling", status: aerospikev1alpha1.AerospikeClusterSpec{Size: 3, Image: spec.Image}, want: false},
+		{name: "upgrading", status: aerospikev1alpha1.AerospikeClusterSpec{Size: 2, Image: "aerospike/aerospike-server-enterprise:5.4.0.5"}, want: false},
+		{name: "not deployed", want: false},
+	}
+
+	for _, test := range tests {
+		aeroCluster := &aerospikev1alpha1.AerospikeCluster{
+			Spec:   spec,
+			Status: aerospikev1alpha1.AerospikeClusterStatus{AerospikeClusterSpec: test.status},
+		}
+		if got := isClusterReconciled(aeroCluster); got != test.want {
+			t.Errorf("%s: got reconciled %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestRemoveRackVolumeSnapshots(t *testing.T) {
+	volumeSnapshots := []aerospikev1alpha1.AerospikeVolumeSnapshotStatus{
+		{Name: "snap-1-0", RackID: 1},
+		{Name: "snap-2-0", RackID: 2},
+		{Name: "snap-1-1", RackID: 1},
+	}
+
+	got := removeRackVolumeSnapshots(volumeSnapshots, 1)
+	want := []aerospikev1alpha1.AerospikeVolumeSnapshotStatus{{Name: "snap-2-0", RackID: 2}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got volume snapshots %v, want %v", got, want)
+	}
+	if got := removeRackVolumeSnapshots(volumeSnapshots, 3); !reflect.DeepEqual(got, volumeSnapshots) {
+		t.Errorf("got volume snapshots %v, want %v", got, volumeSnapshots)
+	}
+}
@@ -0,0 +1,285 @@
+package aerospikecluster
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
+	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/utils"
+	log "github.com/inconshreveable/log15"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// With certManager the operator creates a cert-manager Certificate for each network.tls name, with the TLS name and the
+// pod DNS names, and a client certificate for itself. The certificate secrets are mounted in the pods in a directory per
+// TLS name, and the pods are rolled when cert-manager renews the certificates. Certificates are managed as unstructured
+// objects so that cert-manager is only needed by the clusters using it.
+
+const (
+	// certManagerAPIVersion is the cert-manager API version of the Certificates.
+	certManagerAPIVersion = utils.CertManagerGroup + "/v1"
+
+	// certManagerVolumePrefix is the prefix of the pod volumes of the cert-manager certificate secrets.
+	certManagerVolumePrefix = "aerospike-certs-"
+
+	// operatorClientCommonName is the common name of the operator client certificate.
+	operatorClientCommonName = "aerospike-kubernetes-operator"
+)
+
+// certificateSpecKeys are the Certificate spec keys set by the operator.
+var certificateSpecKeys = []string{"secretName", "commonName", "dnsNames", "usages", "issuerRef", "duration", "renewBefore"}
+
+// reconcileCertManager creates and updates the cert-manager Certificates of the cluster and deletes the Certificates no
+// longer needed. It fails till the certificate secrets are issued, so that the pods are not created without them.
+func (r *ReconcileAerospikeCluster) reconcileCertManager(aeroCluster *aerospikev1alpha1.AerospikeCluster) error {
+	logger := pkglog.New(log.Ctx{"AerospikeCluster": utils.ClusterNamespacedName(aeroCluster)})
+
+	if aeroCluster.Spec.CertManager == nil && aeroCluster.Status.CertManager == nil {
+		return nil
+	}
+
+	desired := getDesiredCertificates(aeroCluster)
+	for _, cert := range desired {
+		if err := r.createOrUpdateCertificate(aeroCluster, cert); err != nil {
+			return err
+		}
+	}
+
+	if err := r.deleteStaleCertificates(aeroCluster, desired); err != nil {
+		return err
+	}
+
+	for _, cert := range desired {
+		secretName, _, _ := unstructured.NestedString(cert.Object, "spec", "secretName")
+		secret := &corev1.Secret{}
+		if err := r.client.Get(context.TODO(), types.NamespacedName{Name: secretName, Namespace: aeroCluster.Namespace}, secret); err != nil {
+			if errors.IsNotFound(err) {
+				logger.Info("Waiting for cert-manager to issue the certificate", log.Ctx{"certificate": cert.GetName()})
+				return fmt.Errorf("Certificate %s is not issued yet", cert.GetName())
+			}
+			return fmt.Errorf("Failed to get certificate secret %s: %v", secretName, err)
+		}
+		if len(secret.Data[utils.CertManagerCertFile]) == 0 {
+			return fmt.Errorf("Certificate %s is not issued yet", cert.GetName())
+		}
+	}
+	return nil
+}
+
+// getDesiredCertificates returns the cert-manager Certificates of the network.tls names and of the operator client.
+func getDesiredCertificates(aeroCluster *aerospikev1alpha1.AerospikeCluster) []*unstructured.Unstructured {
+	certManager := aeroCluster.Spec.CertManager
+	if certManager == nil {
+		return nil
+	}
+
+	// The wildcard DNS name of the pods, so that the certificates are not reissued when the cluster is scaled.
+	podDNSName := getFQDNForPod(aeroCluster, "*")
+
+	var certs []*unstructured.Unstructured
+	for _, tlsName := range utils.GetTLSNames(aeroCluster.Spec.AerospikeConfig) {
+		spec := map[string]interface{}{
+			"secretName": utils.CertManagerSecretName(aeroCluster, tlsName),
+			"commonName": tlsName,
+			"dnsNames":   []interface{}{tlsName, podDNSName},
+			// Heartbeat and fabric TLS authenticate both ends.
+			"usages":    []interface{}{"server auth", "client auth"},
+			"issuerRef": getIssuerRef(certManager.IssuerRef),
+		}
+		certs = append(certs, newCertificate(aeroCluster, utils.CertManagerSecretName(aeroCluster, tlsName), spec))
+	}
+
+	if getServiceTLSName(aeroCluster) != "" {
+		issuerRef := certManager.IssuerRef
+		if certManager.ClientIssuerRef != nil {
+			issuerRef = *certManager.ClientIssuerRef
+		}
+		spec := map[string]interface{}{
+			"secretName": utils.CertManagerClientSecretName(aeroCluster),
+			"commonName": operatorClientCommonName,
+			"usages":     []interface{}{"client auth"},
+			"issuerRef":  getIssuerRef(issuerRef),
+		}
+		certs = append(certs, newCertificate(aeroCluster, utils.CertManagerClientSecretName(aeroCluster), spec))
+	}
+	return certs
+}
+
+func newCertificate(aeroCluster *aerospikev1alpha1.AerospikeCluster, name string, spec map[string]interface{}) *unstructured.Unstructured {
+	certManager := aeroCluster.Spec.CertManager
+	if certManager.Duration != nil {
+		spec["duration"] = certManager.Duration.Duration.String()
+	}
+	if certManager.RenewBefore != nil {
+		spec["renewBefore"] = certManager.RenewBefore.Duration.String()
+	}
+
+	cert := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	cert.SetAPIVersion(certManagerAPIVersion)
+	cert.SetKind("Certificate")
+	cert.SetName(name)
+	cert.SetNamespace(aeroCluster.Namespace)
+	cert.SetLabels(utils.LabelsForAerospikeCluster(aeroCluster.Name))
+	return cert
+}
+
+func getIssuerRef(issuerRef aerospikev1alpha1.CertManagerIssuerRef) map[string]interface{} {
+	kind := issuerRef.Kind
+	if kind == "" {
+		kind = "Issuer"
+	}
+	group := issuerRef.Group
+	if group == "" {
+		group = utils.CertManagerGroup
+	}
+	return map[string]interface{}{"name": issuerRef.Name, "kind": kind, "group": group}
+}
+
+// createOrUpdateCertificate creates the Certificate, or updates its spec if it has changed.
+func (r *ReconcileAerospikeCluster) createOrUpdateCertificate(aeroCluster *aerospikev1alpha1.AerospikeCluster, cert *unstructured.Unstructured) error {
+	logger := pkglog.New(log.Ctx{"AerospikeCluster": utils.ClusterNamespacedName(aeroCluster)})
+
+	found := &unstructured.Unstructured{}
+	found.SetGroupVersionKind(cert.GroupVersionKind())
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: cert.GetName(), Namespace: cert.GetNamespace()}, found)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return fmt.Errorf("Failed to get Certificate %s: %v", cert.GetName(), err)
+		}
+
+		// Set AerospikeCluster instance as the owner and controller
+		if err := controllerutil.SetControllerReference(aeroCluster, cert, r.scheme); err != nil {
+			return err
+		}
+		if err := r.client.Create(context.TODO(), cert, createOption); err != nil {
+			return fmt.Errorf("Failed to create Certificate %s: %v", cert.GetName(), err)
+		}
+		logger.Info("Created Certificate", log.Ctx{"certificate": cert.GetName()})
+		return nil
+	}
+
+	foundSpec, _, _ := unstructured.NestedMap(found.Object, "spec")
+	spec, _, _ := unstructured.NestedMap(cert.Object, "spec")
+	updated := false
+	for _, key := range certificateSpecKeys {
+		if !reflect.DeepEqual(foundSpec[key], spec[key]) {
+			updated = true
+			break
+		}
+	}
+	if !updated {
+		return nil
+	}
+
+	for _, key := range certificateSpecKeys {
+		if value, ok := spec[key]; ok {
+			foundSpec[key] = value
+		} else {
+			delete(foundSpec, key)
+		}
+	}
+	if err := unstructured.SetNestedMap(found.Object, foundSpec, "spec"); err != nil {
+		return err
+	}
+	if err := r.client.Update(context.TODO(), found, updateOption); err != nil {
+		return fmt.Errorf("Failed to update Certificate %s: %v", cert.GetName(), err)
+	}
+	logger.Info("Updated Certificate", log.Ctx{"certificate": cert.GetName()})
+	return nil
+}
+
+// deleteStaleCertificates deletes the Certificates of the cluster that are not desired.
+func (r *ReconcileAerospikeCluster) deleteStaleCertificates(aeroCluster *aerospikev1alpha1.AerospikeCluster, desired []*unstructured.Unstructured) error {
+	logger := pkglog.New(log.Ctx{"AerospikeCluster": utils.ClusterNamespacedName(aeroCluster)})
+
+	certList := &unstructured.UnstructuredList{}
+	certList.SetAPIVersion(certManagerAPIVersion)
+	certList.SetKind("CertificateList")
+	err := r.client.List(context.TODO(), certList, client.InNamespace(aeroCluster.Namespace), client.MatchingLabels(utils.LabelsForAerospikeCluster(aeroCluster.Name)))
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			// cert-manager is not installed, no Certificates to delete.
+			return nil
+		}
+		return fmt.Errorf("Failed to list Certificates: %v", err)
+	}
+
+	for _, cert := range certList.Items {
+		isDesired := false
+		for _, desiredCert := range desired {
+			if desiredCert.GetName() == cert.GetName() {
+				isDesired = true
+				break
+			}
+		}
+		if isDesired || !isOwnedBy(cert.GetOwnerReferences(), aeroCluster) {
+			continue
+		}
+		if err := r.client.Delete(context.TODO(), &cert); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("Failed to delete Certificate %s: %v", cert.GetName(), err)
+		}
+		logger.Info("Deleted Certificate", log.Ctx{"certificate": cert.GetName()})
+	}
+	return nil
+}
+
+func isOwnedBy(ownerRefs []metav1.OwnerReference, aeroCluster *aerospikev1alpha1.AerospikeCluster) bool {
+	for _, ownerRef := range ownerRefs {
+		if ownerRef.UID == aeroCluster.UID {
+			return true
+		}
+	}
+	return false
+}
+
+// updateStatefulSetCertManagerVolumes mounts the cert-manager certificate secrets in the aerospike server container, a
+// directory per TLS name.
+func updateStatefulSetCertManagerVolumes(aeroCluster *aerospikev1alpha1.AerospikeCluster, st *appsv1.StatefulSet) {
+	// Remove the previous certificate volumes.
+	var volumes []corev1.Volume
+	for _, volume := range st.Spec.Template.Spec.Volumes {
+		if !strings.HasPrefix(volume.Name, certManagerVolumePrefix) {
+			volumes = append(volumes, volume)
+		}
+	}
+	container := &st.Spec.Template.Spec.Containers[0]
+	var mounts []corev1.VolumeMount
+	for _, mount := range container.VolumeMounts {
+		if !strings.HasPrefix(mount.Name, certManagerVolumePrefix) {
+			mounts = append(mounts, mount)
+		}
+	}
+
+	if aeroCluster.Spec.CertManager != nil {
+		for i, tlsName := range utils.GetTLSNames(aeroCluster.Spec.AerospikeConfig) {
+			volumeName := certManagerVolumePrefix + strconv.Itoa(i)
+			volumes = append(volumes, corev1.Volume{
+				Name: volumeName,
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: utils.CertManagerSecretName(aeroCluster, tlsName),
+					},
+				},
+			})
+			mounts = append(mounts, corev1.VolumeMount{
+				Name:      volumeName,
+				MountPath: utils.CertManagerTLSPath(tlsName, ""),
+				ReadOnly:  true,
+			})
+		}
+	}
+
+	st.Spec.Template.Spec.Volumes = volumes
+	container.VolumeMounts = mounts
+}
@@ -0,0 +1,39 @@
+package aerospikecluster
+
+import (
+	"reflect"
+	"testing"
+
+	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestGetDesiredCertificatesDNSNames(t *testing.T) {
+	aeroCluster := &aerospikev1alpha1.AerospikeCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "aerocluster", Namespace: "test"},
+		Spec: aerospikev1alpha1.AerospikeClusterSpec{
+			Size: 2,
+			AerospikeConfig: aerospikev1alpha1.Values{
+				"network": map[string]interface{}{
+					"tls": []interface{}{map[string]interface{}{"name": "aerospike-a-0.test-runner"}},
+				},
+			},
+			RackConfig:  aerospikev1alpha1.RackConfig{Racks: []aerospikev1alpha1.Rack{{ID: 1}}},
+			CertManager: &aerospikev1alpha1.AerospikeCertManagerSpec{},
+		},
+	}
+
+	want := []interface{}{"aerospike-a-0.test-runner", "*.aerocluster.test.svc.cluster.local"}
+	for _, size := range []int32{2, 5} {
+		aeroCluster.Spec.Size = size
+		certs := getDesiredCertificates(aeroCluster)
+		if len(certs) != 1 {
+			t.Fatalf("size %d: got %d certificates, want 1", size, len(certs))
+		}
+		dnsNames, _, _ := unstructured.NestedSlice(certs[0].Object, "spec", "dnsNames")
+		if !reflect.DeepEqual(dnsNames, want) {
+			t.Errorf("size %d: got dnsNames %v, want %v", size, dnsNames, want)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package aerospikecluster
+
+import (
+	"context"
+
+	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
+	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/conffile"
+	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/utils"
+	log "github.com/inconshreveable/log15"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// configFileMapper maps a ConfigMap to the clusters reading their aerospike.conf file from it.
+type configFileMapper struct {
+	client client.Client
+}
+
+// Map returns reconcile requests for the clusters in the ConfigMap namespace reading their aerospike.conf file from it.
+func (m *configFileMapper) Map(obj handler.MapObject) []reconcile.Request {
+	clusterList := &aerospikev1alpha1.AerospikeClusterList{}
+	if err := m.client.List(context.TODO(), clusterList, client.InNamespace(obj.Meta.GetNamespace())); err != nil {
+		pkglog.Error("Failed to list AerospikeClusters for ConfigMap", log.Ctx{"ConfigMap": utils.NamespacedName(obj.Meta.GetNamespace(), obj.Meta.GetName()), "err": err})
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range clusterList.Items {
+		if conffile.IsReferenced(&clusterList.Items[i], obj.Meta.GetName()) {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: clusterList.Items[i].Name, Namespace: clusterList.Items[i].Namespace}})
+		}
+	}
+	return requests
+}
+
+// reconcileConfigFile updates the cluster if its aerospike.conf file ConfigMap has changed since the file was parsed
+// into the cluster spec. The update goes through the mutating webhook which parses the current file.
+//
+// A ConfigMap which cannot be read or applied is reported with an event, and the cluster is reconciled with the
+// aerospike.conf file last parsed into its spec. The update is retried on the next reconcile.
+func (r *ReconcileAerospikeCluster) reconcileConfigFile(aeroCluster *aerospikev1alpha1.AerospikeCluster) {
+	logger := pkglog.New(log.Ctx{"AerospikeCluster": utils.ClusterNamespacedName(aeroCluster)})
+
+	if aeroCluster.Spec.AerospikeConfigFile == nil {
+		return
+	}
+
+	configMap, err := conffile.GetConfigMap(r.client, aeroCluster)
+	if err != nil {
+		logger.Warn("Failed to get aerospikeConfigFile. Using the last applied aerospikeConfigFile", log.Ctx{"err": err})
+		r.recordEvent(aeroCluster, corev1.EventTypeWarning, "ConfigFileFailed", "Failed to get aerospikeConfigFile, using the last applied aerospikeConfigFile: %v", err)
+		return
+	}
+
+	version := configMap.ResourceVersion
+	if aeroCluster.Annotations[conffile.VersionAnnotation] == version {
+		return
+	}
+
+	logger.Info("aerospikeConfigFile changed. Updating cluster spec", log.Ctx{"configMap": configMap.Name, "version": version})
+
+	// The mutating webhook sets the version after parsing the file. The update is made on a copy so that the cluster is
+	// left unchanged if it fails.
+	updated := aeroCluster.DeepCopy()
+	if updated.Annotations == nil {
+		updated.Annotations = map[string]string{}
+	}
+	updated.Annotations[conffile.VersionAnnotation] = version
+	if err := r.client.Update(context.TODO(), updated, updateOption); err != nil {
+		logger.Warn("Failed to apply aerospikeConfigFile. Using the last applied aerospikeConfigFile", log.Ctx{"configMap": configMap.Name, "version": version, "err": err})
+		r.recordEvent(aeroCluster, corev1.EventTypeWarning, "ConfigFileFailed", "Failed to apply aerospikeConfigFile ConfigMap %s version %s, using the last applied aerospikeConfigFile: %v", configMap.Name, version, err)
+		return
+	}
+	updated.DeepCopyInto(aeroCluster)
+
+	r.recordEvent(aeroCluster, corev1.EventTypeNormal, "ConfigFileApplied", "Applied aerospikeConfigFile ConfigMap %s version %s", configMap.Name, version)
+}
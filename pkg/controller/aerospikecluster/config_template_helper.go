@@ -0,0 +1,79 @@
+package aerospikecluster
+
+import (
+	"context"
+
+	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
+	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/utils"
+	log "github.com/inconshreveable/log15"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// configTemplateMapper maps a config template to the clusters referencing it.
+type configTemplateMapper struct {
+	client client.Client
+}
+
+// Map returns reconcile requests for the clusters in the config template namespace referencing it.
+func (m *configTemplateMapper) Map(obj handler.MapObject) []reconcile.Request {
+	clusterList := &aerospikev1alpha1.AerospikeClusterList{}
+	if err := m.client.List(context.TODO(), clusterList, client.InNamespace(obj.Meta.GetNamespace())); err != nil {
+		pkglog.Error("Failed to list AerospikeClusters for AerospikeConfigTemplate", log.Ctx{"AerospikeConfigTemplate": utils.NamespacedName(obj.Meta.GetNamespace(), obj.Meta.GetName()), "err": err})
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range clusterList.Items {
+		if utils.IsConfigTemplateReferenced(&clusterList.Items[i], obj.Meta.GetName()) {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: clusterList.Items[i].Name, Namespace: clusterList.Items[i].Namespace}})
+		}
+	}
+	return requests
+}
+
+// reconcileConfigTemplates updates the cluster if its config templates have changed since they were merged into the
+// cluster spec. The update goes through the mutating webhook which merges the current config templates.
+//
+// Config templates which cannot be read or applied are reported with an event, and the cluster is reconciled with the
+// config templates last applied to its spec. The update is retried on the next reconcile.
+func (r *ReconcileAerospikeCluster) reconcileConfigTemplates(aeroCluster *aerospikev1alpha1.AerospikeCluster) {
+	logger := pkglog.New(log.Ctx{"AerospikeCluster": utils.ClusterNamespacedName(aeroCluster)})
+
+	if len(aeroCluster.Spec.ConfigTemplates) == 0 {
+		return
+	}
+
+	templates, err := utils.GetConfigTemplates(r.client, aeroCluster)
+	if err != nil {
+		logger.Warn("Failed to get config templates. Using the last applied config templates", log.Ctx{"err": err})
+		r.recordEvent(aeroCluster, corev1.EventTypeWarning, "ConfigTemplateFailed", "Failed to get config templates, using the last applied config templates: %v", err)
+		return
+	}
+
+	version := utils.GetConfigTemplateVersion(templates)
+	if aeroCluster.Annotations[utils.ConfigTemplateVersionAnnotation] == version {
+		return
+	}
+
+	logger.Info("Config templates changed. Updating cluster spec", log.Ctx{"configTemplates": aeroCluster.Spec.ConfigTemplates, "version": version})
+
+	// The mutating webhook sets the version after merging the config templates. The update is made on a copy so that
+	// the cluster is left unchanged if it fails.
+	updated := aeroCluster.DeepCopy()
+	if updated.Annotations == nil {
+		updated.Annotations = map[string]string{}
+	}
+	updated.Annotations[utils.ConfigTemplateVersionAnnotation] = version
+	if err := r.client.Update(context.TODO(), updated, updateOption); err != nil {
+		logger.Warn("Failed to apply config templates. Using the last applied config templates", log.Ctx{"version": version, "err": err})
+		r.recordEvent(aeroCluster, corev1.EventTypeWarning, "ConfigTemplateFailed", "Failed to apply config templates %s, using the last applied config templates: %v", version, err)
+		return
+	}
+	updated.DeepCopyInto(aeroCluster)
+
+	r.recordEvent(aeroCluster, corev1.EventTypeNormal, "ConfigTemplateApplied", "Applied config templates %s", version)
+}
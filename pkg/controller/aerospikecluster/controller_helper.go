@@ -6,7 +6,6 @@ import (
 	"crypto/x509"
 	"encoding/json"
 	"fmt"
-	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
@@ -17,7 +16,6 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
@@ -27,6 +25,7 @@ import (
 	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
 	accessControl "github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/asconfig"
 	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/configmap"
+	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/jsonpatch"
 	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/utils"
 	lib "github.com/aerospike/aerospike-management-lib"
 	as "github.com/ashishshinde/aerospike-client-go"
@@ -50,10 +49,17 @@ const (
 	// while deleting pvc
 	storagePathAnnotationKey = "storage-path"
 
-	confDirName     = "confdir"
-	initConfDirName = "initconfigs"
+	// filesystemVolumesInitPath is the directory of the filesystem storage volumes in the init container.
+	filesystemVolumesInitPath = "/filesystem-volumes"
+
+	confDirName      = "confdir"
+	initConfDirName  = "initconfigs"
+	secretVolumeName = "secretinfo"
 )
 
+// storageVolumeNameRegex matches k8s volume names generated by getPVCName for storage volumes.
+var storageVolumeNameRegex = regexp.MustCompile("^[0-9a-f]{30}-")
+
 //------------------------------------------------------------------------------------
 // controller helper
 //------------------------------------------------------------------------------------
@@ -196,6 +202,16 @@ func (r *ReconcileAerospikeCluster) createStatefulSet(aeroCluster *aerospikev1al
 
 	updateStatefulSetConfigMapVolumes(aeroCluster, st, rackState)
 
+	updateStatefulSetCertManagerVolumes(aeroCluster, st)
+
+	updateStatefulSetLDAPVolumes(aeroCluster, st)
+
+	updateStatefulSetAdminSecurityMetadataVolumes(aeroCluster, st, rackState)
+
+	if err := r.updateStatefulSetTLSInfo(aeroCluster, st); err != nil {
+		return nil, err
+	}
+
 	updateStatefulSetAffinity(aeroCluster, st, ls, rackState)
 	// Set AerospikeCluster instance as the owner and controller
 	controllerutil.SetControllerReference(aeroCluster, st, r.scheme)
@@ -205,7 +221,7 @@ func (r *ReconcileAerospikeCluster) createStatefulSet(aeroCluster *aerospikev1al
 	}
 	logger.Info("Created new StatefulSet", log.Ctx{"StatefulSet.Namespace": st.Namespace, "StatefulSet.Name": st.Name})
 
-	if err := r.waitForStatefulSetToBeReady(st); err != nil {
+	if err := r.waitForStatefulSetToBeReady(aeroCluster, st); err != nil {
 		return st, fmt.Errorf("Failed to wait for statefulset to be ready: %v", err)
 	}
 
@@ -223,11 +239,66 @@ func (r *ReconcileAerospikeCluster) deleteStatefulSet(aeroCluster *aerospikev1al
 	return r.client.Delete(context.TODO(), st)
 }
 
-func (r *ReconcileAerospikeCluster) waitForStatefulSetToBeReady(st *appsv1.StatefulSet) error {
+// patchClusterCondition sets the condition in the cluster status if it has changed.
+func (r *ReconcileAerospikeCluster) patchClusterCondition(aeroCluster *aerospikev1alpha1.AerospikeCluster, condType aerospikev1alpha1.AerospikeClusterConditionType, status corev1.ConditionStatus, reason, message string) error {
+	cond := aerospikev1alpha1.AerospikeClusterCondition{
+		Type:               condType,
+		Status:             status,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            message,
+	}
+	conditions, changed := setClusterCondition(aeroCluster.Status.Conditions, cond)
+	if !changed {
+		return nil
+	}
+
+	patch := jsonpatch.JsonPatchOperation{Operation: "add", Path: "/status/conditions", Value: conditions}
+	jsonpatchJSON, err := json.Marshal([]jsonpatch.JsonPatchOperation{patch})
+	if err != nil {
+		return fmt.Errorf("Error marshalling json patch: %v", err)
+	}
+
+	constantPatch := client.ConstantPatch(types.JSONPatchType, jsonpatchJSON)
+	if err = r.client.Status().Patch(context.TODO(), aeroCluster, constantPatch, client.FieldOwner(patchFieldOwner)); err != nil {
+		return fmt.Errorf("Error updating %s condition: %v", condType, err)
+	}
+	aeroCluster.Status.Conditions = conditions
+	return nil
+}
+
+// setClusterCondition returns the conditions with cond set in place of the condition of the same type. The last
+// transition time is kept if the status has not changed. Returns false if the conditions are unchanged.
+func setClusterCondition(conditions []aerospikev1alpha1.AerospikeClusterCondition, cond aerospikev1alpha1.AerospikeClusterCondition) ([]aerospikev1alpha1.AerospikeClusterCondition, bool) {
+	updated := []aerospikev1alpha1.AerospikeClusterCondition{}
+	found := false
+	for _, existing := range conditions {
+		if existing.Type != cond.Type {
+			updated = append(updated, existing)
+			continue
+		}
+		if existing.Status == cond.Status && existing.Reason == cond.Reason && existing.Message == cond.Message {
+			return conditions, false
+		}
+		if existing.Status == cond.Status {
+			cond.LastTransitionTime = existing.LastTransitionTime
+		}
+		updated = append(updated, cond)
+		found = true
+	}
+	if !found {
+		updated = append(updated, cond)
+	}
+	return updated, true
+}
+
+func (r *ReconcileAerospikeCluster) waitForStatefulSetToBeReady(aeroCluster *aerospikev1alpha1.AerospikeCluster, st *appsv1.StatefulSet) error {
 	logger := pkglog.New(log.Ctx{"AerospikeCluster statefulset": types.NamespacedName{Name: st.Name, Namespace: st.Namespace}})
 
 	const podStatusMaxRetry = 18
 	const podStatusRetryInterval = time.Second * 10
+	// Pods may be briefly unschedulable, e.g. while their volumes are bound or the cluster autoscaler adds a node.
+	const podUnschedulableTimeout = time.Minute
 
 	logger.Info("Waiting for statefulset to be ready", log.Ctx{"WaitTimePerPod": podStatusRetryInterval * time.Duration(podStatusMaxRetry)})
 
@@ -257,6 +328,14 @@ func (r *ReconcileAerospikeCluster) waitForStatefulSetToBeReady(st *appsv1.State
 			if err := utils.CheckPodFailed(pod); err != nil {
 				return fmt.Errorf("StatefulSet pod %s failed: %v", podName, err)
 			}
+			if cond := utils.GetPodUnschedulableCondition(pod); cond != nil && time.Since(cond.LastTransitionTime.Time) > podUnschedulableTimeout {
+				// No point waiting for a pod that cannot be placed. Surface the scheduler message on the cluster.
+				r.recordEvent(aeroCluster, corev1.EventTypeWarning, "PodUnschedulable", "Pod %s cannot be scheduled: %s", podName, cond.Message)
+				if err := r.patchClusterCondition(aeroCluster, aerospikev1alpha1.AerospikeClusterPodsSchedulable, corev1.ConditionFalse, "PodUnschedulable", fmt.Sprintf("Pod %s cannot be scheduled: %s", podName, cond.Message)); err != nil {
+					logger.Warn("Failed to set PodsSchedulable condition", log.Ctx{"err": err})
+				}
+				return fmt.Errorf("StatefulSet pod %s is unschedulable: %s", podName, cond.Message)
+			}
 			if utils.IsPodRunningAndReady(pod) {
 				isReady = true
 				logger.Info("Pod is running and ready", log.Ctx{"pod": podName})
@@ -302,6 +381,11 @@ func (r *ReconcileAerospikeCluster) waitForStatefulSetToBeReady(st *appsv1.State
 	return nil
 }
 
+// recordEvent records an event for the AerospikeCluster.
+func (r *ReconcileAerospikeCluster) recordEvent(aeroCluster *aerospikev1alpha1.AerospikeCluster, eventType, reason, messageFmt string, args ...interface{}) {
+	r.recorder.Eventf(aeroCluster, eventType, reason, messageFmt, args...)
+}
+
 func (r *ReconcileAerospikeCluster) getStatefulSet(aeroCluster *aerospikev1alpha1.AerospikeCluster, rackState RackState) (*appsv1.StatefulSet, error) {
 	found := &appsv1.StatefulSet{}
 	err := r.client.Get(context.TODO(), getNamespacedNameForStatefulSet(aeroCluster, rackState.Rack.ID), found)
@@ -669,65 +753,70 @@ func (r *ReconcileAerospikeCluster) getClusterServerPool(aeroCluster *aerospikev
 		serverPool = x509.NewCertPool()
 	}
 
-	// get the tls info from secret
-	found := &v1.Secret{}
-	err = r.client.Get(context.TODO(), types.NamespacedName{Name: aeroCluster.Spec.AerospikeConfigSecret.SecretName, Namespace: aeroCluster.Namespace}, found)
-	if err != nil {
-		logger.Warn("Failed to get secret certificates to the pool, returning empty certPool", log.Ctx{"err": err})
-		return serverPool
-	}
-	tlsName := getServiceTLSName(aeroCluster)
+	tlsName := getClientTLSName(aeroCluster)
 	if tlsName == "" {
-		logger.Warn("Failed to get tlsName from aerospikeConfig, returning empty certPool", log.Ctx{"err": err})
+		logger.Warn("Failed to get tlsName from aerospikeConfig, returning empty certPool")
 		return serverPool
 	}
+
+	// Trust the CA certificates of the pods not rolled to the current TLS files yet.
+	for _, caCert := range aeroCluster.Status.TLSCACertificates {
+		serverPool.AppendCertsFromPEM([]byte(caCert))
+	}
+
 	// get ca-file and use as cacert
-	tlsConfList := aeroCluster.Spec.AerospikeConfig["network"].(map[string]interface{})["tls"].([]interface{})
-	for _, tlsConfInt := range tlsConfList {
-		tlsConf := tlsConfInt.(map[string]interface{})
-		if tlsConf["name"].(string) == tlsName {
-			if cafile, ok := tlsConf["ca-file"]; ok {
-				logger.Debug("Adding cert in tls serverpool", log.Ctx{"tlsConf": tlsConf})
-				caFileName := filepath.Base(cafile.(string))
-				serverPool.AppendCertsFromPEM(found.Data[caFileName])
-			}
-		}
+	confFiles := getTLSConfFilesByName(aeroCluster, tlsName)
+	if confFiles == nil || confFiles.caFile == "" {
+		return serverPool
 	}
+	caData, err := r.newTLSFileReader(aeroCluster).read(confFiles.caFile)
+	if err != nil {
+		logger.Warn("Failed to get secret certificates to the pool, returning empty certPool", log.Ctx{"err": err})
+		return serverPool
+	}
+	logger.Debug("Adding cert in tls serverpool", log.Ctx{"caFile": confFiles.caFile})
+	serverPool.AppendCertsFromPEM(caData)
 	return serverPool
 }
 
 func (r *ReconcileAerospikeCluster) getClientCertificate(aeroCluster *aerospikev1alpha1.AerospikeCluster) (*tls.Certificate, error) {
-	logger := pkglog.New(log.Ctx{"AerospikeCluster": utils.ClusterNamespacedName(aeroCluster)})
+	tlsName := getClientTLSName(aeroCluster)
+	if tlsName == "" {
+		return nil, fmt.Errorf("Failed to get tlsName from aerospikeConfig")
+	}
 
-	// get the tls info from secret
-	found := &v1.Secret{}
-	err := r.client.Get(context.TODO(), types.NamespacedName{Name: aeroCluster.Spec.AerospikeConfigSecret.SecretName, Namespace: aeroCluster.Namespace}, found)
+	// The operator has its own client certificate with cert-manager.
+	if aeroCluster.Spec.CertManager != nil {
+		secretName := utils.CertManagerClientSecretName(aeroCluster)
+		found := &v1.Secret{}
+		if err := r.client.Get(context.TODO(), types.NamespacedName{Name: secretName, Namespace: aeroCluster.Namespace}, found); err != nil {
+			return nil, fmt.Errorf("Failed to get client certificate secret %s: %v", secretName, err)
+		}
+		cert, err := tls.X509KeyPair(found.Data[utils.CertManagerCertFile], found.Data[utils.CertManagerKeyFile])
+		if err != nil {
+			return nil, fmt.Errorf("failed to load X509 key pair for cluster: %v", err)
+		}
+		return &cert, nil
+	}
+
+	confFiles := getTLSConfFilesByName(aeroCluster, tlsName)
+	if confFiles == nil {
+		return nil, fmt.Errorf("Failed to get tls config for creating client certificate")
+	}
+	reader := r.newTLSFileReader(aeroCluster)
+	certData, err := reader.read(confFiles.certFile)
 	if err != nil {
-		logger.Warn("Failed to get secret certificates to the pool", log.Ctx{"err": err})
 		return nil, err
 	}
-
-	tlsName := getServiceTLSName(aeroCluster)
-	if tlsName == "" {
-		logger.Warn("Failed to get tlsName from aerospikeConfig", log.Ctx{"err": err})
+	keyData, err := reader.read(confFiles.keyFile)
+	if err != nil {
 		return nil, err
 	}
-	// get ca-file and use as cacert
-	tlsConfList := aeroCluster.Spec.AerospikeConfig["network"].(map[string]interface{})["tls"].([]interface{})
-	for _, tlsConfInt := range tlsConfList {
-		tlsConf := tlsConfInt.(map[string]interface{})
-		if tlsConf["name"].(string) == tlsName {
-			certFileName := filepath.Base(tlsConf["cert-file"].(string))
-			keyFileName := filepath.Base(tlsConf["key-file"].(string))
-
-			cert, err := tls.X509KeyPair(found.Data[certFileName], found.Data[keyFileName])
-			if err != nil {
-				return nil, fmt.Errorf("failed to load X509 key pair for cluster: %v", err)
-			}
-			return &cert, nil
-		}
+	cert, err := tls.X509KeyPair(certData, keyData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load X509 key pair for cluster: %v", err)
 	}
-	return nil, fmt.Errorf("Failed to get tls config for creating client certificate")
+	return &cert, nil
 }
 
 func (r *ReconcileAerospikeCluster) isAeroClusterUpgradeNeeded(aeroCluster *aerospikev1alpha1.AerospikeCluster, rackID int) (bool, error) {
@@ -764,36 +853,6 @@ func (r *ReconcileAerospikeCluster) isAnyPodInFailedState(aeroCluster *aerospike
 	return false
 }
 
-// FromSecretPasswordProvider provides user password from the secret provided in AerospikeUserSpec.
-type FromSecretPasswordProvider struct {
-	// Client to read secrets.
-	client *client.Client
-
-	// The secret namespace.
-	namespace string
-}
-
-// Get returns the password for the username using userSpec.
-func (pp FromSecretPasswordProvider) Get(username string, userSpec *aerospikev1alpha1.AerospikeUserSpec) (string, error) {
-	secret := &corev1.Secret{}
-	secretName := userSpec.SecretName
-	// Assuming secret is in same namespace
-	err := (*pp.client).Get(context.TODO(), types.NamespacedName{Name: secretName, Namespace: pp.namespace}, secret)
-	if err != nil {
-		return "", fmt.Errorf("Failed to get secret %s: %v", secretName, err)
-	}
-
-	passbyte, ok := secret.Data["password"]
-	if !ok {
-		return "", fmt.Errorf("Failed to get password from secret. Please check your secret %s", secretName)
-	}
-	return string(passbyte), nil
-}
-
-func (r *ReconcileAerospikeCluster) getPasswordProvider(aeroCluster *aerospikev1alpha1.AerospikeCluster) FromSecretPasswordProvider {
-	return FromSecretPasswordProvider{client: &r.client, namespace: aeroCluster.Namespace}
-}
-
 func (r *ReconcileAerospikeCluster) getClientPolicy(aeroCluster *aerospikev1alpha1.AerospikeCluster) *as.ClientPolicy {
 	logger := pkglog.New(log.Ctx{"AerospikeCluster": utils.ClusterNamespacedName(aeroCluster)})
 
@@ -805,7 +864,7 @@ func (r *ReconcileAerospikeCluster) getClientPolicy(aeroCluster *aerospikev1alph
 	policy.ClusterName = aeroCluster.Name
 
 	// tls config
-	if tlsName := getServiceTLSName(aeroCluster); tlsName != "" {
+	if tlsName := getClientTLSName(aeroCluster); tlsName != "" {
 		logger.Debug("Set tls config in aeospike client policy")
 		tlsConf := tls.Config{
 			RootCAs:                  r.getClusterServerPool(aeroCluster),
@@ -826,7 +885,7 @@ func (r *ReconcileAerospikeCluster) getClientPolicy(aeroCluster *aerospikev1alph
 		policy.TlsConfig = &tlsConf
 	}
 
-	user, pass, err := accessControl.AerospikeAdminCredentials(&aeroCluster.Spec, &aeroCluster.Status.AerospikeClusterSpec, r.getPasswordProvider(aeroCluster))
+	user, pass, err := accessControl.AerospikeAdminCredentials(&aeroCluster.Spec, &aeroCluster.Status.AerospikeClusterSpec, utils.AdminSecretName(aeroCluster), r.getPasswordProvider(aeroCluster))
 	if err != nil {
 		logger.Error("Failed to get cluster auth info", log.Ctx{"err": err})
 	}
@@ -834,6 +893,8 @@ func (r *ReconcileAerospikeCluster) getClientPolicy(aeroCluster *aerospikev1alph
 	policy.Timeout = time.Minute * 1
 	policy.User = user
 	policy.Password = pass
+	// The operator authenticates as an internal user, also when external users authenticate with LDAP.
+	policy.AuthMode = as.AuthModeInternal
 	return policy
 }
 
@@ -870,7 +931,7 @@ func updateStatefulSetStorage(aeroCluster *aerospikev1alpha1.AerospikeCluster, s
 			st.Spec.Template.Spec.InitContainers[0].VolumeDevices = append(st.Spec.Template.Spec.InitContainers[0].VolumeDevices, initVolumeDevice)
 		} else if volume.VolumeMode == aerospikev1alpha1.AerospikeVolumeModeFilesystem {
 			volumeMode = corev1.PersistentVolumeFilesystem
-			initContainerVolumePathPrefix = "/filesystem-volumes"
+			initContainerVolumePathPrefix = filesystemVolumesInitPath
 
 			logger.Info("Add volume mount for volume", log.Ctx{"volume": volume})
 			volumeMount := corev1.VolumeMount{
@@ -885,36 +946,58 @@ func updateStatefulSetStorage(aeroCluster *aerospikev1alpha1.AerospikeCluster, s
 			}
 			st.Spec.Template.Spec.InitContainers[0].VolumeMounts = append(st.Spec.Template.Spec.InitContainers[0].VolumeMounts, initVolumeMount)
 		} else {
-			// No volume claims for config maps.
+			// No volume claims for pod volumes.
 			continue
 		}
 
-		storageClass := volume.StorageClass
-		pvc := corev1.PersistentVolumeClaim{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      pvcName,
-				Namespace: aeroCluster.Namespace,
-				// Use this path annotation while matching pvc with storage volume
-				Annotations: map[string]string{
-					storagePathAnnotationKey: volume.Path,
-				},
-			},
-			Spec: corev1.PersistentVolumeClaimSpec{
-				VolumeMode:  &volumeMode,
-				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
-				Resources: corev1.ResourceRequirements{
-					Requests: corev1.ResourceList{
-						corev1.ResourceStorage: resource.MustParse(fmt.Sprintf("%dGi", volume.SizeInGB)),
-					},
-				},
-				StorageClassName: &storageClass,
-			},
-		}
+		addVolumeAttachmentMounts(st.Spec.Template.Spec.Containers, pvcName, volume)
+
+		pvc := newPVCForVolume(aeroCluster, pvcName, volume, volumeMode)
 		st.Spec.VolumeClaimTemplates = append(st.Spec.VolumeClaimTemplates, pvc)
 	}
 	return nil
 }
 
+// newPVCForVolume returns the claim for a persistent storage volume.
+func newPVCForVolume(aeroCluster *aerospikev1alpha1.AerospikeCluster, pvcName string, volume aerospikev1alpha1.AerospikePersistentVolumeSpec, volumeMode corev1.PersistentVolumeMode) corev1.PersistentVolumeClaim {
+	// Copy user labels and annotations since creating the statefulset mutates the claim template.
+	var labels map[string]string
+	if len(volume.Labels) != 0 {
+		labels = map[string]string{}
+		for key, value := range volume.Labels {
+			labels[key] = value
+		}
+	}
+
+	annotations := map[string]string{}
+	for key, value := range volume.Annotations {
+		annotations[key] = value
+	}
+	// Use this path annotation while matching pvc with storage volume
+	annotations[storagePathAnnotationKey] = volume.Path
+
+	storageClass := volume.StorageClass
+	return corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        pvcName,
+			Namespace:   aeroCluster.Namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			VolumeMode:  &volumeMode,
+			AccessModes: volume.GetAccessModes(),
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: volume.GetSize(),
+				},
+			},
+			StorageClassName: &storageClass,
+			Selector:         volume.Selector.DeepCopy(),
+		},
+	}
+}
+
 func updateStatefulSetAffinity(aeroCluster *aerospikev1alpha1.AerospikeCluster, st *appsv1.StatefulSet, labels map[string]string, rackState RackState) {
 	logger := pkglog.New(log.Ctx{"AerospikeCluster": utils.ClusterNamespacedName(aeroCluster)})
 
@@ -940,21 +1023,21 @@ func updateStatefulSetAffinity(aeroCluster *aerospikev1alpha1.AerospikeCluster,
 
 	if rackState.Rack.Zone != "" {
 		matchExpressions = append(matchExpressions, corev1.NodeSelectorRequirement{
-			Key:      "failure-domain.beta.kubernetes.io/zone",
+			Key:      utils.ZoneLabel,
 			Operator: corev1.NodeSelectorOpIn,
 			Values:   []string{rackState.Rack.Zone},
 		})
 	}
 	if rackState.Rack.Region != "" {
 		matchExpressions = append(matchExpressions, corev1.NodeSelectorRequirement{
-			Key:      "failure-domain.beta.kubernetes.io/region",
+			Key:      utils.RegionLabel,
 			Operator: corev1.NodeSelectorOpIn,
 			Values:   []string{rackState.Rack.Region},
 		})
 	}
 	if rackState.Rack.RackLabel != "" {
 		matchExpressions = append(matchExpressions, corev1.NodeSelectorRequirement{
-			Key:      "aerospike.com/rack-label",
+			Key:      utils.RackLabel,
 			Operator: corev1.NodeSelectorOpIn,
 			Values:   []string{rackState.Rack.RackLabel},
 		})
@@ -962,7 +1045,7 @@ func updateStatefulSetAffinity(aeroCluster *aerospikev1alpha1.AerospikeCluster,
 
 	if rackState.Rack.NodeName != "" {
 		matchExpressions = append(matchExpressions, corev1.NodeSelectorRequirement{
-			Key:      "kubernetes.io/hostname",
+			Key:      utils.HostnameLabel,
 			Operator: corev1.NodeSelectorOpIn,
 			Values:   []string{rackState.Rack.NodeName},
 		})
@@ -989,7 +1072,6 @@ func updateStatefulSetAffinity(aeroCluster *aerospikev1alpha1.AerospikeCluster,
 func updateStatefulSetSecretInfo(aeroCluster *aerospikev1alpha1.AerospikeCluster, st *appsv1.StatefulSet) {
 	logger := pkglog.New(log.Ctx{"AerospikeCluster": utils.ClusterNamespacedName(aeroCluster)})
 	if aeroCluster.Spec.AerospikeConfigSecret.SecretName != "" {
-		const secretVolumeName = "secretinfo"
 		logger.Info("Add secret volume in statefulset pods")
 		var volFound bool
 		for _, vol := range st.Spec.Template.Spec.Volumes {
@@ -1074,13 +1156,18 @@ func updateStatefulSetPodSpec(aeroCluster *aerospikev1alpha1.AerospikeCluster, s
 }
 
 // Called while creating new cluster and also during rolling restart.
+// Adds the rack's non persistent volumes, e.g. configMap, emptyDir, secret, hostPath and projected volumes, to the pods.
 func updateStatefulSetConfigMapVolumes(aeroCluster *aerospikev1alpha1.AerospikeCluster, st *appsv1.StatefulSet, rackState RackState) {
-	configMaps, _ := rackState.Rack.Storage.GetConfigMaps()
+	podVolumes := rackState.Rack.Storage.GetPodVolumes()
+
+	// The storage volume mounts of the sidecars are rebuilt from the spec below, so that mounts of detached volumes are
+	// removed.
+	removeStorageVolumeMounts(st.Spec.Template.Spec.Containers[1:])
 
 	// Add to stateful set volumes.
-	for _, configMapVolume := range configMaps {
+	for _, podVolume := range podVolumes {
 		// Ignore error since its not possible.
-		pvcName, _ := getPVCName(configMapVolume.Path)
+		pvcName, _ := getPVCName(podVolume.Path)
 		found := false
 		for _, volume := range st.Spec.Template.Spec.Volumes {
 			if volume.Name == pvcName {
@@ -1091,31 +1178,31 @@ func updateStatefulSetConfigMapVolumes(aeroCluster *aerospikev1alpha1.AerospikeC
 
 		if !found {
 			k8sVolume := corev1.Volume{
-				Name: pvcName,
-				VolumeSource: corev1.VolumeSource{
-					ConfigMap: &corev1.ConfigMapVolumeSource{
-						LocalObjectReference: corev1.LocalObjectReference{
-							Name: configMapVolume.ConfigMapName,
-						},
-					},
-				},
+				Name:         pvcName,
+				VolumeSource: podVolume.GetVolumeSource(),
 			}
 			st.Spec.Template.Spec.Volumes = append(st.Spec.Template.Spec.Volumes, k8sVolume)
 		}
 
-		addConfigMapVolumeMount(st.Spec.Template.Spec.InitContainers, configMapVolume)
-		addConfigMapVolumeMount(st.Spec.Template.Spec.Containers, configMapVolume)
+		if podVolume.VolumeMode == aerospikev1alpha1.AerospikeVolumeModeConfigMap {
+			// Config maps are mounted in all the containers.
+			addPodVolumeMount(st.Spec.Template.Spec.InitContainers, podVolume)
+			addPodVolumeMount(st.Spec.Template.Spec.Containers, podVolume)
+		} else {
+			// Other volumes are mounted in the aerospike server container and the attached sidecars.
+			addPodVolumeMount(st.Spec.Template.Spec.Containers[:1], podVolume)
+		}
 	}
 
 	// Remove to stateful set volumes.
 	j := 0
 	for _, volume := range st.Spec.Template.Spec.Volumes {
-		found := volume.ConfigMap == nil || volume.Name == confDirName || volume.Name == initConfDirName
-		for _, configMapVolume := range configMaps {
+		// Do not touch internal volumes.
+		found := !isPodStorageVolume(volume)
+		for _, podVolume := range podVolumes {
 			// Ignore error since its not possible.
-			pvcName, _ := getPVCName(configMapVolume.Path)
+			pvcName, _ := getPVCName(podVolume.Path)
 			if volume.Name == pvcName {
-				// Do not touch non confimap volumes or reserved config map volumes.
 				found = true
 				break
 			}
@@ -1127,22 +1214,30 @@ func updateStatefulSetConfigMapVolumes(aeroCluster *aerospikev1alpha1.AerospikeC
 			j++
 		} else {
 			// Remove volume mounts from the containers.
-			removeConfigMapVolumeMount(st.Spec.Template.Spec.InitContainers, volume)
-			removeConfigMapVolumeMount(st.Spec.Template.Spec.Containers, volume)
+			removePodVolumeMount(st.Spec.Template.Spec.InitContainers, volume)
+			removePodVolumeMount(st.Spec.Template.Spec.Containers, volume)
 		}
 	}
 	st.Spec.Template.Spec.Volumes = st.Spec.Template.Spec.Volumes[:j]
+
+	// Sidecars are recreated from the spec during rolling restart, so mount all attached volumes including the
+	// persistent volumes again.
+	for _, volume := range rackState.Rack.Storage.Volumes {
+		// Ignore error since its not possible.
+		pvcName, _ := getPVCName(volume.Path)
+		addVolumeAttachmentMounts(st.Spec.Template.Spec.Containers, pvcName, volume)
+	}
 }
 
-func addConfigMapVolumeMount(containers []corev1.Container, configMapVolume aerospikev1alpha1.AerospikePersistentVolumeSpec) {
+func addPodVolumeMount(containers []corev1.Container, podVolume aerospikev1alpha1.AerospikePersistentVolumeSpec) {
 	// Update volume mounts.
 	for i := range containers {
 		container := &containers[i]
 		// Ignore error since its not possible.
-		pvcName, _ := getPVCName(configMapVolume.Path)
+		pvcName, _ := getPVCName(podVolume.Path)
 		mountFound := false
 		for _, mount := range container.VolumeMounts {
-			if mount.Name == pvcName {
+			if mount.Name == pvcName && mount.MountPath == podVolume.Path {
 				mountFound = true
 				break
 			}
@@ -1151,14 +1246,14 @@ func addConfigMapVolumeMount(containers []corev1.Container, configMapVolume aero
 		if !mountFound {
 			volumeMount := corev1.VolumeMount{
 				Name:      pvcName,
-				MountPath: configMapVolume.Path,
+				MountPath: podVolume.Path,
 			}
 			container.VolumeMounts = append(container.VolumeMounts, volumeMount)
 		}
 	}
 }
 
-func removeConfigMapVolumeMount(containers []corev1.Container, volume corev1.Volume) {
+func removePodVolumeMount(containers []corev1.Container, volume corev1.Volume) {
 	// Update volume mounts.
 	for i := range containers {
 		container := &containers[i]
@@ -1175,6 +1270,82 @@ func removeConfigMapVolumeMount(containers []corev1.Container, volume corev1.Vol
 	}
 }
 
+// removeStorageVolumeMounts removes the mounts and devices of the storage volumes from the containers. Mounts of other
+// volumes, e.g. the ones defined in the sidecar spec, are retained.
+func removeStorageVolumeMounts(containers []corev1.Container) {
+	for i := range containers {
+		container := &containers[i]
+
+		mounts := container.VolumeMounts[:0]
+		for _, mount := range container.VolumeMounts {
+			if !storageVolumeNameRegex.MatchString(mount.Name) {
+				mounts = append(mounts, mount)
+			}
+		}
+		container.VolumeMounts = mounts
+
+		devices := container.VolumeDevices[:0]
+		for _, device := range container.VolumeDevices {
+			if !storageVolumeNameRegex.MatchString(device.Name) {
+				devices = append(devices, device)
+			}
+		}
+		container.VolumeDevices = devices
+	}
+}
+
+// addVolumeAttachmentMounts mounts the volume in the sidecar containers it is attached to.
+func addVolumeAttachmentMounts(containers []corev1.Container, volumeName string, volume aerospikev1alpha1.AerospikePersistentVolumeSpec) {
+	for _, attachment := range volume.Sidecars {
+		for i := range containers {
+			container := &containers[i]
+			if container.Name != attachment.ContainerName {
+				continue
+			}
+
+			path := attachment.GetPath(volume)
+			if volume.VolumeMode == aerospikev1alpha1.AerospikeVolumeModeBlock {
+				if !hasVolumeDevice(container, volumeName, path) {
+					container.VolumeDevices = append(container.VolumeDevices, corev1.VolumeDevice{
+						Name:       volumeName,
+						DevicePath: path,
+					})
+				}
+			} else if !hasVolumeMount(container, volumeName, path) {
+				container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+					Name:      volumeName,
+					MountPath: path,
+					SubPath:   attachment.SubPath,
+					ReadOnly:  attachment.ReadOnly,
+				})
+			}
+		}
+	}
+}
+
+func hasVolumeMount(container *corev1.Container, volumeName, path string) bool {
+	for _, mount := range container.VolumeMounts {
+		if mount.Name == volumeName && mount.MountPath == path {
+			return true
+		}
+	}
+	return false
+}
+
+func hasVolumeDevice(container *corev1.Container, volumeName, path string) bool {
+	for _, device := range container.VolumeDevices {
+		if device.Name == volumeName && device.DevicePath == path {
+			return true
+		}
+	}
+	return false
+}
+
+// isPodStorageVolume indicates if the pod volume was added for a non persistent storage volume.
+func isPodStorageVolume(volume corev1.Volume) bool {
+	return volume.PersistentVolumeClaim == nil && storageVolumeNameRegex.MatchString(volume.Name)
+}
+
 func updateStatefulSetAerospikeServerContainerResources(aeroCluster *aerospikev1alpha1.AerospikeCluster, st *appsv1.StatefulSet) {
 	// These resource is for main aerospike container. Other sidecar can mention their own resource
 	st.Spec.Template.Spec.Containers[0].Resources = *aeroCluster.Spec.Resources
@@ -1374,25 +1545,8 @@ func getNamespacedNameForConfigMap(aeroCluster *aerospikev1alpha1.AerospikeClust
 	}
 }
 
-func splitRacks(nodes, racks int) []int {
-	nodesPerRack, extraNodes := nodes/racks, nodes%racks
-
-	// Distributing nodes in given racks
-	var topology []int
-
-	for rackIdx := 0; rackIdx < racks; rackIdx++ {
-		nodesForThisRack := nodesPerRack
-		if rackIdx < extraNodes {
-			nodesForThisRack++
-		}
-		topology = append(topology, nodesForThisRack)
-	}
-
-	return topology
-}
-
 func getNewRackStateList(aeroCluster *aerospikev1alpha1.AerospikeCluster) []RackState {
-	topology := splitRacks(int(aeroCluster.Spec.Size), len(aeroCluster.Spec.RackConfig.Racks))
+	topology := utils.SplitRacks(int(aeroCluster.Spec.Size), len(aeroCluster.Spec.RackConfig.Racks))
 	var rackStateList []RackState
 	for idx, rack := range aeroCluster.Spec.RackConfig.Racks {
 		rackStateList = append(rackStateList, RackState{
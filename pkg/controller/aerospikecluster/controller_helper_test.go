@@ -0,0 +1,115 @@
+package aerospikecluster
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSetClusterCondition(t *testing.T) {
+	before := metav1.NewTime(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+	now := metav1.NewTime(before.Add(time.Hour))
+
+	ready := aerospikev1alpha1.AerospikeClusterCondition{Type: aerospikev1alpha1.AerospikeClusterPodsSchedulable, Status: corev1.ConditionTrue, LastTransitionTime: before, Reason: "PodsReady"}
+	other := aerospikev1alpha1.AerospikeClusterCondition{Type: "Other", Status: corev1.ConditionTrue, LastTransitionTime: before}
+
+	unschedulable := aerospikev1alpha1.AerospikeClusterCondition{Type: aerospikev1alpha1.AerospikeClusterPodsSchedulable, Status: corev1.ConditionFalse, LastTransitionTime: now, Reason: "PodUnschedulable", Message: "Pod aerocluster-0-1 cannot be scheduled"}
+	unschedulableBefore := unschedulable
+	unschedulableBefore.LastTransitionTime = before
+	otherPod := unschedulable
+	otherPod.Message = "Pod aerocluster-0-2 cannot be scheduled"
+	otherPodBefore := otherPod
+	otherPodBefore.LastTransitionTime = before
+
+	tests := []struct {
+		name        string
+		conditions  []aerospikev1alpha1.AerospikeClusterCondition
+		cond        aerospikev1alpha1.AerospikeClusterCondition
+		want        []aerospikev1alpha1.AerospikeClusterCondition
+		wantChanged bool
+	}{
+		{name: "added", conditions: []aerospikev1alpha1.AerospikeClusterCondition{other}, cond: unschedulable, want: []aerospikev1alpha1.AerospikeClusterCondition{other, unschedulable}, wantChanged: true},
+		{name: "status changed", conditions: []aerospikev1alpha1.AerospikeClusterCondition{ready, other}, cond: unschedulable, want: []aerospikev1alpha1.AerospikeClusterCondition{unschedulable, other}, wantChanged: true},
+		{name: "unchanged", conditions: []aerospikev1alpha1.AerospikeClusterCondition{unschedulableBefore}, cond: unschedulable, want: []aerospikev1alpha1.AerospikeClusterCondition{unschedulableBefore}},
+		{name: "message changed", conditions: []aerospikev1alpha1.AerospikeClusterCondition{unschedulableBefore}, cond: otherPod, want: []aerospikev1alpha1.AerospikeClusterCondition{otherPodBefore}, wantChanged: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, changed := setClusterCondition(test.conditions, test.cond)
+			if changed != test.wantChanged {
+				t.Errorf("got changed %v, want %v", changed, test.wantChanged)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got conditions %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestUpdateStatefulSetConfigMapVolumesRemovesDetachedMounts(t *testing.T) {
+	dataVolume := aerospikev1alpha1.AerospikePersistentVolumeSpec{
+		Path:       "/opt/aerospike/data",
+		VolumeMode: aerospikev1alpha1.AerospikeVolumeModeFilesystem,
+		Sidecars:   []aerospikev1alpha1.AerospikeVolumeAttachment{{ContainerName: "exporter", Path: "/data"}},
+	}
+	logVolume := aerospikev1alpha1.AerospikePersistentVolumeSpec{
+		Path:       "/opt/aerospike/logs",
+		VolumeMode: aerospikev1alpha1.AerospikeVolumeModeEmptyDir,
+		EmptyDir:   &corev1.EmptyDirVolumeSource{},
+		Sidecars:   []aerospikev1alpha1.AerospikeVolumeAttachment{{ContainerName: "exporter", ReadOnly: true}},
+	}
+	configVolume := aerospikev1alpha1.AerospikePersistentVolumeSpec{
+		Path:          "/etc/exporter",
+		VolumeMode:    aerospikev1alpha1.AerospikeVolumeModeConfigMap,
+		ConfigMapName: "exporter-config",
+	}
+	dataName, _ := getPVCName(dataVolume.Path)
+	logName, _ := getPVCName(logVolume.Path)
+	configName, _ := getPVCName(configVolume.Path)
+
+	st := &appsv1.StatefulSet{}
+	st.Spec.Template.Spec.InitContainers = []corev1.Container{{Name: "init"}}
+	st.Spec.Template.Spec.Containers = []corev1.Container{
+		{Name: "aerospike-server"},
+		{Name: "exporter", VolumeMounts: []corev1.VolumeMount{{Name: "exporter-cache", MountPath: "/cache"}}},
+	}
+
+	update := func(volumes ...aerospikev1alpha1.AerospikePersistentVolumeSpec) []corev1.VolumeMount {
+		rackState := RackState{Rack: aerospikev1alpha1.Rack{Storage: aerospikev1alpha1.AerospikeStorageSpec{Volumes: volumes}}}
+		updateStatefulSetConfigMapVolumes(&aerospikev1alpha1.AerospikeCluster{}, st, rackState)
+		return st.Spec.Template.Spec.Containers[1].VolumeMounts
+	}
+
+	want := []corev1.VolumeMount{
+		{Name: "exporter-cache", MountPath: "/cache"},
+		{Name: configName, MountPath: "/etc/exporter"},
+		{Name: dataName, MountPath: "/data"},
+		{Name: logName, MountPath: "/opt/aerospike/logs", ReadOnly: true},
+	}
+	if got := update(dataVolume, logVolume, configVolume); !reflect.DeepEqual(got, want) {
+		t.Errorf("got sidecar mounts %v, want %v", got, want)
+	}
+
+	// Dropping the attachments removes the sidecar mounts.
+	dataVolume.Sidecars = nil
+	logVolume.Sidecars = nil
+	want = []corev1.VolumeMount{
+		{Name: "exporter-cache", MountPath: "/cache"},
+		{Name: configName, MountPath: "/etc/exporter"},
+	}
+	if got := update(dataVolume, logVolume, configVolume); !reflect.DeepEqual(got, want) {
+		t.Errorf("got sidecar mounts %v, want %v", got, want)
+	}
+
+	// Removing the config map volume removes its mount.
+	want = []corev1.VolumeMount{{Name: "exporter-cache", MountPath: "/cache"}}
+	if got := update(dataVolume, logVolume); !reflect.DeepEqual(got, want) {
+		t.Errorf("got sidecar mounts %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,103 @@
+package aerospikecluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
+	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/featurekey"
+	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/jsonpatch"
+	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/utils"
+	log "github.com/inconshreveable/log15"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// featureKeyExpiryWarning is how long before the feature key expires to start warning.
+const featureKeyExpiryWarning = 30 * 24 * time.Hour
+
+// reconcileFeatureKey validates the cluster spec against the feature key before any pod is changed, since pods with an
+// unlicensed config crash loop and block further changes. The feature key expiry is updated in the status and a warning
+// event is recorded when the feature key is about to expire.
+func (r *ReconcileAerospikeCluster) reconcileFeatureKey(aeroCluster *aerospikev1alpha1.AerospikeCluster) error {
+	logger := pkglog.New(log.Ctx{"AerospikeCluster": utils.ClusterNamespacedName(aeroCluster)})
+
+	if !utils.IsEnterprise(aeroCluster.Spec.Image) {
+		return nil
+	}
+
+	featureKey, err := featurekey.Load(r.client, aeroCluster)
+	if err != nil {
+		r.recordEvent(aeroCluster, corev1.EventTypeWarning, "FeatureKeyInvalid", "Failed to load feature key: %v", err)
+		return err
+	}
+
+	var featureKeyStatus *aerospikev1alpha1.AerospikeFeatureKeyStatus
+	if featureKey != nil {
+		featureKeyStatus = &aerospikev1alpha1.AerospikeFeatureKeyStatus{SerialNumber: featureKey.SerialNumber}
+		if featureKey.ValidUntil != nil {
+			validUntil := metav1.NewTime(*featureKey.ValidUntil)
+			featureKeyStatus.ValidUntil = &validUntil
+		}
+	}
+	if err := r.patchFeatureKeyStatus(aeroCluster, featureKeyStatus); err != nil {
+		return err
+	}
+
+	if featureKey == nil {
+		return nil
+	}
+
+	if allErrs := featureKey.Validate(&aeroCluster.Spec, field.NewPath("spec")); len(allErrs) != 0 {
+		err := allErrs.ToAggregate()
+		r.recordEvent(aeroCluster, corev1.EventTypeWarning, "FeatureKeyInvalid", "Cluster spec is not valid for the feature key: %v", err)
+		return fmt.Errorf("Cluster spec is not valid for the feature key: %v", err)
+	}
+
+	if featureKey.ValidUntil != nil && time.Until(*featureKey.ValidUntil) < featureKeyExpiryWarning {
+		logger.Warn("Feature key is about to expire", log.Ctx{"serialNumber": featureKey.SerialNumber, "validUntil": featureKey.ValidUntil})
+		r.recordEvent(aeroCluster, corev1.EventTypeWarning, "FeatureKeyExpiring", "Feature key %s expires on %s", featureKey.SerialNumber, featureKey.ValidUntil.Format("2006-01-02"))
+	}
+	return nil
+}
+
+// patchFeatureKeyStatus updates the feature key status if it has changed.
+func (r *ReconcileAerospikeCluster) patchFeatureKeyStatus(aeroCluster *aerospikev1alpha1.AerospikeCluster, featureKeyStatus *aerospikev1alpha1.AerospikeFeatureKeyStatus) error {
+	if isFeatureKeyStatusEqual(aeroCluster.Status.FeatureKey, featureKeyStatus) {
+		return nil
+	}
+
+	var patch jsonpatch.JsonPatchOperation
+	if featureKeyStatus == nil {
+		patch = jsonpatch.JsonPatchOperation{Operation: "remove", Path: "/status/featureKey"}
+	} else {
+		patch = jsonpatch.JsonPatchOperation{Operation: "add", Path: "/status/featureKey", Value: featureKeyStatus}
+	}
+
+	jsonpatchJSON, err := json.Marshal([]jsonpatch.JsonPatchOperation{patch})
+	if err != nil {
+		return fmt.Errorf("Error marshalling json patch: %v", err)
+	}
+
+	constantPatch := client.ConstantPatch(types.JSONPatchType, jsonpatchJSON)
+	if err = r.client.Status().Patch(context.TODO(), aeroCluster, constantPatch, client.FieldOwner(patchFieldOwner)); err != nil {
+		return fmt.Errorf("Error updating feature key status: %v", err)
+	}
+	return nil
+}
+
+func isFeatureKeyStatusEqual(status1, status2 *aerospikev1alpha1.AerospikeFeatureKeyStatus) bool {
+	if status1 == nil || status2 == nil {
+		return status1 == status2
+	}
+	if status1.ValidUntil == nil || status2.ValidUntil == nil {
+		return status1.SerialNumber == status2.SerialNumber && status1.ValidUntil == status2.ValidUntil
+	}
+	// Compare instants, the time read back from the status is in the local timezone.
+	return status1.SerialNumber == status2.SerialNumber && status1.ValidUntil.Equal(status2.ValidUntil)
+}
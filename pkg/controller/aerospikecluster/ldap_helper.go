@@ -0,0 +1,70 @@
+package aerospikecluster
+
+import (
+	"strings"
+
+	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
+	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/utils"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// With access control LDAP the LDAP spec is rendered into the security.ldap config by the mutating webhook, and the
+// query user password and the CA certificate secrets are mounted in the pods at the files set in the config. External
+// users get their roles from their LDAP groups. The operator keeps using the internal admin user.
+
+const (
+	// ldapVolumePrefix is the prefix of the names of the LDAP secret volumes.
+	ldapVolumePrefix = "aerospike-ldap-"
+)
+
+// updateStatefulSetLDAPVolumes mounts the LDAP query user and CA certificate secrets in the aerospike server container.
+func updateStatefulSetLDAPVolumes(aeroCluster *aerospikev1alpha1.AerospikeCluster, st *appsv1.StatefulSet) {
+	// Remove the previous LDAP volumes.
+	var volumes []corev1.Volume
+	for _, volume := range st.Spec.Template.Spec.Volumes {
+		if !strings.HasPrefix(volume.Name, ldapVolumePrefix) {
+			volumes = append(volumes, volume)
+		}
+	}
+	container := &st.Spec.Template.Spec.Containers[0]
+	var mounts []corev1.VolumeMount
+	for _, mount := range container.VolumeMounts {
+		if !strings.HasPrefix(mount.Name, ldapVolumePrefix) {
+			mounts = append(mounts, mount)
+		}
+	}
+
+	if ldap := utils.GetLDAPSpec(&aeroCluster.Spec); ldap != nil {
+		secrets := []struct {
+			name       string
+			secretName string
+			mountPath  string
+		}{
+			{ldapVolumePrefix + "query-user", ldap.QueryUserSecretName, utils.LDAPQueryUserPath},
+			{ldapVolumePrefix + "tls-ca", ldap.TLSCASecretName, utils.LDAPTLSCAPath},
+		}
+
+		for _, secret := range secrets {
+			if secret.secretName == "" {
+				continue
+			}
+			volumes = append(volumes, corev1.Volume{
+				Name: secret.name,
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: secret.secretName,
+					},
+				},
+			})
+			mounts = append(mounts, corev1.VolumeMount{
+				Name:      secret.name,
+				MountPath: secret.mountPath,
+				ReadOnly:  true,
+			})
+		}
+	}
+
+	st.Spec.Template.Spec.Volumes = volumes
+	container.VolumeMounts = mounts
+}
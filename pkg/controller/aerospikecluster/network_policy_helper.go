@@ -0,0 +1,190 @@
+package aerospikecluster
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
+	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/utils"
+	log "github.com/inconshreveable/log15"
+	"github.com/operator-framework/operator-sdk/pkg/k8sutil"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// With kubernetesNetworkPolicy the operator creates a NetworkPolicy per rack, named after the rack StatefulSet, selecting
+// the rack pods. Heartbeat, fabric and info are allowed from the pods of the cluster, the service ports from the
+// serviceFrom sources, and the service and info ports from the operator. The NetworkPolicies of new racks are created
+// before their pods, and the ones of removed racks are deleted after their pods.
+
+const (
+	// namespaceNameLabel is the label with the namespace name set on the namespaces from Kubernetes 1.21.
+	namespaceNameLabel = "kubernetes.io/metadata.name"
+)
+
+// reconcileNetworkPolicies creates and updates the NetworkPolicies of the racks in the spec.
+func (r *ReconcileAerospikeCluster) reconcileNetworkPolicies(aeroCluster *aerospikev1alpha1.AerospikeCluster) error {
+	if aeroCluster.Spec.KubernetesNetworkPolicy == nil {
+		return nil
+	}
+
+	operatorPeer, err := getOperatorNetworkPolicyPeer(aeroCluster)
+	if err != nil {
+		return err
+	}
+
+	for _, rack := range aeroCluster.Spec.RackConfig.Racks {
+		if err := r.createOrUpdateNetworkPolicy(aeroCluster, getDesiredNetworkPolicy(aeroCluster, rack.ID, operatorPeer)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getOperatorNetworkPolicyPeer returns the source of the operator access, the pods in the operator namespace by default.
+func getOperatorNetworkPolicyPeer(aeroCluster *aerospikev1alpha1.AerospikeCluster) (networkingv1.NetworkPolicyPeer, error) {
+	if operatorFrom := aeroCluster.Spec.KubernetesNetworkPolicy.OperatorFrom; operatorFrom != nil {
+		return *operatorFrom, nil
+	}
+
+	operatorNs, err := k8sutil.GetOperatorNamespace()
+	if err != nil {
+		if err == k8sutil.ErrRunLocal {
+			return networkingv1.NetworkPolicyPeer{}, fmt.Errorf("Set kubernetesNetworkPolicy.operatorFrom when the operator runs outside the Kubernetes cluster")
+		}
+		return networkingv1.NetworkPolicyPeer{}, fmt.Errorf("Failed to get operator namespace: %v", err)
+	}
+
+	// All the pods in the policy namespace are selected without a namespace selector.
+	peer := networkingv1.NetworkPolicyPeer{PodSelector: &metav1.LabelSelector{}}
+	if operatorNs != aeroCluster.Namespace {
+		peer.NamespaceSelector = &metav1.LabelSelector{MatchLabels: map[string]string{namespaceNameLabel: operatorNs}}
+	}
+	return peer, nil
+}
+
+// getDesiredNetworkPolicy returns the NetworkPolicy of the rack pods.
+func getDesiredNetworkPolicy(aeroCluster *aerospikev1alpha1.AerospikeCluster, rackID int, operatorPeer networkingv1.NetworkPolicyPeer) *networkingv1.NetworkPolicy {
+	clusterPeer := networkingv1.NetworkPolicyPeer{
+		PodSelector: &metav1.LabelSelector{MatchLabels: utils.LabelsForAerospikeCluster(aeroCluster.Name)},
+	}
+
+	// The service ports are open to all sources without serviceFrom.
+	var serviceFrom []networkingv1.NetworkPolicyPeer
+	for _, peer := range aeroCluster.Spec.KubernetesNetworkPolicy.ServiceFrom {
+		serviceFrom = append(serviceFrom, *peer.DeepCopy())
+	}
+
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getNamespacedNameForStatefulSet(aeroCluster, rackID).Name,
+			Namespace: aeroCluster.Namespace,
+			Labels:    utils.LabelsForAerospikeClusterRack(aeroCluster.Name, rackID),
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: utils.LabelsForAerospikeClusterRack(aeroCluster.Name, rackID)},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{
+				{
+					Ports: networkPolicyPorts(utils.HeartbeatPort, utils.HeartbeatTLSPort, utils.FabricPort, utils.FabricTLSPort, utils.InfoPort),
+					From:  []networkingv1.NetworkPolicyPeer{clusterPeer},
+				},
+				{
+					Ports: networkPolicyPorts(utils.ServicePort, utils.ServiceTLSPort),
+					From:  serviceFrom,
+				},
+				{
+					Ports: networkPolicyPorts(utils.ServicePort, utils.ServiceTLSPort, utils.InfoPort),
+					From:  []networkingv1.NetworkPolicyPeer{operatorPeer},
+				},
+			},
+		},
+	}
+}
+
+// networkPolicyPorts returns the TCP NetworkPolicy ports. The protocol is set so that the spec compares equal to the
+// defaulted spec read back.
+func networkPolicyPorts(ports ...int) []networkingv1.NetworkPolicyPort {
+	var policyPorts []networkingv1.NetworkPolicyPort
+	for _, port := range ports {
+		protocol := corev1.ProtocolTCP
+		portValue := intstr.FromInt(port)
+		policyPorts = append(policyPorts, networkingv1.NetworkPolicyPort{Protocol: &protocol, Port: &portValue})
+	}
+	return policyPorts
+}
+
+// createOrUpdateNetworkPolicy creates the NetworkPolicy, or updates its spec if it has changed.
+func (r *ReconcileAerospikeCluster) createOrUpdateNetworkPolicy(aeroCluster *aerospikev1alpha1.AerospikeCluster, policy *networkingv1.NetworkPolicy) error {
+	logger := pkglog.New(log.Ctx{"AerospikeCluster": utils.ClusterNamespacedName(aeroCluster)})
+
+	found := &networkingv1.NetworkPolicy{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: policy.Name, Namespace: policy.Namespace}, found)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return fmt.Errorf("Failed to get NetworkPolicy %s: %v", policy.Name, err)
+		}
+
+		// Set AerospikeCluster instance as the owner and controller
+		if err := controllerutil.SetControllerReference(aeroCluster, policy, r.scheme); err != nil {
+			return err
+		}
+		if err := r.client.Create(context.TODO(), policy, createOption); err != nil {
+			return fmt.Errorf("Failed to create NetworkPolicy %s: %v", policy.Name, err)
+		}
+		logger.Info("Created NetworkPolicy", log.Ctx{"networkPolicy": policy.Name})
+		return nil
+	}
+
+	if reflect.DeepEqual(found.Spec, policy.Spec) {
+		return nil
+	}
+
+	found.Spec = policy.Spec
+	if err := r.client.Update(context.TODO(), found, updateOption); err != nil {
+		return fmt.Errorf("Failed to update NetworkPolicy %s: %v", policy.Name, err)
+	}
+	logger.Info("Updated NetworkPolicy", log.Ctx{"networkPolicy": policy.Name})
+	return nil
+}
+
+// deleteStaleNetworkPolicies deletes the NetworkPolicies of the racks no longer in the spec, or all of them when
+// kubernetesNetworkPolicy is removed.
+func (r *ReconcileAerospikeCluster) deleteStaleNetworkPolicies(aeroCluster *aerospikev1alpha1.AerospikeCluster) error {
+	logger := pkglog.New(log.Ctx{"AerospikeCluster": utils.ClusterNamespacedName(aeroCluster)})
+
+	if aeroCluster.Spec.KubernetesNetworkPolicy == nil && aeroCluster.Status.KubernetesNetworkPolicy == nil {
+		return nil
+	}
+
+	desired := map[string]bool{}
+	if aeroCluster.Spec.KubernetesNetworkPolicy != nil {
+		for _, rack := range aeroCluster.Spec.RackConfig.Racks {
+			desired[getNamespacedNameForStatefulSet(aeroCluster, rack.ID).Name] = true
+		}
+	}
+
+	policyList := &networkingv1.NetworkPolicyList{}
+	err := r.client.List(context.TODO(), policyList, client.InNamespace(aeroCluster.Namespace), client.MatchingLabels(utils.LabelsForAerospikeCluster(aeroCluster.Name)))
+	if err != nil {
+		return fmt.Errorf("Failed to list NetworkPolicies: %v", err)
+	}
+
+	for _, policy := range policyList.Items {
+		if desired[policy.Name] || !isOwnedBy(policy.OwnerReferences, aeroCluster) {
+			continue
+		}
+		if err := r.client.Delete(context.TODO(), &policy); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("Failed to delete NetworkPolicy %s: %v", policy.Name, err)
+		}
+		logger.Info("Deleted NetworkPolicy", log.Ctx{"networkPolicy": policy.Name})
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package aerospikecluster
+
+import (
+	"reflect"
+	"testing"
+
+	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetDesiredNetworkPolicy(t *testing.T) {
+	operatorPeer := networkingv1.NetworkPolicyPeer{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"name": "aerospike"}}}
+	servicePeer := networkingv1.NetworkPolicyPeer{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}}
+	aeroCluster := &aerospikev1alpha1.AerospikeCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "aerocluster", Namespace: "test"},
+		Spec: aerospikev1alpha1.AerospikeClusterSpec{
+			KubernetesNetworkPolicy: &aerospikev1alpha1.AerospikeKubernetesNetworkPolicySpec{
+				ServiceFrom:  []networkingv1.NetworkPolicyPeer{servicePeer},
+				OperatorFrom: &operatorPeer,
+			},
+		},
+	}
+
+	peer, err := getOperatorNetworkPolicyPeer(aeroCluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+	policy := getDesiredNetworkPolicy(aeroCluster, 2, peer)
+
+	if policy.Name != "aerocluster-2" || policy.Namespace != "test" {
+		t.Errorf("got NetworkPolicy %s/%s, want test/aerocluster-2", policy.Namespace, policy.Name)
+	}
+	wantSelector := map[string]string{"app": "aerospike-cluster", "aerospike.com/cr": "aerocluster", "aerospike.com/rack-id": "2"}
+	if !reflect.DeepEqual(policy.Spec.PodSelector.MatchLabels, wantSelector) {
+		t.Errorf("got pod selector %v, want %v", policy.Spec.PodSelector.MatchLabels, wantSelector)
+	}
+
+	wantRules := []struct {
+		ports []int
+		from  []networkingv1.NetworkPolicyPeer
+	}{
+		{
+			[]int{3002, 3012, 3001, 3011, 3003},
+			[]networkingv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "aerospike-cluster", "aerospike.com/cr": "aerocluster"}}}},
+		},
+		{[]int{3000, 4333}, []networkingv1.NetworkPolicyPeer{servicePeer}},
+		{[]int{3000, 4333, 3003}, []networkingv1.NetworkPolicyPeer{operatorPeer}},
+	}
+	if len(policy.Spec.Ingress) != len(wantRules) {
+		t.Fatalf("got %d ingress rules, want %d", len(policy.Spec.Ingress), len(wantRules))
+	}
+	for i, rule := range policy.Spec.Ingress {
+		var ports []int
+		for _, port := range rule.Ports {
+			ports = append(ports, port.Port.IntValue())
+		}
+		if !reflect.DeepEqual(ports, wantRules[i].ports) {
+			t.Errorf("rule %d: got ports %v, want %v", i, ports, wantRules[i].ports)
+		}
+		if !reflect.DeepEqual(rule.From, wantRules[i].from) {
+			t.Errorf("rule %d: got from %v, want %v", i, rule.From, wantRules[i].from)
+		}
+	}
+
+	// The service ports are open to all sources without serviceFrom.
+	aeroCluster.Spec.KubernetesNetworkPolicy.ServiceFrom = nil
+	policy = getDesiredNetworkPolicy(aeroCluster, 2, peer)
+	if policy.Spec.Ingress[1].From != nil {
+		t.Errorf("got service sources %v, want none", policy.Spec.Ingress[1].From)
+	}
+}
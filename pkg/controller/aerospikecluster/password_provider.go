@@ -0,0 +1,279 @@
+package aerospikecluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
+	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/utils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// User passwords are read from a secret in the cluster namespace, or from the password source of the user: a file in
+// the operator password file directory, a secret in another namespace that allows the cluster namespace, or an
+// operator password host. Passwords are read on each reconcile, so password sources other than secrets are picked up
+// on the next reconcile.
+
+const (
+	// passwordHTTPTimeout is the timeout of the requests to HTTP password sources.
+	passwordHTTPTimeout = 10 * time.Second
+
+	// passwordSizeMax is the maximum size of a password file or HTTP response.
+	passwordSizeMax = 64 * 1024
+)
+
+// PasswordProvider provides user passwords from the password source of the user, or the user secret in the cluster
+// namespace.
+type PasswordProvider struct {
+	// Provider for secrets in the cluster namespace.
+	secret FromSecretPasswordProvider
+
+	// Provider for secrets in other namespaces.
+	namespaceSecret FromNamespaceSecretPasswordProvider
+
+	// Provider for files.
+	file FromFilePasswordProvider
+
+	// Provider for HTTP endpoints.
+	http FromHTTPPasswordProvider
+}
+
+// Get returns the password for the username using userSpec.
+func (pp PasswordProvider) Get(username string, userSpec *aerospikev1alpha1.AerospikeUserSpec) (string, error) {
+	source := userSpec.PasswordSource
+	switch {
+	case source == nil:
+		return pp.secret.Get(username, userSpec)
+	case source.File != "":
+		return pp.file.Get(username, userSpec)
+	case source.Secret != nil:
+		return pp.namespaceSecret.Get(username, userSpec)
+	case source.HTTP != nil:
+		return pp.http.Get(username, userSpec)
+	}
+	return "", fmt.Errorf("No password source for user %s", username)
+}
+
+// FromSecretPasswordProvider provides user password from the secret provided in AerospikeUserSpec.
+type FromSecretPasswordProvider struct {
+	// Client to read secrets.
+	client *client.Client
+
+	// The secret namespace.
+	namespace string
+}
+
+// Get returns the password for the username using userSpec.
+func (pp FromSecretPasswordProvider) Get(username string, userSpec *aerospikev1alpha1.AerospikeUserSpec) (string, error) {
+	secret := &corev1.Secret{}
+	secretName := userSpec.SecretName
+	// Assuming secret is in same namespace
+	err := (*pp.client).Get(context.TODO(), types.NamespacedName{Name: secretName, Namespace: pp.namespace}, secret)
+	if err != nil {
+		return "", fmt.Errorf("Failed to get secret %s: %v", secretName, err)
+	}
+
+	passbyte, ok := secret.Data[utils.GetUserSecretKey(userSpec)]
+	if !ok {
+		return "", fmt.Errorf("Failed to get password from secret key %s. Please check your secret %s", utils.GetUserSecretKey(userSpec), secretName)
+	}
+	return string(passbyte), nil
+}
+
+// FromNamespaceSecretPasswordProvider provides user password from the password source secret in another namespace.
+type FromNamespaceSecretPasswordProvider struct {
+	// Reader to read secrets from the API server, since the namespace may not be watched.
+	reader client.Reader
+
+	// The cluster namespace, which has to be allowed by the secret.
+	namespace string
+}
+
+// Get returns the password for the username using the userSpec password source secret.
+func (pp FromNamespaceSecretPasswordProvider) Get(username string, userSpec *aerospikev1alpha1.AerospikeUserSpec) (string, error) {
+	source := userSpec.PasswordSource.Secret
+	secretName := utils.NamespacedName(source.Namespace, source.Name)
+
+	secret := &corev1.Secret{}
+	if err := pp.reader.Get(context.TODO(), types.NamespacedName{Name: source.Name, Namespace: source.Namespace}, secret); err != nil {
+		return "", fmt.Errorf("Failed to get secret %s: %v", secretName, err)
+	}
+
+	if source.Namespace != pp.namespace && !isNamespaceAllowed(secret.Annotations[utils.PasswordAllowedNamespacesAnnotation], pp.namespace) {
+		return "", fmt.Errorf("Secret %s does not allow namespace %s in its %s annotation", secretName, pp.namespace, utils.PasswordAllowedNamespacesAnnotation)
+	}
+
+	key := utils.GetPasswordSecretSourceKey(source)
+	passbyte, ok := secret.Data[key]
+	if !ok {
+		return "", fmt.Errorf("Failed to get password from secret key %s. Please check your secret %s", key, secretName)
+	}
+	return string(passbyte), nil
+}
+
+// isNamespaceAllowed indicates if the namespace is in the comma separated allowed namespaces.
+func isNamespaceAllowed(allowedNamespaces string, namespace string) bool {
+	for _, allowed := range strings.Split(allowedNamespaces, ",") {
+		if strings.TrimSpace(allowed) == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// FromFilePasswordProvider provides user password from the password source file in the operator pod.
+type FromFilePasswordProvider struct{}
+
+// Get returns the password for the username using the userSpec password source file.
+func (pp FromFilePasswordProvider) Get(username string, userSpec *aerospikev1alpha1.AerospikeUserSpec) (string, error) {
+	path := userSpec.PasswordSource.File
+	data, err := readPasswordSourceFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	return toPassword(data, fmt.Sprintf("password file %s", path))
+}
+
+// readPasswordSourceFile reads a file under the password file directory, including the targets of symlinks, of at most
+// passwordSizeMax bytes.
+func readPasswordSourceFile(path string) ([]byte, error) {
+	if !utils.IsPasswordFileAllowed(path) {
+		return nil, fmt.Errorf("File %s is not in the operator password file directory", path)
+	}
+	realPath, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read file %s: %v", path, err)
+	}
+	if !utils.IsPasswordFileAllowed(realPath) {
+		return nil, fmt.Errorf("File %s links outside the operator password file directory", path)
+	}
+
+	f, err := os.Open(realPath)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read file %s: %v", path, err)
+	}
+	defer f.Close()
+
+	data, err := ioutil.ReadAll(io.LimitReader(f, passwordSizeMax+1))
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read file %s: %v", path, err)
+	}
+	if len(data) > passwordSizeMax {
+		return nil, fmt.Errorf("File %s is larger than %d bytes", path, passwordSizeMax)
+	}
+	return data, nil
+}
+
+// FromHTTPPasswordProvider provides user password from the password source HTTP endpoint.
+type FromHTTPPasswordProvider struct {
+	// Client to send the requests.
+	client *http.Client
+}
+
+// Get returns the password for the username using the userSpec password source HTTP endpoint.
+func (pp FromHTTPPasswordProvider) Get(username string, userSpec *aerospikev1alpha1.AerospikeUserSpec) (string, error) {
+	source := userSpec.PasswordSource.HTTP
+
+	req, err := http.NewRequest(http.MethodGet, source.URL, nil)
+	if err != nil {
+		return "", fmt.Errorf("Invalid password URL %s: %v", source.URL, err)
+	}
+	if err := checkPasswordURL(req.URL); err != nil {
+		return "", err
+	}
+
+	if source.BearerTokenFile != "" {
+		token, err := readPasswordSourceFile(source.BearerTokenFile)
+		if err != nil {
+			return "", fmt.Errorf("Failed to read bearer token: %v", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+
+	httpClient := pp.client
+	if httpClient == nil {
+		httpClient = newPasswordHTTPClient()
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("Failed to get password from %s: %v", source.URL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Failed to get password from %s: %s", source.URL, resp.Status)
+	}
+
+	data, err := ioutil.ReadAll(http.MaxBytesReader(nil, resp.Body, passwordSizeMax))
+	if err != nil {
+		return "", fmt.Errorf("Failed to read password from %s: %v", source.URL, err)
+	}
+
+	if source.JSONKey != "" {
+		body := map[string]interface{}{}
+		if err := json.Unmarshal(data, &body); err != nil {
+			return "", fmt.Errorf("Failed to parse password response from %s: %v", source.URL, err)
+		}
+		password, ok := body[source.JSONKey].(string)
+		if !ok {
+			return "", fmt.Errorf("Password response from %s has no string key %s", source.URL, source.JSONKey)
+		}
+		data = []byte(password)
+	}
+
+	return toPassword(data, fmt.Sprintf("password from %s", source.URL))
+}
+
+// checkPasswordURL returns an error if the URL is not an http or https URL of a password host.
+func checkPasswordURL(passwordURL *url.URL) error {
+	if passwordURL.Scheme != "http" && passwordURL.Scheme != "https" {
+		return fmt.Errorf("Password URL %s is not an http or https URL", passwordURL)
+	}
+	if !utils.IsPasswordHostAllowed(passwordURL.Host) {
+		return fmt.Errorf("Password URL host %s is not an operator password host", passwordURL.Host)
+	}
+	return nil
+}
+
+// newPasswordHTTPClient returns the client of the password HTTP requests, following redirects to password hosts only.
+func newPasswordHTTPClient() *http.Client {
+	return &http.Client{
+		Timeout: passwordHTTPTimeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 10 {
+				return fmt.Errorf("Stopped after 10 redirects")
+			}
+			return checkPasswordURL(req.URL)
+		},
+	}
+}
+
+// toPassword returns the password in data without the trailing newline.
+func toPassword(data []byte, description string) (string, error) {
+	password := strings.TrimRight(string(data), "\r\n")
+	if password == "" {
+		return "", fmt.Errorf("Empty %s", description)
+	}
+	return password, nil
+}
+
+func (r *ReconcileAerospikeCluster) getPasswordProvider(aeroCluster *aerospikev1alpha1.AerospikeCluster) PasswordProvider {
+	return PasswordProvider{
+		secret:          FromSecretPasswordProvider{client: &r.client, namespace: aeroCluster.Namespace},
+		namespaceSecret: FromNamespaceSecretPasswordProvider{reader: r.apiReader, namespace: aeroCluster.Namespace},
+		http:            FromHTTPPasswordProvider{client: newPasswordHTTPClient()},
+	}
+}
@@ -0,0 +1,158 @@
+package aerospikecluster
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
+	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/utils"
+)
+
+func TestFromFilePasswordProvider(t *testing.T) {
+	dir, err := ioutil.TempDir("", "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	passwordFile := filepath.Join(dir, "password")
+	if err := ioutil.WriteFile(passwordFile, []byte("s3cret\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	emptyFile := filepath.Join(dir, "empty")
+	if err := ioutil.WriteFile(emptyFile, []byte("\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	largeFile := filepath.Join(dir, "large")
+	if err := ioutil.WriteFile(largeFile, make([]byte, passwordSizeMax+1), 0600); err != nil {
+		t.Fatal(err)
+	}
+	outsideFile := filepath.Join(os.TempDir(), filepath.Base(dir)+"-outside")
+	if err := ioutil.WriteFile(outsideFile, []byte("s3cret\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(outsideFile)
+	linkFile := filepath.Join(dir, "link")
+	if err := os.Symlink(outsideFile, linkFile); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := utils.InitPasswordSources(dir, ""); err != nil {
+		t.Fatal(err)
+	}
+	defer utils.InitPasswordSources("", "")
+
+	pp := PasswordProvider{}
+	userSpec := &aerospikev1alpha1.AerospikeUserSpec{
+		Name:           "app",
+		PasswordSource: &aerospikev1alpha1.AerospikePasswordSourceSpec{File: passwordFile},
+	}
+	password, err := pp.Get(userSpec.Name, userSpec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if password != "s3cret" {
+		t.Errorf("got password %q, want %q", password, "s3cret")
+	}
+
+	for _, file := range []string{emptyFile, largeFile, filepath.Join(dir, "missing"), outsideFile, linkFile, dir + "/../" + filepath.Base(outsideFile)} {
+		userSpec.PasswordSource.File = file
+		if _, err := pp.Get(userSpec.Name, userSpec); err == nil {
+			t.Errorf("%s: expected error", file)
+		}
+	}
+}
+
+func TestFromHTTPPasswordProvider(t *testing.T) {
+	dir, err := ioutil.TempDir("", "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tokenFile := filepath.Join(dir, "token")
+	if err := ioutil.WriteFile(tokenFile, []byte("t0ken\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer t0ken" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/plain":
+			w.Write([]byte("s3cret\n"))
+		case "/json":
+			w.Write([]byte(`{"password": "s3cret"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	otherServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("s3cret\n"))
+	}))
+	defer otherServer.Close()
+
+	redirectServer := httptest.NewServer(http.RedirectHandler(otherServer.URL+"/plain", http.StatusFound))
+	defer redirectServer.Close()
+
+	serverURL, _ := url.Parse(server.URL)
+	redirectURL, _ := url.Parse(redirectServer.URL)
+	if err := utils.InitPasswordSources(dir, serverURL.Host+","+redirectURL.Host); err != nil {
+		t.Fatal(err)
+	}
+	defer utils.InitPasswordSources("", "")
+
+	tests := []struct {
+		name    string
+		source  aerospikev1alpha1.AerospikePasswordHTTPSource
+		wantErr bool
+	}{
+		{"plain", aerospikev1alpha1.AerospikePasswordHTTPSource{URL: server.URL + "/plain", BearerTokenFile: tokenFile}, false},
+		{"json", aerospikev1alpha1.AerospikePasswordHTTPSource{URL: server.URL + "/json", JSONKey: "password", BearerTokenFile: tokenFile}, false},
+		{"missing json key", aerospikev1alpha1.AerospikePasswordHTTPSource{URL: server.URL + "/json", JSONKey: "pass", BearerTokenFile: tokenFile}, true},
+		{"not found", aerospikev1alpha1.AerospikePasswordHTTPSource{URL: server.URL + "/missing", BearerTokenFile: tokenFile}, true},
+		{"no token", aerospikev1alpha1.AerospikePasswordHTTPSource{URL: server.URL + "/plain"}, true},
+		{"host not allowed", aerospikev1alpha1.AerospikePasswordHTTPSource{URL: otherServer.URL + "/plain"}, true},
+		{"redirect to host not allowed", aerospikev1alpha1.AerospikePasswordHTTPSource{URL: redirectServer.URL}, true},
+		{"token file not allowed", aerospikev1alpha1.AerospikePasswordHTTPSource{URL: server.URL + "/plain", BearerTokenFile: "/var/run/secrets/kubernetes.io/serviceaccount/token"}, true},
+	}
+
+	pp := PasswordProvider{http: FromHTTPPasswordProvider{client: newPasswordHTTPClient()}}
+	for _, test := range tests {
+		source := test.source
+		userSpec := &aerospikev1alpha1.AerospikeUserSpec{
+			Name:           "app",
+			PasswordSource: &aerospikev1alpha1.AerospikePasswordSourceSpec{HTTP: &source},
+		}
+		password, err := pp.Get(userSpec.Name, userSpec)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("%s: expected error", test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+		} else if password != "s3cret" {
+			t.Errorf("%s: got password %q, want %q", test.name, password, "s3cret")
+		}
+	}
+}
+
+func TestIsNamespaceAllowed(t *testing.T) {
+	if !isNamespaceAllowed("dev, prod", "prod") {
+		t.Errorf("expected prod to be allowed")
+	}
+	if isNamespaceAllowed("dev,production", "prod") || isNamespaceAllowed("", "prod") {
+		t.Errorf("expected prod not to be allowed")
+	}
+}
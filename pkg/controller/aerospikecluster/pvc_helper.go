@@ -0,0 +1,410 @@
+package aerospikecluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/inconshreveable/log15"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
+	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/jsonpatch"
+	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/utils"
+)
+
+const (
+	// This label is added to pvc retained after its pod is removed from the cluster.
+	pvcRetainedLabelKey = "aerospike.com/pvc-retained"
+	// This annotation has the time after which a retained pvc is deleted.
+	pvcRetentionExpiryAnnotationKey = "aerospike.com/pvc-retention-expiry"
+)
+
+//------------------------------------------------------------------------------------
+// PVC retention
+//------------------------------------------------------------------------------------
+
+// getPVCRetentionPolicy returns the volume policy applicable to the pvc.
+func getPVCRetentionPolicy(storage *aerospikev1alpha1.AerospikeStorageSpec, pvc *corev1.PersistentVolumeClaim, path string) aerospikev1alpha1.AerospikePersistentVolumePolicySpec {
+	if v := getVolumeConfigForPVC(storage, path); v != nil {
+		return v.AerospikePersistentVolumePolicySpec
+	}
+
+	if pvc.Spec.VolumeMode != nil && *pvc.Spec.VolumeMode == corev1.PersistentVolumeBlock {
+		return storage.BlockVolumePolicy
+	}
+	return storage.FileSystemVolumePolicy
+}
+
+func isPVCRetained(pvc *corev1.PersistentVolumeClaim) bool {
+	_, ok := pvc.Labels[pvcRetainedLabelKey]
+	return ok
+}
+
+// retainPVC labels the pvc as retained and returns its retention status.
+func (r *ReconcileAerospikeCluster) retainPVC(aeroCluster *aerospikev1alpha1.AerospikeCluster, pvc corev1.PersistentVolumeClaim, path string, policy aerospikev1alpha1.AerospikePersistentVolumePolicySpec) (*aerospikev1alpha1.AerospikeRetainedPVCStatus, error) {
+	now := metav1.Now()
+	retainedStatus := &aerospikev1alpha1.AerospikeRetainedPVCStatus{
+		Path:          path,
+		RetainedSince: now,
+	}
+
+	podName, err := getPodNameForPVC(&pvc, path)
+	if err != nil {
+		return nil, err
+	}
+	rackID, err := utils.GetRackIDFromPodName(podName)
+	if err != nil {
+		return nil, err
+	}
+	retainedStatus.PodName = podName
+	retainedStatus.RackID = *rackID
+
+	if pvc.Labels == nil {
+		pvc.Labels = map[string]string{}
+	}
+	pvc.Labels[pvcRetainedLabelKey] = "true"
+
+	if expiryTime := getPVCRetentionExpiryTime(policy, now); expiryTime != nil {
+		retainedStatus.ExpiryTime = expiryTime
+
+		if pvc.Annotations == nil {
+			pvc.Annotations = map[string]string{}
+		}
+		pvc.Annotations[pvcRetentionExpiryAnnotationKey] = expiryTime.UTC().Format(time.RFC3339)
+	}
+
+	if err := r.client.Update(context.TODO(), &pvc, updateOption); err != nil {
+		return nil, fmt.Errorf("Could not retain pvc %s: %v", pvc.Name, err)
+	}
+	return retainedStatus, nil
+}
+
+// getPVCRetentionExpiryTime returns the time a pvc retained now expires, nil if it does not expire.
+func getPVCRetentionExpiryTime(policy aerospikev1alpha1.AerospikePersistentVolumePolicySpec, now metav1.Time) *metav1.Time {
+	if policy.RetentionPolicy != aerospikev1alpha1.AerospikeVolumeRetentionPolicyRetainForDuration {
+		return nil
+	}
+	expiryTime := metav1.NewTime(now.Add(policy.RetentionDuration.Duration))
+	return &expiryTime
+}
+
+// isPVCDeletedWithCluster indicates if the pvc is deleted when the cluster is deleted. PVCs retained for a duration are
+// deleted, since they are cleaned up only while the cluster exists. Retained PVCs are kept.
+func isPVCDeletedWithCluster(pvc *corev1.PersistentVolumeClaim, policy aerospikev1alpha1.AerospikePersistentVolumePolicySpec) bool {
+	if policy.RetentionPolicy != aerospikev1alpha1.AerospikeVolumeRetentionPolicyRetain {
+		return true
+	}
+
+	// Retained for a duration before the policy changed.
+	_, ok := pvc.Annotations[pvcRetentionExpiryAnnotationKey]
+	return ok
+}
+
+// reattachRetainedPVCs removes the retained label from PVCs of pods to be added back to the cluster.
+//
+// The statefulset reuses the PVCs as is. The retained PVC status is removed only after the pods are up, so that the
+// init container skips initializing the reattached volumes.
+func (r *ReconcileAerospikeCluster) reattachRetainedPVCs(aeroCluster *aerospikev1alpha1.AerospikeCluster, podNames []string, rackID int) error {
+	logger := pkglog.New(log.Ctx{"AerospikeCluster": utils.ClusterNamespacedName(aeroCluster)})
+
+	pvcItems, err := r.getPodsPVCList(aeroCluster, podNames, rackID)
+	if err != nil {
+		return fmt.Errorf("Could not find pvc for pods %v: %v", podNames, err)
+	}
+
+	for _, pvc := range pvcItems {
+		if !isPVCRetained(&pvc) {
+			continue
+		}
+
+		if utils.IsPVCTerminating(&pvc) {
+			return fmt.Errorf("Retained pvc %s is terminating", pvc.Name)
+		}
+
+		delete(pvc.Labels, pvcRetainedLabelKey)
+		delete(pvc.Annotations, pvcRetentionExpiryAnnotationKey)
+		if err := r.client.Update(context.TODO(), &pvc, updateOption); err != nil {
+			return fmt.Errorf("Could not reattach pvc %s: %v", pvc.Name, err)
+		}
+		logger.Info("Retained PVC reattached", log.Ctx{"PVC": pvc.Name})
+	}
+	return nil
+}
+
+// cleanupRetainedPVCs deletes retained PVCs whose retention duration has expired and drops stale retained PVC status.
+// Returns the time till the next retained PVC expires, zero if none of the PVCs expire.
+func (r *ReconcileAerospikeCluster) cleanupRetainedPVCs(aeroCluster *aerospikev1alpha1.AerospikeCluster) (time.Duration, error) {
+	logger := pkglog.New(log.Ctx{"AerospikeCluster": utils.ClusterNamespacedName(aeroCluster)})
+
+	pvcItems, err := r.getClusterPVCList(aeroCluster)
+	if err != nil {
+		return 0, fmt.Errorf("Could not find pvc for cluster: %v", err)
+	}
+
+	expiredPVCs, pvcs, nextExpiry := getExpiredRetainedPVCs(logger, pvcItems, time.Now())
+	for _, pvc := range expiredPVCs {
+		if err := r.client.Delete(context.TODO(), &pvc); err != nil {
+			return 0, fmt.Errorf("Could not delete expired pvc %s: %v", pvc.Name, err)
+		}
+		logger.Info("Retained PVC expired and removed", log.Ctx{"PVC": pvc.Name, "expiry": pvc.Annotations[pvcRetentionExpiryAnnotationKey]})
+	}
+
+	staleStatus := []string{}
+	for pvcName, retainedStatus := range aeroCluster.Status.RetainedPVCs {
+		pvc, ok := pvcs[pvcName]
+		if !ok {
+			staleStatus = append(staleStatus, pvcName)
+			continue
+		}
+
+		// Pod status is added back only after the reattached pod is initialized.
+		if _, podInitialized := aeroCluster.Status.Pods[retainedStatus.PodName]; !isPVCRetained(&pvc) && podInitialized {
+			staleStatus = append(staleStatus, pvcName)
+		}
+	}
+
+	if err := r.removeRetainedPVCStatus(aeroCluster, staleStatus); err != nil {
+		return 0, err
+	}
+	return nextExpiry, nil
+}
+
+// getExpiredRetainedPVCs splits the PVCs into the retained PVCs whose retention duration has expired and the others,
+// keyed by name. Returns also the time till the next retained PVC expires, zero if none of the PVCs expire.
+func getExpiredRetainedPVCs(logger log.Logger, pvcItems []corev1.PersistentVolumeClaim, now time.Time) ([]corev1.PersistentVolumeClaim, map[string]corev1.PersistentVolumeClaim, time.Duration) {
+	var nextExpiry time.Duration
+	expiredPVCs := []corev1.PersistentVolumeClaim{}
+	pvcs := map[string]corev1.PersistentVolumeClaim{}
+
+	for _, pvc := range pvcItems {
+		if !isPVCRetained(&pvc) || utils.IsPVCTerminating(&pvc) {
+			pvcs[pvc.Name] = pvc
+			continue
+		}
+
+		expiry, ok := pvc.Annotations[pvcRetentionExpiryAnnotationKey]
+		if !ok {
+			pvcs[pvc.Name] = pvc
+			continue
+		}
+
+		expiryTime, err := time.Parse(time.RFC3339, expiry)
+		if err != nil {
+			logger.Error("Invalid PVC retention expiry. Retaining PVC", log.Ctx{"PVC": pvc.Name, "expiry": expiry, "err": err})
+			pvcs[pvc.Name] = pvc
+			continue
+		}
+
+		if remaining := expiryTime.Sub(now); remaining > 0 {
+			if nextExpiry == 0 || remaining < nextExpiry {
+				nextExpiry = remaining
+			}
+			pvcs[pvc.Name] = pvc
+			continue
+		}
+
+		expiredPVCs = append(expiredPVCs, pvc)
+	}
+
+	return expiredPVCs, pvcs, nextExpiry
+}
+
+// addRetainedPVCStatus adds retained PVCs to the cluster status.
+func (r *ReconcileAerospikeCluster) addRetainedPVCStatus(aeroCluster *aerospikev1alpha1.AerospikeCluster, retainedPVCs map[string]aerospikev1alpha1.AerospikeRetainedPVCStatus) error {
+	if len(retainedPVCs) == 0 {
+		return nil
+	}
+
+	patches := []jsonpatch.JsonPatchOperation{}
+
+	if aeroCluster.Status.RetainedPVCs == nil {
+		patches = append(patches, jsonpatch.JsonPatchOperation{
+			Operation: "add",
+			Path:      "/status/retainedPVCs",
+			Value:     retainedPVCs,
+		})
+	} else {
+		for pvcName, retainedStatus := range retainedPVCs {
+			patches = append(patches, jsonpatch.JsonPatchOperation{
+				Operation: "add",
+				Path:      "/status/retainedPVCs/" + pvcName,
+				Value:     retainedStatus,
+			})
+		}
+	}
+
+	if err := r.patchRetainedPVCStatus(aeroCluster, patches); err != nil {
+		return err
+	}
+
+	if aeroCluster.Status.RetainedPVCs == nil {
+		aeroCluster.Status.RetainedPVCs = map[string]aerospikev1alpha1.AerospikeRetainedPVCStatus{}
+	}
+	for pvcName, retainedStatus := range retainedPVCs {
+		aeroCluster.Status.RetainedPVCs[pvcName] = retainedStatus
+	}
+	return nil
+}
+
+// removeRetainedPVCStatusForPods removes the retained PVC status of the given pods.
+func (r *ReconcileAerospikeCluster) removeRetainedPVCStatusForPods(aeroCluster *aerospikev1alpha1.AerospikeCluster, podNames []string) error {
+	pvcNames := []string{}
+	for pvcName := range getRetainedPVCNamesForPods(aeroCluster.Status.RetainedPVCs, podNames) {
+		pvcNames = append(pvcNames, pvcName)
+	}
+	return r.removeRetainedPVCStatus(aeroCluster, pvcNames)
+}
+
+// getRetainedPVCNamesForPods returns the names of the PVCs in the retained PVC status which belong to the given pods.
+func getRetainedPVCNamesForPods(retainedPVCs map[string]aerospikev1alpha1.AerospikeRetainedPVCStatus, podNames []string) map[string]bool {
+	pvcNames := map[string]bool{}
+	for pvcName, retainedStatus := range retainedPVCs {
+		if utils.ContainsString(podNames, retainedStatus.PodName) {
+			pvcNames[pvcName] = true
+		}
+	}
+	return pvcNames
+}
+
+// removeRetainedPVCStatus removes pvcNames from the cluster's retained PVC status.
+func (r *ReconcileAerospikeCluster) removeRetainedPVCStatus(aeroCluster *aerospikev1alpha1.AerospikeCluster, pvcNames []string) error {
+	if len(pvcNames) == 0 {
+		return nil
+	}
+
+	patches := []jsonpatch.JsonPatchOperation{}
+	for _, pvcName := range pvcNames {
+		patches = append(patches, jsonpatch.JsonPatchOperation{
+			Operation: "remove",
+			Path:      "/status/retainedPVCs/" + pvcName,
+		})
+	}
+
+	if err := r.patchRetainedPVCStatus(aeroCluster, patches); err != nil {
+		return err
+	}
+
+	for _, pvcName := range pvcNames {
+		delete(aeroCluster.Status.RetainedPVCs, pvcName)
+	}
+	return nil
+}
+
+func (r *ReconcileAerospikeCluster) patchRetainedPVCStatus(aeroCluster *aerospikev1alpha1.AerospikeCluster, patches []jsonpatch.JsonPatchOperation) error {
+	jsonpatchJSON, err := json.Marshal(patches)
+	if err != nil {
+		return fmt.Errorf("Error marshalling json patch: %v", err)
+	}
+
+	constantPatch := client.ConstantPatch(types.JSONPatchType, jsonpatchJSON)
+	if err = r.client.Status().Patch(context.TODO(), aeroCluster, constantPatch, client.FieldOwner(patchFieldOwner)); err != nil {
+		return fmt.Errorf("Error updating retained pvc status: %v", err)
+	}
+	return nil
+}
+
+// getPodNameForPVC returns the name of the statefulset pod the pvc belongs to.
+// StatefulSet pvc names are of the form <claim-name>-<pod-name>.
+func getPodNameForPVC(pvc *corev1.PersistentVolumeClaim, path string) (string, error) {
+	claimName, err := getPVCName(path)
+	if err != nil {
+		return "", err
+	}
+
+	if !strings.HasPrefix(pvc.Name, claimName+"-") {
+		return "", fmt.Errorf("PVC %s does not belong to volume %s", pvc.Name, path)
+	}
+	return strings.TrimPrefix(pvc.Name, claimName+"-"), nil
+}
+
+//------------------------------------------------------------------------------------
+// Restore from cluster snapshot
+//------------------------------------------------------------------------------------
+
+// restorePVCsFromSnapshot creates the PVCs of a new cluster from the volume snapshots of a cluster snapshot.
+//
+// PVCs are matched to volume snapshots by rack ID, pod ordinal and storage path. The statefulsets adopt the PVCs since
+// they have the claim template names. The restored PVCs are added to the retained PVC status so that the init container
+// does not initialize them.
+func (r *ReconcileAerospikeCluster) restorePVCsFromSnapshot(aeroCluster *aerospikev1alpha1.AerospikeCluster) error {
+	logger := pkglog.New(log.Ctx{"AerospikeCluster": utils.ClusterNamespacedName(aeroCluster)})
+	logger.Info("Restore PVCs from cluster snapshot", log.Ctx{"snapshot": aeroCluster.Spec.RestoreFromSnapshot})
+
+	snapshot := &aerospikev1alpha1.AerospikeClusterSnapshot{}
+	snapshotName := types.NamespacedName{Name: aeroCluster.Spec.RestoreFromSnapshot, Namespace: aeroCluster.Namespace}
+	if err := r.client.Get(context.TODO(), snapshotName, snapshot); err != nil {
+		return fmt.Errorf("Failed to get AerospikeClusterSnapshot %s: %v", snapshotName, err)
+	}
+	if snapshot.Status.Phase != aerospikev1alpha1.AerospikeClusterSnapshotCompleted {
+		return fmt.Errorf("AerospikeClusterSnapshot %s is not completed, phase %q", snapshotName, snapshot.Status.Phase)
+	}
+
+	now := metav1.Now()
+	restoredPVCs := map[string]aerospikev1alpha1.AerospikeRetainedPVCStatus{}
+
+	for _, rackState := range getNewRackStateList(aeroCluster) {
+		stsName := getNamespacedNameForStatefulSet(aeroCluster, rackState.Rack.ID)
+
+		for _, volume := range rackState.Rack.Storage.Volumes {
+			var volumeMode corev1.PersistentVolumeMode
+			switch volume.VolumeMode {
+			case aerospikev1alpha1.AerospikeVolumeModeBlock:
+				volumeMode = corev1.PersistentVolumeBlock
+			case aerospikev1alpha1.AerospikeVolumeModeFilesystem:
+				volumeMode = corev1.PersistentVolumeFilesystem
+			default:
+				// No volume claims for pod volumes.
+				continue
+			}
+
+			claimName, err := getPVCName(volume.Path)
+			if err != nil {
+				return fmt.Errorf("Failed to create ripemd hash for pvc name from volume.path %s", volume.Path)
+			}
+
+			for ordinal := 0; ordinal < rackState.Size; ordinal++ {
+				volumeSnapshot := snapshot.Status.GetVolumeSnapshot(rackState.Rack.ID, fmt.Sprint(ordinal), volume.Path)
+				if volumeSnapshot == nil {
+					logger.Info("No volume snapshot found. Volume will be initialized", log.Ctx{"rackID": rackState.Rack.ID, "ordinal": ordinal, "path": volume.Path})
+					continue
+				}
+
+				podName := getStatefulSetPodName(stsName.Name, int32(ordinal))
+				pvc := newPVCForVolume(aeroCluster, claimName+"-"+podName, volume, volumeMode)
+				if pvc.Labels == nil {
+					pvc.Labels = map[string]string{}
+				}
+				for key, value := range utils.LabelsForAerospikeClusterRack(aeroCluster.Name, rackState.Rack.ID) {
+					pvc.Labels[key] = value
+				}
+				apiGroup := volumeSnapshotAPIGroup
+				pvc.Spec.DataSource = &corev1.TypedLocalObjectReference{
+					APIGroup: &apiGroup,
+					Kind:     volumeSnapshotKind,
+					Name:     volumeSnapshot.Name,
+				}
+
+				if err := r.client.Create(context.TODO(), &pvc, createOption); err != nil && !errors.IsAlreadyExists(err) {
+					return fmt.Errorf("Failed to restore pvc %s from VolumeSnapshot %s: %v", pvc.Name, volumeSnapshot.Name, err)
+				}
+				logger.Info("Restored PVC from volume snapshot", log.Ctx{"PVC": pvc.Name, "VolumeSnapshot": volumeSnapshot.Name})
+
+				restoredPVCs[pvc.Name] = aerospikev1alpha1.AerospikeRetainedPVCStatus{
+					PodName:       podName,
+					RackID:        rackState.Rack.ID,
+					Path:          volume.Path,
+					RetainedSince: now,
+				}
+			}
+		}
+	}
+
+	return r.addRetainedPVCStatus(aeroCluster, restoredPVCs)
+}
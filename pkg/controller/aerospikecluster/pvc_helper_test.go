@@ -0,0 +1,117 @@
+package aerospikecluster
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetRetainedPVCNamesForPods(t *testing.T) {
+	retainedPVCs := map[string]aerospikev1alpha1.AerospikeRetainedPVCStatus{
+		"ns-aerocluster-1-0":   {PodName: "aerocluster-1-0", RackID: 1, Path: "/opt/aerospike/data"},
+		"ns-aerocluster-1-1":   {PodName: "aerocluster-1-1", RackID: 1, Path: "/opt/aerospike/data"},
+		"work-aerocluster-1-1": {PodName: "aerocluster-1-1", RackID: 1, Path: "/opt/aerospike/work"},
+		"ns-aerocluster-2-0":   {PodName: "aerocluster-2-0", RackID: 2, Path: "/opt/aerospike/data"},
+	}
+
+	got := getRetainedPVCNamesForPods(retainedPVCs, []string{"aerocluster-1-1", "aerocluster-1-2"})
+	want := map[string]bool{"ns-aerocluster-1-1": true, "work-aerocluster-1-1": true}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got PVCs %v, want %v", got, want)
+	}
+
+	if got := getRetainedPVCNamesForPods(nil, []string{"aerocluster-1-0"}); len(got) != 0 {
+		t.Errorf("got PVCs %v without retained PVC status", got)
+	}
+}
+
+func TestGetPVCRetentionExpiryTime(t *testing.T) {
+	now := metav1.NewTime(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		policy aerospikev1alpha1.AerospikeVolumeRetentionPolicy
+		want   *metav1.Time
+	}{
+		{aerospikev1alpha1.AerospikeVolumeRetentionPolicyRetain, nil},
+		{aerospikev1alpha1.AerospikeVolumeRetentionPolicyDelete, nil},
+		{aerospikev1alpha1.AerospikeVolumeRetentionPolicyRetainForDuration, &metav1.Time{Time: now.Add(24 * time.Hour)}},
+	}
+
+	for _, test := range tests {
+		policy := aerospikev1alpha1.AerospikePersistentVolumePolicySpec{RetentionPolicy: test.policy, RetentionDuration: metav1.Duration{Duration: 24 * time.Hour}}
+		if got := getPVCRetentionExpiryTime(policy, now); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got expiry %v, want %v", test.policy, got, test.want)
+		}
+	}
+}
+
+func TestGetExpiredRetainedPVCs(t *testing.T) {
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	newPVC := func(name string, retained bool, expiry string) corev1.PersistentVolumeClaim {
+		pvc := corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{}, Annotations: map[string]string{}}}
+		if retained {
+			pvc.Labels[pvcRetainedLabelKey] = "true"
+		}
+		if expiry != "" {
+			pvc.Annotations[pvcRetentionExpiryAnnotationKey] = expiry
+		}
+		return pvc
+	}
+
+	pvcItems := []corev1.PersistentVolumeClaim{
+		newPVC("attached", false, ""),
+		newPVC("retained", true, ""),
+		newPVC("expired", true, "2020-12-31T00:00:00Z"),
+		newPVC("expiring", true, "2021-01-01T02:00:00Z"),
+		newPVC("expiring-soon", true, "2021-01-01T01:00:00Z"),
+		newPVC("invalid", true, "tomorrow"),
+	}
+
+	expired, kept, nextExpiry := getExpiredRetainedPVCs(pkglog, pvcItems, now)
+
+	if len(expired) != 1 || expired[0].Name != "expired" {
+		t.Errorf("got expired PVCs %v, want [expired]", expired)
+	}
+
+	for _, name := range []string{"attached", "retained", "expiring", "expiring-soon", "invalid"} {
+		if _, ok := kept[name]; !ok {
+			t.Errorf("got PVC %s not kept", name)
+		}
+	}
+
+	if nextExpiry != time.Hour {
+		t.Errorf("got next expiry %v, want %v", nextExpiry, time.Hour)
+	}
+
+	if _, _, nextExpiry := getExpiredRetainedPVCs(pkglog, pvcItems[:2], now); nextExpiry != 0 {
+		t.Errorf("got next expiry %v without expiring PVCs", nextExpiry)
+	}
+}
+
+func TestIsPVCDeletedWithCluster(t *testing.T) {
+	retainedForDuration := corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{pvcRetentionExpiryAnnotationKey: "2021-01-01T00:00:00Z"}}}
+
+	tests := []struct {
+		name   string
+		pvc    corev1.PersistentVolumeClaim
+		policy aerospikev1alpha1.AerospikeVolumeRetentionPolicy
+		want   bool
+	}{
+		{"delete", corev1.PersistentVolumeClaim{}, aerospikev1alpha1.AerospikeVolumeRetentionPolicyDelete, true},
+		{"retain", corev1.PersistentVolumeClaim{}, aerospikev1alpha1.AerospikeVolumeRetentionPolicyRetain, false},
+		{"retain for duration", corev1.PersistentVolumeClaim{}, aerospikev1alpha1.AerospikeVolumeRetentionPolicyRetainForDuration, true},
+		{"retained for duration before retain", retainedForDuration, aerospikev1alpha1.AerospikeVolumeRetentionPolicyRetain, true},
+	}
+
+	for _, test := range tests {
+		policy := aerospikev1alpha1.AerospikePersistentVolumePolicySpec{RetentionPolicy: test.policy}
+		if got := isPVCDeletedWithCluster(&test.pvc, policy); got != test.want {
+			t.Errorf("%s: got deleted %v, want %v", test.name, got, test.want)
+		}
+	}
+}
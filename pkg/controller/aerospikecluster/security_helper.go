@@ -0,0 +1,564 @@
+package aerospikecluster
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
+	accessControl "github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/asconfig"
+	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/configmap"
+	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/jsonpatch"
+	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/utils"
+	"github.com/aerospike/aerospike-management-lib/deployment"
+	as "github.com/ashishshinde/aerospike-client-go"
+	log "github.com/inconshreveable/log15"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// Security is enabled or disabled with a rolling restart. While auth-enabled and auth-disabled nodes co-exist the
+// cluster wide info calls use the admin credentials of the secured nodes, which the nodes without security ignore, and
+// single node calls use a client policy for the node. The access control is set up as soon as the first secured nodes
+// are up, so that the admin password and the users are in place before the rest of the cluster is secured.
+
+const (
+	// adminBootstrapPasswordLength is the number of random bytes of the admin bootstrap password, hex encoded in the
+	// secret.
+	adminBootstrapPasswordLength = 24
+
+	// adminSecurityMetadataVolumeName is the name of the volume of the security metadata seed in the admin secret.
+	adminSecurityMetadataVolumeName = "admin-security-metadata"
+
+	// smdVolumeName is the name of the system metadata directory volume of pods without a work directory storage
+	// volume.
+	smdVolumeName = "aerospike-smd"
+)
+
+// getSecurityState returns whether security is enabled in the spec and in the status. They differ while security is
+// being enabled or disabled.
+func getSecurityState(aeroCluster *aerospikev1alpha1.AerospikeCluster) (bool, bool, error) {
+	desired, err := utils.IsSecurityEnabled(aeroCluster.Spec.AerospikeConfig)
+	if err != nil {
+		return false, false, fmt.Errorf("Failed to get cluster security status: %v", err)
+	}
+
+	// AerospikeConfig nil means status not updated yet
+	if aeroCluster.Status.AerospikeConfig == nil {
+		return desired, desired, nil
+	}
+	current, err := utils.IsSecurityEnabled(aeroCluster.Status.AerospikeConfig)
+	if err != nil {
+		return false, false, fmt.Errorf("Failed to get cluster security status: %v", err)
+	}
+	return desired, current, nil
+}
+
+// isPodSecurityEnabled returns true if the pod runs with security enabled. While security is being enabled or disabled
+// the pod runs the desired config if its config hash matches the desired config of its rack, else the previous config.
+func (r *ReconcileAerospikeCluster) isPodSecurityEnabled(aeroCluster *aerospikev1alpha1.AerospikeCluster, pod *corev1.Pod) (bool, error) {
+	desired, current, err := getSecurityState(aeroCluster)
+	if err != nil || desired == current {
+		return desired, err
+	}
+
+	rackID, err := utils.GetRackIDFromPodName(pod.Name)
+	if err != nil {
+		return false, err
+	}
+	for _, rack := range aeroCluster.Spec.RackConfig.Racks {
+		if rack.ID != *rackID {
+			continue
+		}
+		confTemp, err := configmap.BuildConfigTemplate(aeroCluster, rack)
+		if err != nil {
+			return false, fmt.Errorf("Failed to build config template: %v", err)
+		}
+		confHash, err := utils.GetHash(confTemp)
+		if err != nil {
+			return false, err
+		}
+		if aeroCluster.Status.Pods[pod.Name].AerospikeConfigHash == confHash {
+			return desired, nil
+		}
+	}
+	return current, nil
+}
+
+// getClientPolicyForPod returns the client policy for info calls to a single pod. Pods without security get no
+// credentials while security is being enabled or disabled.
+func (r *ReconcileAerospikeCluster) getClientPolicyForPod(aeroCluster *aerospikev1alpha1.AerospikeCluster, pod *corev1.Pod) *as.ClientPolicy {
+	logger := pkglog.New(log.Ctx{"AerospikeCluster": utils.ClusterNamespacedName(aeroCluster)})
+
+	policy := r.getClientPolicy(aeroCluster)
+	enabled, err := r.isPodSecurityEnabled(aeroCluster, pod)
+	if err != nil {
+		logger.Error("Failed to get pod security status. Using cluster clientPolicy", log.Ctx{"podName": pod.Name, "err": err})
+		return policy
+	}
+	if !enabled {
+		policy.User = ""
+		policy.Password = ""
+	}
+	return policy
+}
+
+// reconcileAdminSecret creates the admin secret of the operator with a random admin password before the first secured
+// pod starts. The secret has the security metadata seeding new nodes with its password, so that the nodes never have
+// the default admin password. The password is used till access control is set up. The secret is then updated to the
+// admin user password each time the operator changes it, so that the cluster is accessed with the current password
+// while the admin user secret is rotated.
+func (r *ReconcileAerospikeCluster) reconcileAdminSecret(aeroCluster *aerospikev1alpha1.AerospikeCluster) error {
+	logger := pkglog.New(log.Ctx{"AerospikeCluster": utils.ClusterNamespacedName(aeroCluster)})
+
+	desired, _, err := getSecurityState(aeroCluster)
+	if err != nil {
+		return err
+	}
+	if !desired && aeroCluster.Status.AerospikeAccessControl == nil {
+		return nil
+	}
+
+	secretName := utils.AdminSecretName(aeroCluster)
+	secret := &corev1.Secret{}
+	err = r.client.Get(context.TODO(), types.NamespacedName{Name: secretName, Namespace: aeroCluster.Namespace}, secret)
+	if err == nil {
+		if err := r.seedAdminSecret(aeroCluster, secret); err != nil {
+			return err
+		}
+		if _, ok := secret.Data[utils.AdminSecretPendingPasswordKey]; !ok {
+			return nil
+		}
+		// An admin password change did not complete.
+		return r.resolvePendingAdminPassword(aeroCluster, secret)
+	}
+	if !errors.IsNotFound(err) {
+		return fmt.Errorf("Failed to get secret %s: %v", secretName, err)
+	}
+
+	var password string
+	if aeroCluster.Status.AerospikeAccessControl == nil {
+		randomPassword := make([]byte, adminBootstrapPasswordLength)
+		if _, err := rand.Read(randomPassword); err != nil {
+			return fmt.Errorf("Failed to generate admin bootstrap password: %v", err)
+		}
+		password = hex.EncodeToString(randomPassword)
+	} else {
+		// Access control was set up before the operator kept the admin password.
+		adminUserSpec, err := accessControl.GetAdminUserSpec(&aeroCluster.Status.AerospikeClusterSpec)
+		if err != nil {
+			return err
+		}
+		if password, err = r.getPasswordProvider(aeroCluster).Get(adminUserSpec.Name, adminUserSpec); err != nil {
+			return err
+		}
+	}
+
+	secret = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretName,
+			Namespace: aeroCluster.Namespace,
+			Labels:    utils.LabelsForAerospikeCluster(aeroCluster.Name),
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{utils.DefaultUserSecretKey: []byte(password)},
+	}
+	if err := setAdminSecurityMetadataData(secret); err != nil {
+		return err
+	}
+	// Set AerospikeCluster instance as the owner and controller
+	controllerutil.SetControllerReference(aeroCluster, secret, r.scheme)
+
+	if err := r.client.Create(context.TODO(), secret, createOption); err != nil {
+		return fmt.Errorf("Failed to create secret %s: %v", secretName, err)
+	}
+	logger.Info("Created admin secret", log.Ctx{"secret": secretName})
+	return nil
+}
+
+// The admin password is changed on the cluster after it is kept as the pending password in the admin secret, and the
+// secret password is updated once the change is done. A pending password left by an access control reconcile which
+// did not complete is resolved on the next reconcile, by logging in with the secret password, the pending password and
+// the admin user password in turn.
+
+// setPendingAdminPassword keeps the admin user password in the admin secret as the pending password, before access
+// control changes it on the cluster.
+func (r *ReconcileAerospikeCluster) setPendingAdminPassword(aeroCluster *aerospikev1alpha1.AerospikeCluster) error {
+	password, err := r.getAdminUserPassword(aeroCluster)
+	if err != nil {
+		return err
+	}
+	secret, err := r.getAdminSecret(aeroCluster)
+	if err != nil {
+		return err
+	}
+	if !setPendingAdminPasswordData(secret, password) {
+		return nil
+	}
+	if err := r.client.Update(context.TODO(), secret, updateOption); err != nil {
+		return fmt.Errorf("Failed to update secret %s: %v", secret.Name, err)
+	}
+	pkglog.Info("Set pending admin password in admin secret", log.Ctx{"AerospikeCluster": utils.ClusterNamespacedName(aeroCluster), "secret": secret.Name})
+	return nil
+}
+
+// updateAdminSecret updates the admin secret of the operator to the admin user password set on the cluster.
+func (r *ReconcileAerospikeCluster) updateAdminSecret(aeroCluster *aerospikev1alpha1.AerospikeCluster) error {
+	password, err := r.getAdminUserPassword(aeroCluster)
+	if err != nil {
+		return err
+	}
+	secret, err := r.getAdminSecret(aeroCluster)
+	if err != nil {
+		return err
+	}
+	if !setAdminPasswordData(secret, password) {
+		return nil
+	}
+	if err := setAdminSecurityMetadataData(secret); err != nil {
+		return err
+	}
+	if err := r.client.Update(context.TODO(), secret, updateOption); err != nil {
+		return fmt.Errorf("Failed to update secret %s: %v", secret.Name, err)
+	}
+	pkglog.Info("Updated admin secret", log.Ctx{"AerospikeCluster": utils.ClusterNamespacedName(aeroCluster), "secret": secret.Name})
+	return nil
+}
+
+// resolvePendingAdminPassword updates the admin secret with a pending password to the admin password the secured nodes
+// accept. The pending password is kept if no secured node is up or none of the passwords is accepted.
+func (r *ReconcileAerospikeCluster) resolvePendingAdminPassword(aeroCluster *aerospikev1alpha1.AerospikeCluster, secret *corev1.Secret) error {
+	logger := pkglog.New(log.Ctx{"AerospikeCluster": utils.ClusterNamespacedName(aeroCluster)})
+
+	pending := secret.Data[utils.AdminSecretPendingPasswordKey]
+
+	conns, err := r.getSecuredHostConns(aeroCluster)
+	if err != nil {
+		return err
+	}
+	if len(conns) == 0 {
+		return nil
+	}
+
+	candidates := []string{string(secret.Data[utils.DefaultUserSecretKey]), string(pending)}
+	if password, err := r.getAdminUserPassword(aeroCluster); err == nil {
+		candidates = append(candidates, password)
+	} else {
+		logger.Warn("Failed to get admin user password", log.Ctx{"err": err})
+	}
+
+	policy := *r.getClientPolicy(aeroCluster)
+	hosts := getHostsForConns(conns)
+	password, err := selectAdminPassword(candidates, func(password string) error {
+		policy.Password = password
+		client, err := as.NewClientWithPolicyAndHost(&policy, hosts...)
+		if err != nil {
+			return err
+		}
+		client.Close()
+		return nil
+	})
+	if err != nil {
+		logger.Warn("Failed to resolve pending admin password", log.Ctx{"err": err})
+		return nil
+	}
+
+	setAdminPasswordData(secret, password)
+	if err := setAdminSecurityMetadataData(secret); err != nil {
+		return err
+	}
+	if err := r.client.Update(context.TODO(), secret, updateOption); err != nil {
+		return fmt.Errorf("Failed to update secret %s: %v", secret.Name, err)
+	}
+	logger.Info("Resolved pending admin password in admin secret", log.Ctx{"secret": secret.Name})
+	return nil
+}
+
+// getAdminUserPassword returns the password of the admin user in the spec.
+func (r *ReconcileAerospikeCluster) getAdminUserPassword(aeroCluster *aerospikev1alpha1.AerospikeCluster) (string, error) {
+	adminUserSpec, err := accessControl.GetAdminUserSpec(&aeroCluster.Spec)
+	if err != nil {
+		return "", err
+	}
+	return r.getPasswordProvider(aeroCluster).Get(adminUserSpec.Name, adminUserSpec)
+}
+
+// getAdminSecret returns the admin secret of the operator.
+func (r *ReconcileAerospikeCluster) getAdminSecret(aeroCluster *aerospikev1alpha1.AerospikeCluster) (*corev1.Secret, error) {
+	secretName := utils.AdminSecretName(aeroCluster)
+	secret := &corev1.Secret{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: secretName, Namespace: aeroCluster.Namespace}, secret); err != nil {
+		return nil, fmt.Errorf("Failed to get secret %s: %v", secretName, err)
+	}
+	return secret, nil
+}
+
+// setPendingAdminPasswordData sets the pending password of the admin secret, unless the password is set already. It
+// returns true if the secret has changed.
+func setPendingAdminPasswordData(secret *corev1.Secret, password string) bool {
+	if string(secret.Data[utils.DefaultUserSecretKey]) == password {
+		return false
+	}
+	if pending, ok := secret.Data[utils.AdminSecretPendingPasswordKey]; ok && string(pending) == password {
+		return false
+	}
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[utils.AdminSecretPendingPasswordKey] = []byte(password)
+	return true
+}
+
+// setAdminPasswordData sets the password of the admin secret and removes its pending password. It returns true if the
+// secret has changed.
+func setAdminPasswordData(secret *corev1.Secret, password string) bool {
+	_, pending := secret.Data[utils.AdminSecretPendingPasswordKey]
+	if !pending && string(secret.Data[utils.DefaultUserSecretKey]) == password {
+		return false
+	}
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[utils.DefaultUserSecretKey] = []byte(password)
+	delete(secret.Data, utils.AdminSecretPendingPasswordKey)
+	return true
+}
+
+// seedAdminSecret adds the security metadata seed to an admin secret created without it.
+func (r *ReconcileAerospikeCluster) seedAdminSecret(aeroCluster *aerospikev1alpha1.AerospikeCluster, secret *corev1.Secret) error {
+	if _, ok := secret.Data[utils.AdminSecretSecurityMetadataKey]; ok {
+		return nil
+	}
+	if err := setAdminSecurityMetadataData(secret); err != nil {
+		return err
+	}
+	if err := r.client.Update(context.TODO(), secret, updateOption); err != nil {
+		return fmt.Errorf("Failed to update secret %s: %v", secret.Name, err)
+	}
+	pkglog.Info("Added security metadata to admin secret", log.Ctx{"AerospikeCluster": utils.ClusterNamespacedName(aeroCluster), "secret": secret.Name})
+	return nil
+}
+
+// setAdminSecurityMetadataData sets the security metadata seed of the admin secret to its password.
+func setAdminSecurityMetadataData(secret *corev1.Secret) error {
+	smd, err := accessControl.AdminSecurityMetadata(string(secret.Data[utils.DefaultUserSecretKey]))
+	if err != nil {
+		return err
+	}
+	secret.Data[utils.AdminSecretSecurityMetadataKey] = smd
+	return nil
+}
+
+// updateStatefulSetAdminSecurityMetadataVolumes mounts the security metadata seed of the admin secret in the init
+// container of secured pods, which copies it to the work directory of nodes without security metadata. The system
+// metadata directory is an emptyDir volume shared with the init container if the work directory is not on a filesystem
+// storage volume.
+func updateStatefulSetAdminSecurityMetadataVolumes(aeroCluster *aerospikev1alpha1.AerospikeCluster, st *appsv1.StatefulSet, rackState RackState) {
+	isAdminVolume := func(name string) bool {
+		return name == adminSecurityMetadataVolumeName || name == smdVolumeName
+	}
+
+	// Remove the previous volumes.
+	var volumes []corev1.Volume
+	for _, volume := range st.Spec.Template.Spec.Volumes {
+		if !isAdminVolume(volume.Name) {
+			volumes = append(volumes, volume)
+		}
+	}
+	containers := []*corev1.Container{&st.Spec.Template.Spec.Containers[0], &st.Spec.Template.Spec.InitContainers[0]}
+	mounts := make([][]corev1.VolumeMount, len(containers))
+	for i, container := range containers {
+		for _, mount := range container.VolumeMounts {
+			if !isAdminVolume(mount.Name) {
+				mounts[i] = append(mounts[i], mount)
+			}
+		}
+	}
+
+	if enabled, err := utils.IsSecurityEnabled(aeroCluster.Spec.AerospikeConfig); err == nil && enabled {
+		volumes = append(volumes, corev1.Volume{
+			Name: adminSecurityMetadataVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: utils.AdminSecretName(aeroCluster),
+					Items:      []corev1.KeyToPath{{Key: utils.AdminSecretSecurityMetadataKey, Path: utils.AdminSecretSecurityMetadataKey}},
+				},
+			},
+		})
+		mounts[1] = append(mounts[1], corev1.VolumeMount{
+			Name:      adminSecurityMetadataVolumeName,
+			MountPath: utils.AdminSecurityMetadataPath,
+			ReadOnly:  true,
+		})
+
+		smdDir := filepath.Join(utils.GetWorkDirectory(rackState.Rack.AerospikeConfig), "smd")
+		if !isFilesystemStorageDir(rackState.Rack.Storage, smdDir) {
+			volumes = append(volumes, corev1.Volume{
+				Name: smdVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					EmptyDir: &corev1.EmptyDirVolumeSource{},
+				},
+			})
+			mounts[0] = append(mounts[0], corev1.VolumeMount{Name: smdVolumeName, MountPath: smdDir})
+			mounts[1] = append(mounts[1], corev1.VolumeMount{Name: smdVolumeName, MountPath: filesystemVolumesInitPath + smdDir})
+		}
+	}
+
+	st.Spec.Template.Spec.Volumes = volumes
+	for i, container := range containers {
+		container.VolumeMounts = mounts[i]
+	}
+}
+
+// isFilesystemStorageDir indicates if the directory is on a filesystem storage volume.
+func isFilesystemStorageDir(storage aerospikev1alpha1.AerospikeStorageSpec, dir string) bool {
+	for _, volume := range storage.Volumes {
+		if volume.VolumeMode != aerospikev1alpha1.AerospikeVolumeModeFilesystem {
+			continue
+		}
+		if rel, err := filepath.Rel(volume.Path, dir); err == nil && !strings.HasPrefix(rel, "..") {
+			return true
+		}
+	}
+	return false
+}
+
+// selectAdminPassword returns the first non empty password the login accepts.
+func selectAdminPassword(candidates []string, login func(password string) error) (string, error) {
+	err := fmt.Errorf("No admin password")
+	for _, password := range candidates {
+		if password == "" {
+			continue
+		}
+		if err = login(password); err == nil {
+			return password, nil
+		}
+	}
+	return "", fmt.Errorf("Failed to login with the admin passwords: %v", err)
+}
+
+// bootstrapSecurity sets up the admin user and the access control users on the secured nodes while security is being
+// enabled. The access control is recorded in the status, so that the nodes are accessed with the new admin credentials.
+func (r *ReconcileAerospikeCluster) bootstrapSecurity(aeroCluster *aerospikev1alpha1.AerospikeCluster) error {
+	logger := pkglog.New(log.Ctx{"AerospikeCluster": utils.ClusterNamespacedName(aeroCluster)})
+
+	desired, current, err := getSecurityState(aeroCluster)
+	if err != nil {
+		return err
+	}
+	if !desired || current || aeroCluster.Status.AerospikeAccessControl != nil {
+		return nil
+	}
+
+	conns, err := r.getSecuredHostConns(aeroCluster)
+	if err != nil {
+		return err
+	}
+
+	if len(conns) == 0 {
+		// No secured node is up yet.
+		return nil
+	}
+
+	logger.Info("Setting up access control on the secured nodes", log.Ctx{"nodes": len(conns)})
+	if err := r.reconcileAccessControlOnHosts(aeroCluster, conns); err != nil {
+		r.recordEvent(aeroCluster, corev1.EventTypeWarning, "SecurityBootstrapFailed", "Failed to set up access control on the secured nodes: %v", err)
+		return fmt.Errorf("Failed to set up access control on the secured nodes: %v", err)
+	}
+
+	if err := r.patchAccessControlStatus(aeroCluster); err != nil {
+		return err
+	}
+
+	r.recordEvent(aeroCluster, corev1.EventTypeNormal, "SecurityBootstrapped", "Set up access control on %d secured nodes", len(conns))
+	return nil
+}
+
+// getSecuredHostConns returns connections to the running pods with security enabled.
+func (r *ReconcileAerospikeCluster) getSecuredHostConns(aeroCluster *aerospikev1alpha1.AerospikeCluster) ([]*deployment.HostConn, error) {
+	podList, err := r.getClusterPodList(aeroCluster)
+	if err != nil {
+		return nil, err
+	}
+
+	var conns []*deployment.HostConn
+	for _, pod := range podList.Items {
+		if utils.IsTerminating(&pod) || !utils.IsPodRunningAndReady(&pod) {
+			continue
+		}
+		enabled, err := r.isPodSecurityEnabled(aeroCluster, &pod)
+		if err != nil {
+			return nil, err
+		}
+		if !enabled {
+			continue
+		}
+		conn, err := r.newHostConn(aeroCluster, &pod)
+		if err != nil {
+			return nil, err
+		}
+		conns = append(conns, conn)
+	}
+
+	return conns, nil
+}
+
+// getHostsForConns returns the aerospike client hosts of the connections.
+func getHostsForConns(conns []*deployment.HostConn) []*as.Host {
+	var hosts []*as.Host
+	for _, conn := range conns {
+		hosts = append(hosts, &as.Host{
+			Name:    conn.ASConn.AerospikeHostName,
+			TLSName: conn.ASConn.AerospikeTLSName,
+			Port:    conn.ASConn.AerospikePort,
+		})
+	}
+	return hosts
+}
+
+// patchManagedAccessControlStatus updates the status users and roles created by the operator.
+func (r *ReconcileAerospikeCluster) patchManagedAccessControlStatus(aeroCluster *aerospikev1alpha1.AerospikeCluster, managed *aerospikev1alpha1.AerospikeManagedAccessControlStatus) error {
+	if reflect.DeepEqual(aeroCluster.Status.ManagedAccessControl, managed) {
+		return nil
+	}
+
+	patch := jsonpatch.JsonPatchOperation{Operation: "add", Path: "/status/managedAccessControl", Value: managed}
+
+	jsonpatchJSON, err := json.Marshal([]jsonpatch.JsonPatchOperation{patch})
+	if err != nil {
+		return fmt.Errorf("Error marshalling json patch: %v", err)
+	}
+
+	constantPatch := client.ConstantPatch(types.JSONPatchType, jsonpatchJSON)
+	if err = r.client.Status().Patch(context.TODO(), aeroCluster, constantPatch, client.FieldOwner(patchFieldOwner)); err != nil {
+		return fmt.Errorf("Error updating managed access control status: %v", err)
+	}
+	aeroCluster.Status.ManagedAccessControl = managed
+	return nil
+}
+
+// patchAccessControlStatus updates the status access control to the spec access control.
+func (r *ReconcileAerospikeCluster) patchAccessControlStatus(aeroCluster *aerospikev1alpha1.AerospikeCluster) error {
+	patch := jsonpatch.JsonPatchOperation{Operation: "add", Path: "/status/aerospikeAccessControl", Value: aeroCluster.Spec.AerospikeAccessControl}
+
+	jsonpatchJSON, err := json.Marshal([]jsonpatch.JsonPatchOperation{patch})
+	if err != nil {
+		return fmt.Errorf("Error marshalling json patch: %v", err)
+	}
+
+	constantPatch := client.ConstantPatch(types.JSONPatchType, jsonpatchJSON)
+	if err = r.client.Status().Patch(context.TODO(), aeroCluster, constantPatch, client.FieldOwner(patchFieldOwner)); err != nil {
+		return fmt.Errorf("Error updating access control status: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package aerospikecluster
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAdminPasswordRotation(t *testing.T) {
+	tests := []struct {
+		name string
+		// serverPassword is the admin password on the cluster when the operator restarts.
+		serverPassword string
+		want           string
+	}{
+		{name: "stopped before changing the password", serverPassword: "old", want: "old"},
+		{name: "stopped after changing the password", serverPassword: "new", want: "new"},
+		{name: "password changed to the admin user password", serverPassword: "newer", want: "newer"},
+	}
+
+	for _, test := range tests {
+		secret := &corev1.Secret{Data: map[string][]byte{"password": []byte("old")}}
+
+		// The admin user secret is rotated to new.
+		if !setPendingAdminPasswordData(secret, "new") {
+			t.Fatalf("%s: pending password not set", test.name)
+		}
+		if setPendingAdminPasswordData(secret, "new") {
+			t.Errorf("%s: pending password set twice", test.name)
+		}
+		want := map[string][]byte{"password": []byte("old"), "pending-password": []byte("new")}
+		if !reflect.DeepEqual(secret.Data, want) {
+			t.Fatalf("%s: got secret data %v, want %v", test.name, secret.Data, want)
+		}
+
+		// The operator restarts and the admin user secret is rotated again to newer.
+		candidates := []string{string(secret.Data["password"]), string(secret.Data["pending-password"]), "newer"}
+		password, err := selectAdminPassword(candidates, func(password string) error {
+			if password != test.serverPassword {
+				return fmt.Errorf("invalid password")
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if password != test.want {
+			t.Errorf("%s: got password %s, want %s", test.name, password, test.want)
+		}
+
+		if !setAdminPasswordData(secret, password) {
+			t.Fatalf("%s: password not set", test.name)
+		}
+		want = map[string][]byte{"password": []byte(test.want)}
+		if !reflect.DeepEqual(secret.Data, want) {
+			t.Errorf("%s: got secret data %v, want %v", test.name, secret.Data, want)
+		}
+		if setAdminPasswordData(secret, password) {
+			t.Errorf("%s: password set twice", test.name)
+		}
+	}
+}
+
+func TestSelectAdminPasswordNoneAccepted(t *testing.T) {
+	var tried []string
+	_, err := selectAdminPassword([]string{"old", "", "new"}, func(password string) error {
+		tried = append(tried, password)
+		return fmt.Errorf("invalid password")
+	})
+	if err == nil {
+		t.Error("expected an error when no password is accepted")
+	}
+	if want := []string{"old", "new"}; !reflect.DeepEqual(tried, want) {
+		t.Errorf("got tried passwords %v, want %v", tried, want)
+	}
+}
+
+func TestUpdateStatefulSetAdminSecurityMetadataVolumes(t *testing.T) {
+	workDirVolume := aerospikev1alpha1.AerospikePersistentVolumeSpec{Path: "/opt/aerospike", VolumeMode: aerospikev1alpha1.AerospikeVolumeModeFilesystem}
+
+	tests := []struct {
+		name     string
+		security bool
+		volumes  []aerospikev1alpha1.AerospikePersistentVolumeSpec
+		// The volume mounts of the server and init containers.
+		serverMounts []string
+		initMounts   []string
+	}{
+		{"security disabled", false, nil, []string{confDirName}, []string{confDirName}},
+		{"work directory on storage", true, []aerospikev1alpha1.AerospikePersistentVolumeSpec{workDirVolume}, []string{confDirName}, []string{confDirName, adminSecurityMetadataVolumeName}},
+		{"work directory not on storage", true, nil, []string{confDirName, smdVolumeName}, []string{confDirName, adminSecurityMetadataVolumeName, smdVolumeName}},
+	}
+
+	for _, test := range tests {
+		aeroCluster := &aerospikev1alpha1.AerospikeCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "aerocluster"},
+			Spec: aerospikev1alpha1.AerospikeClusterSpec{
+				AerospikeConfig: aerospikev1alpha1.Values{"security": map[string]interface{}{"enable-security": test.security}},
+			},
+		}
+		rackState := RackState{Rack: aerospikev1alpha1.Rack{AerospikeConfig: aeroCluster.Spec.AerospikeConfig, Storage: aerospikev1alpha1.AerospikeStorageSpec{Volumes: test.volumes}}}
+
+		st := &appsv1.StatefulSet{}
+		st.Spec.Template.Spec.Containers = []corev1.Container{{VolumeMounts: []corev1.VolumeMount{{Name: confDirName}}}}
+		st.Spec.Template.Spec.InitContainers = []corev1.Container{{VolumeMounts: []corev1.VolumeMount{{Name: confDirName}}}}
+
+		// Updating twice keeps a single copy of the volumes.
+		updateStatefulSetAdminSecurityMetadataVolumes(aeroCluster, st, rackState)
+		updateStatefulSetAdminSecurityMetadataVolumes(aeroCluster, st, rackState)
+
+		mountNames := func(container corev1.Container) []string {
+			names := []string{}
+			for _, mount := range container.VolumeMounts {
+				names = append(names, mount.Name)
+			}
+			return names
+		}
+
+		if got := mountNames(st.Spec.Template.Spec.Containers[0]); !reflect.DeepEqual(got, test.serverMounts) {
+			t.Errorf("%s: got server mounts %v, want %v", test.name, got, test.serverMounts)
+		}
+		if got := mountNames(st.Spec.Template.Spec.InitContainers[0]); !reflect.DeepEqual(got, test.initMounts) {
+			t.Errorf("%s: got init mounts %v, want %v", test.name, got, test.initMounts)
+		}
+		if len(st.Spec.Template.Spec.Volumes) != len(test.initMounts)-1 {
+			t.Errorf("%s: got volumes %v", test.name, st.Spec.Template.Spec.Volumes)
+		}
+	}
+}
+
+func TestSetAdminSecurityMetadataData(t *testing.T) {
+	secret := &corev1.Secret{Data: map[string][]byte{"password": []byte("old")}}
+	if err := setAdminSecurityMetadataData(secret); err != nil {
+		t.Fatal(err)
+	}
+	old := secret.Data["security.smd"]
+
+	setAdminPasswordData(secret, "new")
+	if err := setAdminSecurityMetadataData(secret); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(old) == 0 || reflect.DeepEqual(old, secret.Data["security.smd"]) {
+		t.Errorf("got security metadata not updated to the new password")
+	}
+}
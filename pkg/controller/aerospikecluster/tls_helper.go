@@ -0,0 +1,470 @@
+package aerospikecluster
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
+	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/jsonpatch"
+	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/utils"
+	log "github.com/inconshreveable/log15"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// TLS is added to a cluster with a staged roll. The clear service port stays configured, the pods are rolled to the
+// TLS config one at a time and the operator keeps using the clear port till all the pods are rolled. Clients cut over
+// to the TLS port after the roll. The pods are rolled again when the content of the TLS files in the
+// AerospikeConfigSecret or the cert-manager certificate secrets changes, to rotate the certificates.
+
+const (
+	// tlsCertificatesHashAnnotation has the hash of the TLS files used by the pod.
+	tlsCertificatesHashAnnotation = "aerospike.com/tls-certificates-hash"
+
+	// tlsCertificateExpiryWarning is how long before a TLS certificate expires to start warning.
+	tlsCertificateExpiryWarning = 30 * 24 * time.Hour
+)
+
+// secretMapper maps a Secret to the clusters using it as their AerospikeConfigSecret, cert-manager certificate secret
+// or user password secret. User password source secrets can be in other namespaces.
+type secretMapper struct {
+	client client.Client
+}
+
+// Map returns reconcile requests for the clusters using the Secret.
+func (m *secretMapper) Map(obj handler.MapObject) []reconcile.Request {
+	clusterList := &aerospikev1alpha1.AerospikeClusterList{}
+	if err := m.client.List(context.TODO(), clusterList); err != nil {
+		pkglog.Error("Failed to list AerospikeClusters for Secret", log.Ctx{"Secret": utils.NamespacedName(obj.Meta.GetNamespace(), obj.Meta.GetName()), "err": err})
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, aeroCluster := range clusterList.Items {
+		secretNamespace := obj.Meta.GetNamespace()
+		secretName := obj.Meta.GetName()
+		isClusterSecret := aeroCluster.Namespace == secretNamespace &&
+			(aeroCluster.Spec.AerospikeConfigSecret.SecretName == secretName || utils.IsCertManagerSecret(&aeroCluster, secretName))
+		if isClusterSecret || utils.IsUserSecret(&aeroCluster, secretNamespace, secretName) {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: aeroCluster.Name, Namespace: aeroCluster.Namespace}})
+		}
+	}
+	return requests
+}
+
+// getServiceTLSNameFromConfig returns the network.service tls-name of the config.
+func getServiceTLSNameFromConfig(config aerospikev1alpha1.Values) string {
+	networkConf, _ := config["network"].(map[string]interface{})
+	serviceConf, _ := networkConf["service"].(map[string]interface{})
+	tlsName, _ := serviceConf["tls-name"].(string)
+	return tlsName
+}
+
+// getClientTLSName returns the service TLS name the operator connects with. The clear service port is used till all
+// the pods are rolled to a changed service tls-name.
+func getClientTLSName(aeroCluster *aerospikev1alpha1.AerospikeCluster) string {
+	tlsName := getServiceTLSName(aeroCluster)
+	// AerospikeConfig nil means status not updated yet
+	if aeroCluster.Status.AerospikeConfig != nil && getServiceTLSNameFromConfig(aeroCluster.Status.AerospikeConfig) != tlsName {
+		return ""
+	}
+	return tlsName
+}
+
+// tlsConfFiles are the files of a network.tls config.
+type tlsConfFiles struct {
+	name     string
+	certFile string
+	keyFile  string
+	caFile   string
+}
+
+// getTLSConfFiles returns the files of the network.tls configs.
+func getTLSConfFiles(aeroCluster *aerospikev1alpha1.AerospikeCluster) []tlsConfFiles {
+	networkConf, _ := aeroCluster.Spec.AerospikeConfig["network"].(map[string]interface{})
+	tlsConfList, _ := networkConf["tls"].([]interface{})
+
+	var confFiles []tlsConfFiles
+	for _, tlsConfInt := range tlsConfList {
+		tlsConf, ok := tlsConfInt.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		confFile := tlsConfFiles{}
+		confFile.name, _ = tlsConf["name"].(string)
+		confFile.certFile, _ = tlsConf["cert-file"].(string)
+		confFile.keyFile, _ = tlsConf["key-file"].(string)
+		confFile.caFile, _ = tlsConf["ca-file"].(string)
+		confFiles = append(confFiles, confFile)
+	}
+	return confFiles
+}
+
+// getTLSConfFilesByName returns the files of the network.tls config with the name.
+func getTLSConfFilesByName(aeroCluster *aerospikev1alpha1.AerospikeCluster, tlsName string) *tlsConfFiles {
+	for _, confFile := range getTLSConfFiles(aeroCluster) {
+		if confFile.name == tlsName {
+			return &confFile
+		}
+	}
+	return nil
+}
+
+// tlsFileReader reads the TLS files of the pods from the secrets they are mounted from, the cert-manager certificate
+// secrets for the files in the cert-manager certificates path, else the AerospikeConfigSecret. Files are keyed by their
+// name in the secret.
+type tlsFileReader struct {
+	r           *ReconcileAerospikeCluster
+	aeroCluster *aerospikev1alpha1.AerospikeCluster
+	secrets     map[string]*corev1.Secret
+}
+
+func (r *ReconcileAerospikeCluster) newTLSFileReader(aeroCluster *aerospikev1alpha1.AerospikeCluster) *tlsFileReader {
+	return &tlsFileReader{r: r, aeroCluster: aeroCluster, secrets: map[string]*corev1.Secret{}}
+}
+
+// read returns the content of the file, nil if the file is not in the secret.
+func (f *tlsFileReader) read(path string) ([]byte, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	secretName := f.aeroCluster.Spec.AerospikeConfigSecret.SecretName
+	if f.aeroCluster.Spec.CertManager != nil && strings.HasPrefix(path, utils.CertManagerCertsPath+"/") {
+		tlsName := filepath.Base(filepath.Dir(path))
+		secretName = utils.CertManagerSecretName(f.aeroCluster, tlsName)
+	}
+	if secretName == "" {
+		return nil, nil
+	}
+
+	secret, err := f.getSecret(secretName)
+	if err != nil {
+		return nil, err
+	}
+	return secret.Data[filepath.Base(path)], nil
+}
+
+func (f *tlsFileReader) getSecret(secretName string) (*corev1.Secret, error) {
+	if secret, ok := f.secrets[secretName]; ok {
+		return secret, nil
+	}
+
+	secret := &corev1.Secret{}
+	if err := f.r.client.Get(context.TODO(), types.NamespacedName{Name: secretName, Namespace: f.aeroCluster.Namespace}, secret); err != nil {
+		return nil, fmt.Errorf("Failed to get secret %s: %v", secretName, err)
+	}
+	f.secrets[secretName] = secret
+	return secret, nil
+}
+
+// getTLSCertificatesHash returns the hash of the content of the TLS files of the pods. Empty if the cluster has no TLS
+// files.
+func (r *ReconcileAerospikeCluster) getTLSCertificatesHash(aeroCluster *aerospikev1alpha1.AerospikeCluster) (string, error) {
+	reader := r.newTLSFileReader(aeroCluster)
+
+	files := map[string][]byte{}
+	for _, confFile := range getTLSConfFiles(aeroCluster) {
+		for _, file := range []string{confFile.certFile, confFile.keyFile, confFile.caFile} {
+			data, err := reader.read(file)
+			if err != nil {
+				return "", err
+			}
+			if data != nil {
+				files[file] = data
+			}
+		}
+	}
+	if len(files) == 0 {
+		return "", nil
+	}
+
+	var fileNames []string
+	for file := range files {
+		fileNames = append(fileNames, file)
+	}
+	sort.Strings(fileNames)
+
+	var content string
+	for _, file := range fileNames {
+		content += file + "\n" + string(files[file]) + "\n"
+	}
+	return utils.GetHash(content)
+}
+
+// isTLSCertificatesUpdated returns true if the pod does not use the current TLS files. Pods created before the TLS
+// files hash was tracked get the current hash instead of being restarted.
+func (r *ReconcileAerospikeCluster) isTLSCertificatesUpdated(aeroCluster *aerospikev1alpha1.AerospikeCluster, pod corev1.Pod) (bool, error) {
+	certHash, err := r.getTLSCertificatesHash(aeroCluster)
+	if err != nil {
+		return false, err
+	}
+	podHash, ok := pod.Annotations[tlsCertificatesHashAnnotation]
+	if !ok && certHash != "" {
+		return false, r.setPodTLSCertificatesHash(&pod, certHash)
+	}
+	return podHash != certHash, nil
+}
+
+// setPodTLSCertificatesHash sets the TLS files hash annotation of the pod.
+func (r *ReconcileAerospikeCluster) setPodTLSCertificatesHash(pod *corev1.Pod, certHash string) error {
+	patchJSON, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{tlsCertificatesHashAnnotation: certHash},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("Error marshalling merge patch: %v", err)
+	}
+
+	constantPatch := client.ConstantPatch(types.MergePatchType, patchJSON)
+	if err := r.client.Patch(context.TODO(), pod, constantPatch, client.FieldOwner(patchFieldOwner)); err != nil {
+		return fmt.Errorf("Failed to set TLS certificates hash of pod %s: %v", pod.Name, err)
+	}
+	pkglog.Info("Set TLS certificates hash of pod", log.Ctx{"podName": pod.Name})
+	return nil
+}
+
+// isTLSCertificatesRolled returns true if all the pods use the current TLS files. Pods without the TLS files hash are
+// taken to use them.
+func (r *ReconcileAerospikeCluster) isTLSCertificatesRolled(aeroCluster *aerospikev1alpha1.AerospikeCluster) (bool, error) {
+	certHash, err := r.getTLSCertificatesHash(aeroCluster)
+	if err != nil {
+		return false, err
+	}
+	podList, err := r.getClusterPodList(aeroCluster)
+	if err != nil {
+		return false, err
+	}
+	for _, pod := range podList.Items {
+		if podHash, ok := pod.Annotations[tlsCertificatesHashAnnotation]; ok && podHash != certHash {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// updateStatefulSetTLSInfo updates the service TLS env vars and the TLS files hash of the statefulset pods.
+func (r *ReconcileAerospikeCluster) updateStatefulSetTLSInfo(aeroCluster *aerospikev1alpha1.AerospikeCluster, st *appsv1.StatefulSet) error {
+	certHash, err := r.getTLSCertificatesHash(aeroCluster)
+	if err != nil {
+		return err
+	}
+
+	if certHash == "" {
+		delete(st.Spec.Template.Annotations, tlsCertificatesHashAnnotation)
+	} else {
+		if st.Spec.Template.Annotations == nil {
+			st.Spec.Template.Annotations = map[string]string{}
+		}
+		st.Spec.Template.Annotations[tlsCertificatesHashAnnotation] = certHash
+	}
+
+	tlsName := getServiceTLSName(aeroCluster)
+	updateTLSEnv := func(container *corev1.Container) {
+		var env []corev1.EnvVar
+		for _, envVar := range container.Env {
+			if envVar.Name != "MY_POD_TLS_NAME" && envVar.Name != "MY_POD_TLS_ENABLED" {
+				env = append(env, envVar)
+			}
+		}
+		env = append(env, newEnvVarStatic("MY_POD_TLS_NAME", tlsName))
+		if tlsName != "" {
+			env = append(env, newEnvVarStatic("MY_POD_TLS_ENABLED", "true"))
+		}
+		container.Env = env
+	}
+	updateTLSEnv(&st.Spec.Template.Spec.InitContainers[0])
+	updateTLSEnv(&st.Spec.Template.Spec.Containers[0])
+	return nil
+}
+
+// updateServiceForPodTLS adds or removes the TLS port of the pod service with multiPodPerHost.
+func (r *ReconcileAerospikeCluster) updateServiceForPodTLS(aeroCluster *aerospikev1alpha1.AerospikeCluster, pod *corev1.Pod) error {
+	service, err := r.getServiceForPod(pod)
+	if err != nil {
+		return err
+	}
+
+	var ports []corev1.ServicePort
+	for _, port := range service.Spec.Ports {
+		if port.Name != "tls" {
+			ports = append(ports, port)
+		}
+	}
+	if getServiceTLSName(aeroCluster) != "" {
+		ports = append(ports, corev1.ServicePort{
+			Name: "tls",
+			Port: utils.ServiceTLSPort,
+		})
+	}
+	if len(ports) == len(service.Spec.Ports) {
+		return nil
+	}
+
+	service.Spec.Ports = ports
+	if err := r.client.Update(context.TODO(), service, updateOption); err != nil {
+		return fmt.Errorf("Failed to update service for pod %s: %v", pod.Name, err)
+	}
+	return nil
+}
+
+// reconcileTLSCertificates updates the TLS certificates status with their expiry dates and records a warning event
+// when a certificate is about to expire.
+func (r *ReconcileAerospikeCluster) reconcileTLSCertificates(aeroCluster *aerospikev1alpha1.AerospikeCluster) error {
+	logger := pkglog.New(log.Ctx{"AerospikeCluster": utils.ClusterNamespacedName(aeroCluster)})
+
+	reader := r.newTLSFileReader(aeroCluster)
+	var certStatuses []aerospikev1alpha1.AerospikeTLSCertificateStatus
+	for _, confFile := range getTLSConfFiles(aeroCluster) {
+		data, err := reader.read(confFile.certFile)
+		if err != nil {
+			return err
+		}
+		if data == nil {
+			continue
+		}
+		cert, err := parseCertificate(data)
+		if err != nil {
+			r.recordEvent(aeroCluster, corev1.EventTypeWarning, "TLSCertificateInvalid", "Failed to parse TLS certificate %s: %v", confFile.certFile, err)
+			return fmt.Errorf("Failed to parse TLS certificate %s: %v", confFile.certFile, err)
+		}
+		certStatuses = append(certStatuses, aerospikev1alpha1.AerospikeTLSCertificateStatus{
+			TLSName:  confFile.name,
+			CertFile: confFile.certFile,
+			NotAfter: metav1.NewTime(cert.NotAfter),
+		})
+
+		if time.Until(cert.NotAfter) < tlsCertificateExpiryWarning {
+			logger.Warn("TLS certificate is about to expire", log.Ctx{"tlsName": confFile.name, "certFile": confFile.certFile, "notAfter": cert.NotAfter})
+			r.recordEvent(aeroCluster, corev1.EventTypeWarning, "TLSCertificateExpiring", "TLS certificate %s of %s expires on %s", confFile.certFile, confFile.name, cert.NotAfter.Format("2006-01-02"))
+		}
+	}
+
+	if err := r.patchTLSCertificatesStatus(aeroCluster, certStatuses); err != nil {
+		return err
+	}
+	return r.reconcileTLSCACertificates(aeroCluster)
+}
+
+// reconcileTLSCACertificates updates the CA certificates trusted by the operator. The CA certificate of the current
+// TLS files is added to the ones trusted before, which are dropped once all the pods are rolled to the current files.
+func (r *ReconcileAerospikeCluster) reconcileTLSCACertificates(aeroCluster *aerospikev1alpha1.AerospikeCluster) error {
+	var caData []byte
+	if tlsName := getClientTLSName(aeroCluster); tlsName != "" {
+		if confFiles := getTLSConfFilesByName(aeroCluster, tlsName); confFiles != nil && confFiles.caFile != "" {
+			var err error
+			if caData, err = r.newTLSFileReader(aeroCluster).read(confFiles.caFile); err != nil {
+				return err
+			}
+		}
+	}
+
+	rolled, err := r.isTLSCertificatesRolled(aeroCluster)
+	if err != nil {
+		return err
+	}
+
+	caCerts := getTrustedCACertificates(aeroCluster.Status.TLSCACertificates, string(caData), rolled)
+	if reflect.DeepEqual(caCerts, aeroCluster.Status.TLSCACertificates) {
+		return nil
+	}
+
+	var patch jsonpatch.JsonPatchOperation
+	if len(caCerts) == 0 {
+		patch = jsonpatch.JsonPatchOperation{Operation: "remove", Path: "/status/tlsCACertificates"}
+	} else {
+		patch = jsonpatch.JsonPatchOperation{Operation: "add", Path: "/status/tlsCACertificates", Value: caCerts}
+	}
+
+	jsonpatchJSON, err := json.Marshal([]jsonpatch.JsonPatchOperation{patch})
+	if err != nil {
+		return fmt.Errorf("Error marshalling json patch: %v", err)
+	}
+
+	constantPatch := client.ConstantPatch(types.JSONPatchType, jsonpatchJSON)
+	if err = r.client.Status().Patch(context.TODO(), aeroCluster, constantPatch, client.FieldOwner(patchFieldOwner)); err != nil {
+		return fmt.Errorf("Error updating TLS CA certificates status: %v", err)
+	}
+	aeroCluster.Status.TLSCACertificates = caCerts
+	return nil
+}
+
+// getTrustedCACertificates returns the CA certificates to trust: the current one, and the ones trusted before till
+// the pods are rolled.
+func getTrustedCACertificates(trusted []string, current string, rolled bool) []string {
+	var caCerts []string
+	if !rolled {
+		caCerts = append(caCerts, trusted...)
+	}
+	if current != "" && !utils.ContainsString(caCerts, current) {
+		caCerts = append(caCerts, current)
+	}
+	return caCerts
+}
+
+// parseCertificate parses the first certificate of the PEM data, the leaf certificate of a chain.
+func parseCertificate(data []byte) (*x509.Certificate, error) {
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, fmt.Errorf("No certificate found")
+		}
+		if block.Type == "CERTIFICATE" {
+			return x509.ParseCertificate(block.Bytes)
+		}
+	}
+}
+
+// patchTLSCertificatesStatus updates the TLS certificates status if it has changed.
+func (r *ReconcileAerospikeCluster) patchTLSCertificatesStatus(aeroCluster *aerospikev1alpha1.AerospikeCluster, certStatuses []aerospikev1alpha1.AerospikeTLSCertificateStatus) error {
+	if isTLSCertificatesStatusEqual(aeroCluster.Status.TLSCertificates, certStatuses) {
+		return nil
+	}
+
+	var patch jsonpatch.JsonPatchOperation
+	if len(certStatuses) == 0 {
+		patch = jsonpatch.JsonPatchOperation{Operation: "remove", Path: "/status/tlsCertificates"}
+	} else {
+		patch = jsonpatch.JsonPatchOperation{Operation: "add", Path: "/status/tlsCertificates", Value: certStatuses}
+	}
+
+	jsonpatchJSON, err := json.Marshal([]jsonpatch.JsonPatchOperation{patch})
+	if err != nil {
+		return fmt.Errorf("Error marshalling json patch: %v", err)
+	}
+
+	constantPatch := client.ConstantPatch(types.JSONPatchType, jsonpatchJSON)
+	if err = r.client.Status().Patch(context.TODO(), aeroCluster, constantPatch, client.FieldOwner(patchFieldOwner)); err != nil {
+		return fmt.Errorf("Error updating TLS certificates status: %v", err)
+	}
+	return nil
+}
+
+func isTLSCertificatesStatusEqual(statuses1, statuses2 []aerospikev1alpha1.AerospikeTLSCertificateStatus) bool {
+	if len(statuses1) != len(statuses2) {
+		return false
+	}
+	for i := range statuses1 {
+		// Compare instants, the time read back from the status is in the local timezone.
+		if statuses1[i].TLSName != statuses2[i].TLSName || statuses1[i].CertFile != statuses2[i].CertFile ||
+			!statuses1[i].NotAfter.Equal(&statuses2[i].NotAfter) {
+			return false
+		}
+	}
+	return true
+}
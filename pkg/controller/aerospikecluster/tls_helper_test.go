@@ -0,0 +1,32 @@
+package aerospikecluster
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetTrustedCACertificates(t *testing.T) {
+	tests := []struct {
+		name    string
+		trusted []string
+		current string
+		rolled  bool
+		want    []string
+	}{
+		{name: "first reconcile", current: "ca-1", rolled: true, want: []string{"ca-1"}},
+		{name: "unchanged", trusted: []string{"ca-1"}, current: "ca-1", rolled: true, want: []string{"ca-1"}},
+		{name: "rolling to new CA", trusted: []string{"ca-1"}, current: "ca-2", want: []string{"ca-1", "ca-2"}},
+		{name: "roll completed", trusted: []string{"ca-1", "ca-2"}, current: "ca-2", rolled: true, want: []string{"ca-2"}},
+		{name: "rolling again", trusted: []string{"ca-1", "ca-2"}, current: "ca-3", want: []string{"ca-1", "ca-2", "ca-3"}},
+		{name: "TLS disabled", trusted: []string{"ca-1"}, rolled: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := getTrustedCACertificates(test.trusted, test.current, test.rolled)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got CA certificates %v, want %v", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,529 @@
+package asconfig
+
+// Aerospike admin protocol commands for roles.
+//
+// Roles are managed with the aerospike client, except for what the vendored client does not
+// support: it has no role quotas, and panics on encoding or parsing the udf-admin, sindex-admin and
+// truncate privileges added in server 6.0. Upgrading the client is blocked on its API changes, so
+// only the role query, setting quotas and granting or revoking these privileges use the commands
+// below, sent over the client's authenticated node connections.
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+
+	as "github.com/ashishshinde/aerospike-client-go"
+	ast "github.com/ashishshinde/aerospike-client-go/types"
+)
+
+const (
+	// Admin commands.
+	adminGrantPrivileges  byte = 12
+	adminRevokePrivileges byte = 13
+	adminSetQuotas        byte = 15
+	adminQueryRoles       byte = 16
+
+	// Admin command fields.
+	adminFieldRole       byte = 11
+	adminFieldPrivileges byte = 12
+	adminFieldWhitelist  byte = 13
+	adminFieldReadQuota  byte = 14
+	adminFieldWriteQuota byte = 15
+
+	// Proto header version and type for admin messages.
+	adminMsgVersion uint64 = 2
+	adminMsgType    uint64 = 2
+
+	// Size of the proto header.
+	adminProtoHeaderSize = 8
+
+	// Size of the admin header following the proto header.
+	adminHeaderSize = 16
+
+	// Size of the field header, the field length followed by the field id.
+	adminFieldHeaderSize = 5
+
+	// Result code marking the end of query results.
+	adminQueryEnd byte = 50
+
+	// Lowest privilege code that can be scoped to a namespace and set, the read privilege code.
+	minScopedPrivilegeCode byte = 10
+)
+
+// Privileges not defined by the aerospike client.
+const (
+	privilegeUDFAdmin    = "udf-admin"
+	privilegeSIndexAdmin = "sindex-admin"
+	privilegeTruncate    = "truncate"
+)
+
+// Privilege codes on the wire.
+var privilegeCodes = map[string]byte{
+	"user-admin":     0,
+	"sys-admin":      1,
+	"data-admin":     2,
+	"udf-admin":      3,
+	"sindex-admin":   4,
+	"read":           10,
+	"read-write":     11,
+	"read-write-udf": 12,
+	"write":          13,
+	"truncate":       14,
+}
+
+// aerospikeRole is a role as queried from the cluster.
+type aerospikeRole struct {
+	// The role's name.
+	name string
+
+	// The role's privileges as controller spec privilege strings.
+	privileges []string
+
+	// The role's whitelist.
+	whitelist []string
+
+	// The role's read quota. Zero means no quota.
+	readQuota uint32
+
+	// The role's write quota. Zero means no quota.
+	writeQuota uint32
+}
+
+// adminCommand is an admin protocol request.
+type adminCommand struct {
+	// The command code.
+	command byte
+
+	// The encoded fields including their headers.
+	fields [][]byte
+}
+
+// newAdminCommand returns a request for the admin command code.
+func newAdminCommand(command byte) *adminCommand {
+	return &adminCommand{command: command}
+}
+
+// addField adds a field to the request.
+func (cmd *adminCommand) addField(id byte, data []byte) {
+	field := make([]byte, adminFieldHeaderSize+len(data))
+	binary.BigEndian.PutUint32(field, uint32(len(data)+1))
+	field[4] = id
+	copy(field[adminFieldHeaderSize:], data)
+	cmd.fields = append(cmd.fields, field)
+}
+
+// addUint32Field adds a field with a big endian uint32 value to the request.
+func (cmd *adminCommand) addUint32Field(id byte, value uint32) {
+	data := make([]byte, 4)
+	binary.BigEndian.PutUint32(data, value)
+	cmd.addField(id, data)
+}
+
+// encode returns the request as sent on the wire.
+func (cmd *adminCommand) encode() []byte {
+	size := adminProtoHeaderSize + adminHeaderSize
+	for _, field := range cmd.fields {
+		size += len(field)
+	}
+
+	buf := make([]byte, adminProtoHeaderSize+adminHeaderSize, size)
+	binary.BigEndian.PutUint64(buf, uint64(size-adminProtoHeaderSize)|(adminMsgVersion<<56)|(adminMsgType<<48))
+	buf[adminProtoHeaderSize+2] = cmd.command
+	buf[adminProtoHeaderSize+3] = byte(len(cmd.fields))
+
+	for _, field := range cmd.fields {
+		buf = append(buf, field...)
+	}
+
+	return buf
+}
+
+// encodePrivileges encodes the privileges field of a request.
+func encodePrivileges(aerospikePrivileges []as.Privilege) ([]byte, error) {
+	buf := []byte{byte(len(aerospikePrivileges))}
+
+	for _, privilege := range aerospikePrivileges {
+		code, ok := privilegeCodes[string(privilege.Code)]
+		if !ok {
+			return nil, fmt.Errorf("Unknown privilege code %v", privilege.Code)
+		}
+
+		buf = append(buf, code)
+
+		if code < minScopedPrivilegeCode {
+			if privilege.Namespace != "" || privilege.SetName != "" {
+				return nil, fmt.Errorf("Global privilege %v cannot have a namespace or set", privilege.Code)
+			}
+			continue
+		}
+
+		if privilege.SetName != "" && privilege.Namespace == "" {
+			return nil, fmt.Errorf("Privilege %v has a set scope with an empty namespace", privilege.Code)
+		}
+
+		buf = append(buf, byte(len(privilege.Namespace)))
+		buf = append(buf, privilege.Namespace...)
+		buf = append(buf, byte(len(privilege.SetName)))
+		buf = append(buf, privilege.SetName...)
+	}
+
+	return buf, nil
+}
+
+// decodePrivileges decodes a privileges field into controller spec privilege strings.
+func decodePrivileges(data []byte) ([]string, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("Empty privileges field")
+	}
+
+	privileges := []string{}
+	count := int(data[0])
+	offset := 1
+
+	for i := 0; i < count; i++ {
+		if offset >= len(data) {
+			return nil, fmt.Errorf("Truncated privileges field")
+		}
+
+		code := data[offset]
+		offset++
+
+		privilege := ""
+		for name, privilegeCode := range privilegeCodes {
+			if privilegeCode == code {
+				privilege = name
+				break
+			}
+		}
+
+		if privilege == "" {
+			return nil, fmt.Errorf("Unknown privilege code %d", code)
+		}
+
+		if code >= minScopedPrivilegeCode {
+			scope := []string{}
+			for j := 0; j < 2; j++ {
+				if offset >= len(data) || offset+1+int(data[offset]) > len(data) {
+					return nil, fmt.Errorf("Truncated privileges field")
+				}
+
+				length := int(data[offset])
+				scope = append(scope, string(data[offset+1:offset+1+length]))
+				offset += 1 + length
+			}
+
+			if scope[0] != "" {
+				privilege += "." + scope[0]
+
+				if scope[1] != "" {
+					privilege += "." + scope[1]
+				}
+			}
+		}
+
+		privileges = append(privileges, privilege)
+	}
+
+	return privileges, nil
+}
+
+// decodeRoles decodes roles from a block of query results.
+//
+// Returns true if the block has the end of the query results.
+func decodeRoles(block []byte) ([]aerospikeRole, bool, error) {
+	roles := []aerospikeRole{}
+	offset := 0
+
+	for offset < len(block) {
+		if offset+adminHeaderSize > len(block) {
+			return nil, false, fmt.Errorf("Truncated role query result")
+		}
+
+		result := block[offset+1]
+		if result == adminQueryEnd {
+			return roles, true, nil
+		}
+
+		if result != 0 {
+			return nil, false, ast.NewAerospikeError(ast.ResultCode(result))
+		}
+
+		fieldCount := int(block[offset+3])
+		offset += adminHeaderSize
+
+		role := aerospikeRole{privileges: []string{}}
+
+		for i := 0; i < fieldCount; i++ {
+			if offset+adminFieldHeaderSize > len(block) {
+				return nil, false, fmt.Errorf("Truncated role query result")
+			}
+
+			length := int(binary.BigEndian.Uint32(block[offset:])) - 1
+			id := block[offset+4]
+			offset += adminFieldHeaderSize
+
+			if length < 0 || offset+length > len(block) {
+				return nil, false, fmt.Errorf("Truncated role query result")
+			}
+
+			data := block[offset : offset+length]
+			offset += length
+
+			switch id {
+			case adminFieldRole:
+				role.name = string(data)
+
+			case adminFieldPrivileges:
+				privileges, err := decodePrivileges(data)
+				if err != nil {
+					return nil, false, err
+				}
+				role.privileges = privileges
+
+			case adminFieldWhitelist:
+				role.whitelist = []string{}
+				for _, address := range strings.Split(string(data), ",") {
+					if address != "" {
+						role.whitelist = append(role.whitelist, address)
+					}
+				}
+
+			case adminFieldReadQuota:
+				if length == 4 {
+					role.readQuota = binary.BigEndian.Uint32(data)
+				}
+
+			case adminFieldWriteQuota:
+				if length == 4 {
+					role.writeQuota = binary.BigEndian.Uint32(data)
+				}
+			}
+		}
+
+		if role.name == "" && len(role.privileges) == 0 {
+			continue
+		}
+
+		roles = append(roles, role)
+	}
+
+	return roles, false, nil
+}
+
+// executeAdminCommand sends the request to a random node and returns the response blocks.
+//
+// Expects a single response block unless it is a query.
+func executeAdminCommand(client *as.Client, adminPolicy *as.AdminPolicy, cmd *adminCommand) ([][]byte, error) {
+	node, err := client.Cluster().GetRandomNode()
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := 1 * time.Second
+	if adminPolicy != nil && adminPolicy.Timeout > 0 {
+		timeout = adminPolicy.Timeout
+	}
+
+	conn, err := node.GetConnection(timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks, err := exchangeAdminCommand(conn, timeout, cmd)
+	if err != nil {
+		node.InvalidateConnection(conn)
+		return nil, err
+	}
+
+	node.PutConnection(conn)
+	return blocks, nil
+}
+
+// exchangeAdminCommand writes the request to the connection and reads the response blocks.
+func exchangeAdminCommand(conn *as.Connection, timeout time.Duration, cmd *adminCommand) ([][]byte, error) {
+	if err := conn.SetTimeout(time.Now().Add(timeout), timeout); err != nil {
+		return nil, err
+	}
+
+	if _, err := conn.Write(cmd.encode()); err != nil {
+		return nil, err
+	}
+
+	blocks := [][]byte{}
+	header := make([]byte, adminProtoHeaderSize)
+
+	for {
+		if _, err := conn.Read(header, adminProtoHeaderSize); err != nil {
+			return nil, err
+		}
+
+		size := int(binary.BigEndian.Uint64(header) & 0xFFFFFFFFFFFF)
+		if size == 0 {
+			return blocks, nil
+		}
+
+		block := make([]byte, size)
+		if _, err := conn.Read(block, size); err != nil {
+			return nil, err
+		}
+
+		blocks = append(blocks, block)
+
+		if cmd.command != adminQueryRoles {
+			return blocks, nil
+		}
+
+		if _, end, err := decodeRoles(block); err != nil || end {
+			return blocks, nil
+		}
+	}
+}
+
+// runAdminCommand runs an admin command not returning results.
+func runAdminCommand(client *as.Client, adminPolicy *as.AdminPolicy, cmd *adminCommand) error {
+	blocks, err := executeAdminCommand(client, adminPolicy, cmd)
+	if err != nil {
+		return err
+	}
+
+	if len(blocks) == 0 || len(blocks[0]) < adminHeaderSize {
+		return fmt.Errorf("Truncated admin command response")
+	}
+
+	if result := blocks[0][1]; result != 0 {
+		return ast.NewAerospikeError(ast.ResultCode(result))
+	}
+
+	return nil
+}
+
+// queryRoles queries the named role, or all roles if the name is empty.
+func queryRoles(client *as.Client, adminPolicy *as.AdminPolicy, roleName string) ([]aerospikeRole, error) {
+	cmd := newAdminCommand(adminQueryRoles)
+	if roleName != "" {
+		cmd.addField(adminFieldRole, []byte(roleName))
+	}
+
+	blocks, err := executeAdminCommand(client, adminPolicy, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := []aerospikeRole{}
+	for _, block := range blocks {
+		blockRoles, _, err := decodeRoles(block)
+		if err != nil {
+			return nil, err
+		}
+
+		roles = append(roles, blockRoles...)
+	}
+
+	return roles, nil
+}
+
+// isClientPrivilege indicates if the aerospike client can encode the privilege.
+func isClientPrivilege(privilege as.Privilege) bool {
+	switch privilege.Code {
+	case privilegeUDFAdmin, privilegeSIndexAdmin, privilegeTruncate:
+		return false
+	}
+	return true
+}
+
+// splitClientPrivileges splits privileges into those the aerospike client can encode and the others.
+func splitClientPrivileges(aerospikePrivileges []as.Privilege) ([]as.Privilege, []as.Privilege) {
+	clientPrivileges := []as.Privilege{}
+	otherPrivileges := []as.Privilege{}
+
+	for _, privilege := range aerospikePrivileges {
+		if isClientPrivilege(privilege) {
+			clientPrivileges = append(clientPrivileges, privilege)
+		} else {
+			otherPrivileges = append(otherPrivileges, privilege)
+		}
+	}
+
+	return clientPrivileges, otherPrivileges
+}
+
+// createRole creates a role. Quotas are set only if set, for servers not supporting them.
+func createRole(client *as.Client, adminPolicy *as.AdminPolicy, roleName string, aerospikePrivileges []as.Privilege, whitelist []string, readQuota uint32, writeQuota uint32) error {
+	clientPrivileges, otherPrivileges := splitClientPrivileges(aerospikePrivileges)
+
+	if err := client.CreateRole(adminPolicy, roleName, clientPrivileges, whitelist); err != nil {
+		return err
+	}
+
+	if len(otherPrivileges) > 0 {
+		if err := changePrivileges(client, adminPolicy, adminGrantPrivileges, roleName, otherPrivileges); err != nil {
+			return err
+		}
+	}
+
+	if readQuota > 0 || writeQuota > 0 {
+		return setQuotas(client, adminPolicy, roleName, readQuota, writeQuota)
+	}
+
+	return nil
+}
+
+// grantPrivileges grants privileges to a role.
+func grantPrivileges(client *as.Client, adminPolicy *as.AdminPolicy, roleName string, aerospikePrivileges []as.Privilege) error {
+	clientPrivileges, otherPrivileges := splitClientPrivileges(aerospikePrivileges)
+
+	if len(clientPrivileges) > 0 {
+		if err := client.GrantPrivileges(adminPolicy, roleName, clientPrivileges); err != nil {
+			return err
+		}
+	}
+
+	if len(otherPrivileges) > 0 {
+		return changePrivileges(client, adminPolicy, adminGrantPrivileges, roleName, otherPrivileges)
+	}
+
+	return nil
+}
+
+// revokePrivileges revokes privileges from a role.
+func revokePrivileges(client *as.Client, adminPolicy *as.AdminPolicy, roleName string, aerospikePrivileges []as.Privilege) error {
+	clientPrivileges, otherPrivileges := splitClientPrivileges(aerospikePrivileges)
+
+	if len(clientPrivileges) > 0 {
+		if err := client.RevokePrivileges(adminPolicy, roleName, clientPrivileges); err != nil {
+			return err
+		}
+	}
+
+	if len(otherPrivileges) > 0 {
+		return changePrivileges(client, adminPolicy, adminRevokePrivileges, roleName, otherPrivileges)
+	}
+
+	return nil
+}
+
+// changePrivileges grants or revokes privileges the aerospike client cannot encode.
+func changePrivileges(client *as.Client, adminPolicy *as.AdminPolicy, command byte, roleName string, aerospikePrivileges []as.Privilege) error {
+	privileges, err := encodePrivileges(aerospikePrivileges)
+	if err != nil {
+		return err
+	}
+
+	cmd := newAdminCommand(command)
+	cmd.addField(adminFieldRole, []byte(roleName))
+	cmd.addField(adminFieldPrivileges, privileges)
+
+	return runAdminCommand(client, adminPolicy, cmd)
+}
+
+// setQuotas sets the read and write quotas of a role. Zero removes the quota.
+func setQuotas(client *as.Client, adminPolicy *as.AdminPolicy, roleName string, readQuota uint32, writeQuota uint32) error {
+	cmd := newAdminCommand(adminSetQuotas)
+	cmd.addField(adminFieldRole, []byte(roleName))
+	cmd.addUint32Field(adminFieldReadQuota, readQuota)
+	cmd.addUint32Field(adminFieldWriteQuota, writeQuota)
+
+	return runAdminCommand(client, adminPolicy, cmd)
+}
@@ -0,0 +1,204 @@
+package asconfig
+
+import (
+	"encoding/binary"
+	"reflect"
+	"testing"
+
+	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
+)
+
+func TestAdminCommandEncode(t *testing.T) {
+	cmd := newAdminCommand(adminSetQuotas)
+	cmd.addField(adminFieldRole, []byte("reader"))
+	cmd.addUint32Field(adminFieldReadQuota, 100)
+
+	buf := cmd.encode()
+
+	header := binary.BigEndian.Uint64(buf)
+	if version := header >> 56; version != adminMsgVersion {
+		t.Errorf("got proto version %d, want %d", version, adminMsgVersion)
+	}
+
+	if size := int(header & 0xFFFFFFFFFFFF); size != len(buf)-adminProtoHeaderSize {
+		t.Errorf("got proto size %d, want %d", size, len(buf)-adminProtoHeaderSize)
+	}
+
+	if buf[adminProtoHeaderSize+2] != adminSetQuotas || buf[adminProtoHeaderSize+3] != 2 {
+		t.Errorf("got command %d with %d fields, want %d with 2 fields", buf[adminProtoHeaderSize+2], buf[adminProtoHeaderSize+3], adminSetQuotas)
+	}
+
+	field := buf[adminProtoHeaderSize+adminHeaderSize:]
+	if length := binary.BigEndian.Uint32(field); length != uint32(len("reader")+1) {
+		t.Errorf("got role field length %d, want %d", length, len("reader")+1)
+	}
+
+	if field[4] != adminFieldRole || string(field[adminFieldHeaderSize:adminFieldHeaderSize+len("reader")]) != "reader" {
+		t.Errorf("got role field %v", field[:adminFieldHeaderSize+len("reader")])
+	}
+}
+
+func TestDecodeRoles(t *testing.T) {
+	aerospikePrivileges, err := privilegeStringtoAerospikePrivilege([]string{"read.test", "truncate.test.demo", "udf-admin", "sindex-admin"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	privileges, err := encodePrivileges(aerospikePrivileges)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	role := newAdminCommand(0)
+	role.addField(adminFieldRole, []byte("reader"))
+	role.addField(adminFieldPrivileges, privileges)
+	role.addField(adminFieldWhitelist, []byte("10.0.0.0/8,192.168.1.1"))
+	role.addUint32Field(adminFieldReadQuota, 100)
+	role.addUint32Field(adminFieldWriteQuota, 50)
+
+	block := role.encode()[adminProtoHeaderSize:]
+	end := make([]byte, adminHeaderSize)
+	end[1] = adminQueryEnd
+	block = append(block, end...)
+
+	roles, isEnd, err := decodeRoles(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !isEnd {
+		t.Errorf("got no query end")
+	}
+
+	expected := []aerospikeRole{
+		{
+			name:       "reader",
+			privileges: []string{"read.test", "truncate.test.demo", "udf-admin", "sindex-admin"},
+			whitelist:  []string{"10.0.0.0/8", "192.168.1.1"},
+			readQuota:  100,
+			writeQuota: 50,
+		},
+	}
+
+	if !reflect.DeepEqual(roles, expected) {
+		t.Errorf("got roles %+v, want %+v", roles, expected)
+	}
+}
+
+func TestEncodeDecodePrivileges(t *testing.T) {
+	tests := []struct {
+		privileges []string
+		// Encoded length, one byte for the count, one per code and the namespace and set of scoped privileges.
+		length int
+	}{
+		{[]string{"sindex-admin"}, 2},
+		{[]string{"udf-admin"}, 2},
+		{[]string{"truncate"}, 4},
+		{[]string{"truncate.test"}, 8},
+		{[]string{"truncate.test.demo"}, 12},
+		{[]string{"sindex-admin", "truncate.test.demo", "read.test"}, 20},
+	}
+
+	for _, test := range tests {
+		aerospikePrivileges, err := privilegeStringtoAerospikePrivilege(test.privileges)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := encodePrivileges(aerospikePrivileges)
+		if err != nil {
+			t.Errorf("%v: got error %v", test.privileges, err)
+			continue
+		}
+
+		if len(data) != test.length {
+			t.Errorf("%v: got encoded length %d, want %d", test.privileges, len(data), test.length)
+		}
+
+		privileges, err := decodePrivileges(data)
+		if err != nil {
+			t.Errorf("%v: got error %v", test.privileges, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(privileges, test.privileges) {
+			t.Errorf("got privileges %v, want %v", privileges, test.privileges)
+		}
+	}
+}
+
+func TestEncodePrivilegesScope(t *testing.T) {
+	for _, privilege := range []string{"udf-admin.test", "sindex-admin.test"} {
+		aerospikePrivileges, err := privilegeStringtoAerospikePrivilege([]string{privilege})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := encodePrivileges(aerospikePrivileges); err == nil {
+			t.Errorf("got no error for scoped global privilege %s", privilege)
+		}
+	}
+}
+
+func TestSplitClientPrivileges(t *testing.T) {
+	aerospikePrivileges, err := privilegeStringtoAerospikePrivilege([]string{"read.test", "truncate.test", "sys-admin", "sindex-admin", "udf-admin"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clientPrivileges, otherPrivileges := splitClientPrivileges(aerospikePrivileges)
+
+	if got, _ := aerospikePrivilegeToPrivilegeString(clientPrivileges); !reflect.DeepEqual(got, []string{"read.test", "sys-admin"}) {
+		t.Errorf("got client privileges %v, want [read.test sys-admin]", got)
+	}
+
+	if got, _ := aerospikePrivilegeToPrivilegeString(otherPrivileges); !reflect.DeepEqual(got, []string{"truncate.test", "sindex-admin", "udf-admin"}) {
+		t.Errorf("got other privileges %v, want [truncate.test sindex-admin udf-admin]", got)
+	}
+}
+
+func TestIsRoleSpecValidVersion(t *testing.T) {
+	aerospikeConfig := aerospikev1alpha1.Values{
+		"security": map[string]interface{}{
+			"enable-security": true,
+		},
+		"namespaces": []interface{}{
+			map[string]interface{}{"name": "test"},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		image string
+		role  aerospikev1alpha1.AerospikeRoleSpec
+		valid bool
+	}{
+		{"old privilege", "aerospike/aerospike-server-enterprise:5.5.0.3", aerospikev1alpha1.AerospikeRoleSpec{Name: "r", Privileges: []string{"read.test"}}, true},
+		{"new privilege on old server", "aerospike/aerospike-server-enterprise:5.5.0.3", aerospikev1alpha1.AerospikeRoleSpec{Name: "r", Privileges: []string{"truncate.test"}}, false},
+		{"new privilege", "aerospike/aerospike-server-enterprise:6.0.0.1", aerospikev1alpha1.AerospikeRoleSpec{Name: "r", Privileges: []string{"truncate.test", "sindex-admin", "udf-admin"}}, true},
+		{"scoped global privilege", "aerospike/aerospike-server-enterprise:6.0.0.1", aerospikev1alpha1.AerospikeRoleSpec{Name: "r", Privileges: []string{"udf-admin.test"}}, false},
+		{"scoped sindex-admin", "aerospike/aerospike-server-enterprise:6.0.0.1", aerospikev1alpha1.AerospikeRoleSpec{Name: "r", Privileges: []string{"sindex-admin.test"}}, false},
+		{"new predefined role name", "aerospike/aerospike-server-enterprise:6.0.0.1", aerospikev1alpha1.AerospikeRoleSpec{Name: "truncate", Privileges: []string{"read"}}, false},
+		{"new predefined role name on old server", "aerospike/aerospike-server-enterprise:5.5.0.3", aerospikev1alpha1.AerospikeRoleSpec{Name: "truncate", Privileges: []string{"read"}}, true},
+		{"predefined role name", "aerospike/aerospike-server-enterprise:5.5.0.3", aerospikev1alpha1.AerospikeRoleSpec{Name: "read", Privileges: []string{"read"}}, false},
+		{"quotas not enabled", "aerospike/aerospike-server-enterprise:6.0.0.1", aerospikev1alpha1.AerospikeRoleSpec{Name: "r", Privileges: []string{"read"}, ReadQuota: 10}, false},
+	}
+
+	for _, test := range tests {
+		valid, err := isRoleSpecValid([]aerospikev1alpha1.AerospikeRoleSpec{test.role}, test.image, aerospikeConfig)
+		if valid != test.valid {
+			t.Errorf("%s: got valid %v, want %v: %v", test.name, valid, test.valid, err)
+		}
+	}
+
+	aerospikeConfig["security"].(map[string]interface{})["enable-quotas"] = true
+
+	quotaRole := aerospikev1alpha1.AerospikeRoleSpec{Name: "r", Privileges: []string{"read"}, ReadQuota: 10, WriteQuota: 10}
+	if _, err := isRoleSpecValid([]aerospikev1alpha1.AerospikeRoleSpec{quotaRole}, "aerospike/aerospike-server-enterprise:6.0.0.1", aerospikeConfig); err != nil {
+		t.Errorf("got error for quotas: %v", err)
+	}
+
+	if _, err := isRoleSpecValid([]aerospikev1alpha1.AerospikeRoleSpec{quotaRole}, "aerospike/aerospike-server-enterprise:5.5.0.3", aerospikeConfig); err == nil {
+		t.Errorf("got no error for quotas on old server")
+	}
+}
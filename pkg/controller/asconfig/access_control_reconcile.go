@@ -4,13 +4,17 @@ package asconfig
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 	"time"
 
 	as "github.com/ashishshinde/aerospike-client-go"
+	"github.com/ashishshinde/aerospike-client-go/pkg/bcrypt"
 	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
+	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/utils"
 	log "github.com/inconshreveable/log15"
 )
 
@@ -18,7 +22,15 @@ import (
 //
 // Returns a tuple of admin username and password to use. If the cluster is not security
 // enabled both username and password will be zero strings.
-func AerospikeAdminCredentials(desiredState *aerospikev1alpha1.AerospikeClusterSpec, currentState *aerospikev1alpha1.AerospikeClusterSpec, passwordProvider AerospikeUserPasswordProvider) (string, string, error) {
+//
+// While security is being enabled with a rolling restart the credentials of the secured nodes
+// are returned. Nodes without security ignore them.
+//
+// The admin password is read from the admin secret of the operator, which has the password the
+// operator has set on the cluster: the bootstrap password till access control is set up and then
+// the password of the admin user. New nodes are seeded with the bootstrap password, see
+// AdminSecurityMetadata, and never have the default admin password.
+func AerospikeAdminCredentials(desiredState *aerospikev1alpha1.AerospikeClusterSpec, currentState *aerospikev1alpha1.AerospikeClusterSpec, adminSecretName string, passwordProvider AerospikeUserPasswordProvider) (string, string, error) {
 	enabled, err := isSecurityEnabled(currentState)
 	if err != nil {
 		// Its possible this is a new cluster and current state is empty.
@@ -29,50 +41,120 @@ func AerospikeAdminCredentials(desiredState *aerospikev1alpha1.AerospikeClusterS
 		}
 	}
 
+	if !enabled {
+		// Security is being enabled or access control was bootstrapped on nodes which are still secured.
+		desiredEnabled, _ := isSecurityEnabled(desiredState)
+		enabled = desiredEnabled || currentState.AerospikeAccessControl != nil
+	}
+
 	if !enabled {
 		// Return zero strings if this is not a security enabled cluster.
 		return "", "", nil
 	}
 
-	if currentState.AerospikeAccessControl == nil {
-		// We haven't yet set up access control. Use default password.
-		return adminUsername, defaultAdminPAssword, nil
+	password, err := passwordProvider.Get(adminUsername, &aerospikev1alpha1.AerospikeUserSpec{Name: adminUsername, SecretName: adminSecretName})
+	if err != nil {
+		return "", "", err
 	}
 
-	adminUserSpec, ok := getUsersFromSpec(currentState)[adminUsername]
+	return adminUsername, password, nil
+}
 
+// GetAdminUserSpec returns the admin user of the access control.
+func GetAdminUserSpec(clusterSpec *aerospikev1alpha1.AerospikeClusterSpec) (*aerospikev1alpha1.AerospikeUserSpec, error) {
+	adminUserSpec, ok := getUsersFromSpec(clusterSpec)[adminUsername]
 	if !ok {
 		// Should not happen on a validated spec.
-		return "", "", fmt.Errorf("%s user missing in access control", adminUsername)
+		return nil, fmt.Errorf("%s user missing in access control", adminUsername)
 	}
+	return &adminUserSpec, nil
+}
 
-	password, err := passwordProvider.Get(adminUsername, &adminUserSpec)
+// System metadata keys of the password and the roles of a user in the security metadata.
+const (
+	smdPasswordKeyFormat = "%s|P"
+	smdRoleKeyFormat     = "%s|R|%s"
+)
 
+// Fixed salt of the password hash clients login with.
+const passwordHashSalt = "$2a$10$7EqJtq98hPqEX7fNZaFWoO"
+
+// smdItem is an item of a system metadata file.
+type smdItem struct {
+	Key        string `json:"key"`
+	Value      string `json:"value"`
+	Generation int    `json:"generation"`
+	Timestamp  int64  `json:"timestamp"`
+}
+
+// AdminSecurityMetadata returns the security system metadata file seeding new nodes with the admin user having the
+// password, in place of the default admin password the nodes create without security metadata.
+//
+// The nodes keep the password hash the clients login with. The items have the lowest generation and timestamp, so that
+// the security metadata of the cluster wins over the seed when a seeded node joins it.
+func AdminSecurityMetadata(password string) ([]byte, error) {
+	hash, err := bcrypt.Hash(password, passwordHashSalt)
 	if err != nil {
-		return "", "", err
+		return nil, fmt.Errorf("Failed to hash admin password: %v", err)
 	}
 
-	return adminUsername, password, nil
+	smd := []interface{}{
+		// The cluster version key and transaction id, unknown before the node joins the cluster.
+		[]int64{0, 0},
+		smdItem{Key: fmt.Sprintf(smdPasswordKeyFormat, adminUsername), Value: hash, Generation: 1},
+		smdItem{Key: fmt.Sprintf(smdRoleKeyFormat, adminUsername, "user-admin"), Generation: 1},
+	}
+	return json.MarshalIndent(smd, "", "  ")
 }
 
 // ReconcileAccessControl reconciles access control to ensure current state moves to the desired state.
-func ReconcileAccessControl(desired *aerospikev1alpha1.AerospikeClusterSpec, current *aerospikev1alpha1.AerospikeClusterSpec, client *as.Client, passwordProvider AerospikeUserPasswordProvider, logger Logger) error {
+//
+// managed has the users and roles created by the operator. It is updated with the users and roles created and
+// dropped, also when the reconcile fails midway.
+func ReconcileAccessControl(desired *aerospikev1alpha1.AerospikeClusterSpec, current *aerospikev1alpha1.AerospikeClusterSpec, managed *aerospikev1alpha1.AerospikeManagedAccessControlStatus, client *as.Client, passwordProvider AerospikeUserPasswordProvider, logger Logger) error {
 	// Get admin policy based in desired state so that new timeout updates can be applied. It is safe.
 	adminPolicy := getAdminPolicy(desired)
+	policy := getAccessControlPolicy(desired)
 
 	desiredRoles := getRolesFromSpec(desired)
 	currentRoles := getRolesFromSpec(current)
-	err := reconcileRoles(desiredRoles, currentRoles, client, adminPolicy, logger)
+	err := reconcileRoles(desiredRoles, currentRoles, policy, managed, desired.Image, client, adminPolicy, logger)
 	if err != nil {
 		return err
 	}
 
 	desiredUsers := getUsersFromSpec(desired)
 	currentUsers := getUsersFromSpec(current)
-	err = reconcileUsers(desiredUsers, currentUsers, passwordProvider, client, adminPolicy, logger)
+	err = reconcileUsers(desiredUsers, currentUsers, policy, managed, passwordProvider, client, adminPolicy, logger)
 	return err
 }
 
+// NewManagedAccessControlStatus returns the users and roles created by the operator for a cluster without a record of
+// them. The users and roles of the current access control were created by the operator, or adopted by it when they
+// existed, except the admin user.
+func NewManagedAccessControlStatus(current *aerospikev1alpha1.AerospikeClusterSpec) *aerospikev1alpha1.AerospikeManagedAccessControlStatus {
+	managed := &aerospikev1alpha1.AerospikeManagedAccessControlStatus{}
+	for userName := range getUsersFromSpec(current) {
+		if userName != adminUsername {
+			managed.Users = append(managed.Users, userName)
+		}
+	}
+	for roleName := range getRolesFromSpec(current) {
+		managed.Roles = append(managed.Roles, roleName)
+	}
+	sort.Strings(managed.Users)
+	sort.Strings(managed.Roles)
+	return managed
+}
+
+// getAccessControlPolicy returns the access control policy of the spec.
+func getAccessControlPolicy(spec *aerospikev1alpha1.AerospikeClusterSpec) aerospikev1alpha1.AerospikeAccessControlPolicy {
+	if spec.AerospikeAccessControl == nil || spec.AerospikeAccessControl.Policy == "" {
+		return aerospikev1alpha1.AerospikeAccessControlPolicyAuthoritative
+	}
+	return spec.AerospikeAccessControl.Policy
+}
+
 // getRolesFromSpec returns roles or an empty map from the spec.
 func getRolesFromSpec(spec *aerospikev1alpha1.AerospikeClusterSpec) map[string]aerospikev1alpha1.AerospikeRoleSpec {
 	var roles map[string]aerospikev1alpha1.AerospikeRoleSpec = map[string]aerospikev1alpha1.AerospikeRoleSpec{}
@@ -80,10 +162,40 @@ func getRolesFromSpec(spec *aerospikev1alpha1.AerospikeClusterSpec) map[string]a
 		for _, roleSpec := range spec.AerospikeAccessControl.Roles {
 			roles[roleSpec.Name] = roleSpec
 		}
+
+		if ldap := spec.AerospikeAccessControl.LDAP; ldap != nil {
+			for _, roleMapping := range ldap.RoleMappings {
+				// Never replace an access control role, groups named after one are rejected by validation.
+				if _, ok := roles[roleMapping.Group]; ok {
+					continue
+				}
+				roles[roleMapping.Group] = getLDAPGroupRoleSpec(roleMapping, roles)
+			}
+		}
 	}
 	return roles
 }
 
+// getLDAPGroupRoleSpec returns the role for an LDAP group, with the privileges of the roles mapped to the group.
+func getLDAPGroupRoleSpec(roleMapping aerospikev1alpha1.AerospikeLDAPRoleMapping, roles map[string]aerospikev1alpha1.AerospikeRoleSpec) aerospikev1alpha1.AerospikeRoleSpec {
+	groupRole := aerospikev1alpha1.AerospikeRoleSpec{Name: roleMapping.Group, Privileges: []string{}}
+	for _, roleName := range roleMapping.Roles {
+		roleSpec, ok := roles[roleName]
+		rolePrivileges := roleSpec.Privileges
+		if !ok {
+			// Predefined roles have the global privilege of the same name.
+			rolePrivileges = []string{roleName}
+		}
+
+		for _, privilege := range rolePrivileges {
+			if !utils.ContainsString(groupRole.Privileges, privilege) {
+				groupRole.Privileges = append(groupRole.Privileges, privilege)
+			}
+		}
+	}
+	return groupRole
+}
+
 // getUsersFromSpec returns users or an empty map from the spec.
 func getUsersFromSpec(spec *aerospikev1alpha1.AerospikeClusterSpec) map[string]aerospikev1alpha1.AerospikeUserSpec {
 	var users map[string]aerospikev1alpha1.AerospikeUserSpec = map[string]aerospikev1alpha1.AerospikeUserSpec{}
@@ -106,9 +218,9 @@ func getAdminPolicy(clusterSpec *aerospikev1alpha1.AerospikeClusterSpec) as.Admi
 }
 
 // reconcileRoles reconciles roles to take them from current to desired.
-func reconcileRoles(desired map[string]aerospikev1alpha1.AerospikeRoleSpec, current map[string]aerospikev1alpha1.AerospikeRoleSpec, client *as.Client, adminPolicy as.AdminPolicy, logger Logger) error {
+func reconcileRoles(desired map[string]aerospikev1alpha1.AerospikeRoleSpec, current map[string]aerospikev1alpha1.AerospikeRoleSpec, policy aerospikev1alpha1.AerospikeAccessControlPolicy, managed *aerospikev1alpha1.AerospikeManagedAccessControlStatus, image string, client *as.Client, adminPolicy as.AdminPolicy, logger Logger) error {
 	// Get list of existing roles from the cluster.
-	asRoles, err := client.QueryRoles(&adminPolicy)
+	asRoles, err := queryRoles(client, &adminPolicy, "")
 	if err != nil {
 		return fmt.Errorf("Error querying roles: %v", err)
 	}
@@ -117,7 +229,7 @@ func reconcileRoles(desired map[string]aerospikev1alpha1.AerospikeRoleSpec, curr
 
 	// List roles in the cluster.
 	for _, role := range asRoles {
-		currentRoleNames = append(currentRoleNames, role.Name)
+		currentRoleNames = append(currentRoleNames, role.name)
 	}
 
 	requiredRoleNames := []string{}
@@ -130,19 +242,12 @@ func reconcileRoles(desired map[string]aerospikev1alpha1.AerospikeRoleSpec, curr
 	roleReconcileCmds := []AerospikeAccessControlReconcileCmd{}
 
 	// Create a list of role commands to drop.
-	rolesToDrop := sliceSubtract(currentRoleNames, requiredRoleNames)
-
-	for _, roleToDrop := range rolesToDrop {
-		_, ok := predefinedRoles[roleToDrop]
-
-		if !ok {
-			// Not a predefined role and can be dropped.
-			roleReconcileCmds = append(roleReconcileCmds, AerospikeRoleDrop{name: roleToDrop})
-		}
+	for _, roleToDrop := range getRolesToDrop(currentRoleNames, requiredRoleNames, managed.Roles, policy, image) {
+		roleReconcileCmds = append(roleReconcileCmds, AerospikeRoleDrop{name: roleToDrop})
 	}
 
 	for roleName, roleSpec := range desired {
-		roleReconcileCmds = append(roleReconcileCmds, AerospikeRoleCreateUpdate{name: roleName, privileges: roleSpec.Privileges, whitelist: roleSpec.Whitelist})
+		roleReconcileCmds = append(roleReconcileCmds, AerospikeRoleCreateUpdate{name: roleName, privileges: roleSpec.Privileges, whitelist: roleSpec.Whitelist, readQuota: roleSpec.ReadQuota, writeQuota: roleSpec.WriteQuota})
 	}
 
 	// Execute all commands.
@@ -152,13 +257,20 @@ func reconcileRoles(desired map[string]aerospikev1alpha1.AerospikeRoleSpec, curr
 		if err != nil {
 			return err
 		}
+
+		switch cmd := cmd.(type) {
+		case AerospikeRoleDrop:
+			managed.Roles = updateManagedNames(managed.Roles, currentRoleNames, cmd.name, true)
+		case AerospikeRoleCreateUpdate:
+			managed.Roles = updateManagedNames(managed.Roles, currentRoleNames, cmd.name, false)
+		}
 	}
 
 	return nil
 }
 
 // reconcileUsers reconciles users to take them from current to desired.
-func reconcileUsers(desired map[string]aerospikev1alpha1.AerospikeUserSpec, current map[string]aerospikev1alpha1.AerospikeUserSpec, passwordProvider AerospikeUserPasswordProvider, client *as.Client, adminPolicy as.AdminPolicy, logger Logger) error {
+func reconcileUsers(desired map[string]aerospikev1alpha1.AerospikeUserSpec, current map[string]aerospikev1alpha1.AerospikeUserSpec, policy aerospikev1alpha1.AerospikeAccessControlPolicy, managed *aerospikev1alpha1.AerospikeManagedAccessControlStatus, passwordProvider AerospikeUserPasswordProvider, client *as.Client, adminPolicy as.AdminPolicy, logger Logger) error {
 	// Get list of existing users from the cluster.
 	asUsers, err := client.QueryUsers(&adminPolicy)
 	if err != nil {
@@ -182,9 +294,7 @@ func reconcileUsers(desired map[string]aerospikev1alpha1.AerospikeUserSpec, curr
 	userReconcileCmds := []AerospikeAccessControlReconcileCmd{}
 
 	// Create a list of user commands to drop.
-	usersToDrop := sliceSubtract(currentUserNames, requiredUserNames)
-
-	for _, userToDrop := range usersToDrop {
+	for _, userToDrop := range getNamesToDrop(currentUserNames, requiredUserNames, managed.Users, policy) {
 		userReconcileCmds = append(userReconcileCmds, AerospikeUserDrop{name: userToDrop})
 	}
 
@@ -216,11 +326,51 @@ func reconcileUsers(desired map[string]aerospikev1alpha1.AerospikeUserSpec, curr
 		if err != nil {
 			return err
 		}
+
+		switch cmd := cmd.(type) {
+		case AerospikeUserDrop:
+			managed.Users = updateManagedNames(managed.Users, currentUserNames, cmd.name, true)
+		case AerospikeUserCreateUpdate:
+			managed.Users = updateManagedNames(managed.Users, currentUserNames, cmd.name, false)
+		}
 	}
 
 	return nil
 }
 
+// getNamesToDrop returns the users or roles in the cluster which are not required. With the ManagedOnly policy, only
+// the managed ones, created by the operator, are dropped to preserve the ones created outside the operator.
+func getNamesToDrop(currentNames []string, requiredNames []string, managedNames []string, policy aerospikev1alpha1.AerospikeAccessControlPolicy) []string {
+	if policy == aerospikev1alpha1.AerospikeAccessControlPolicyManagedOnly {
+		return sliceSubtract(managedNames, requiredNames)
+	}
+	return sliceSubtract(currentNames, requiredNames)
+}
+
+// getRolesToDrop returns the roles to drop like getNamesToDrop, except the roles predefined by the server version of
+// the image which cannot be dropped.
+func getRolesToDrop(currentRoleNames []string, requiredRoleNames []string, managedRoleNames []string, policy aerospikev1alpha1.AerospikeAccessControlPolicy, image string) []string {
+	rolesToDrop := []string{}
+	for _, roleName := range getNamesToDrop(currentRoleNames, requiredRoleNames, managedRoleNames, policy) {
+		if !isPredefinedRole(roleName, image) {
+			rolesToDrop = append(rolesToDrop, roleName)
+		}
+	}
+	return rolesToDrop
+}
+
+// updateManagedNames returns the managed users or roles updated after the user or role name is dropped, or created or
+// updated. A created user or role is managed, one which existed in the cluster is not.
+func updateManagedNames(managedNames []string, currentNames []string, name string, dropped bool) []string {
+	if dropped {
+		return utils.RemoveString(managedNames, name)
+	}
+	if !utils.ContainsString(currentNames, name) && !utils.ContainsString(managedNames, name) {
+		return append(managedNames, name)
+	}
+	return managedNames
+}
+
 // privilegeStringtoAerospikePrivilege converts privilegeString to an Aerospike privilege.
 func privilegeStringtoAerospikePrivilege(privilegeStrings []string) ([]as.Privilege, error) {
 	aerospikePrivileges := []as.Privilege{}
@@ -279,6 +429,18 @@ func privilegeStringtoAerospikePrivilege(privilegeStrings []string) ([]as.Privil
 			code = as.UserAdmin
 			break
 
+		case "udf-admin":
+			code = privilegeUDFAdmin
+			break
+
+		case "sindex-admin":
+			code = privilegeSIndexAdmin
+			break
+
+		case "truncate":
+			code = privilegeTruncate
+			break
+
 		default:
 			return nil, fmt.Errorf("Unknown privilege %s", privilegeCode)
 
@@ -327,6 +489,18 @@ func aerospikePrivilegeToPrivilegeString(aerospikePrivileges []as.Privilege) ([]
 			buffer.WriteString("user-admin")
 			break
 
+		case privilegeUDFAdmin:
+			buffer.WriteString("udf-admin")
+			break
+
+		case privilegeSIndexAdmin:
+			buffer.WriteString("sindex-admin")
+			break
+
+		case privilegeTruncate:
+			buffer.WriteString("truncate")
+			break
+
 		default:
 			return nil, fmt.Errorf("Unknown privilege code %v", aerospikePrivilege.Code)
 		}
@@ -369,11 +543,17 @@ type AerospikeRoleCreateUpdate struct {
 
 	// The whitelist to set for the role. These whitelist addresses and only these whitelist addresses will be granted to the role after this operation.
 	whitelist []string
+
+	// The read quota to set for the role. Zero means no quota.
+	readQuota uint32
+
+	// The write quota to set for the role. Zero means no quota.
+	writeQuota uint32
 }
 
 // Execute creates a new Aerospike role or updates an existing one.
 func (roleCreate AerospikeRoleCreateUpdate) Execute(client *as.Client, adminPolicy *as.AdminPolicy, logger Logger) error {
-	role, err := client.QueryRole(adminPolicy, roleCreate.name)
+	roles, err := queryRoles(client, adminPolicy, roleCreate.name)
 	isCreate := false
 
 	if err != nil {
@@ -383,13 +563,15 @@ func (roleCreate AerospikeRoleCreateUpdate) Execute(client *as.Client, adminPoli
 			// Failure to query for the role.
 			return fmt.Errorf("Error querying role %s: %v", roleCreate.name, err)
 		}
+	} else if len(roles) == 0 {
+		isCreate = true
 	}
 
 	if isCreate {
 		return roleCreate.createRole(client, adminPolicy, logger)
 	}
 
-	return roleCreate.updateRole(client, adminPolicy, role, logger)
+	return roleCreate.updateRole(client, adminPolicy, &roles[0], logger)
 }
 
 // createRole creates a new Aerospike role.
@@ -401,7 +583,7 @@ func (roleCreate AerospikeRoleCreateUpdate) createRole(client *as.Client, adminP
 		return fmt.Errorf("Could not create role %s: %v", roleCreate.name, err)
 	}
 
-	err = client.CreateRole(adminPolicy, roleCreate.name, aerospikePrivileges, roleCreate.whitelist)
+	err = createRole(client, adminPolicy, roleCreate.name, aerospikePrivileges, roleCreate.whitelist, roleCreate.readQuota, roleCreate.writeQuota)
 	if err != nil {
 		return fmt.Errorf("Could not create role %s: %v", roleCreate.name, err)
 	}
@@ -411,16 +593,12 @@ func (roleCreate AerospikeRoleCreateUpdate) createRole(client *as.Client, adminP
 }
 
 // updateRole updates an existing Aerospike role.
-func (roleCreate AerospikeRoleCreateUpdate) updateRole(client *as.Client, adminPolicy *as.AdminPolicy, role *as.Role, logger Logger) error {
+func (roleCreate AerospikeRoleCreateUpdate) updateRole(client *as.Client, adminPolicy *as.AdminPolicy, role *aerospikeRole, logger Logger) error {
 	// Update the role.
 	logger.Info("Updating role", log.Ctx{"rolename": roleCreate.name})
 
 	// Find the privileges to drop.
-	currentPrivileges, err := aerospikePrivilegeToPrivilegeString(role.Privileges)
-	if err != nil {
-		return fmt.Errorf("Could not update role %s: %v", roleCreate.name, err)
-	}
-
+	currentPrivileges := role.privileges
 	desiredPrivileges := roleCreate.privileges
 	privilegesToRevoke := sliceSubtract(currentPrivileges, desiredPrivileges)
 	privilegesToGrant := sliceSubtract(desiredPrivileges, currentPrivileges)
@@ -431,7 +609,7 @@ func (roleCreate AerospikeRoleCreateUpdate) updateRole(client *as.Client, adminP
 			return fmt.Errorf("Could not update role %s: %v", roleCreate.name, err)
 		}
 
-		err = client.RevokePrivileges(adminPolicy, roleCreate.name, aerospikePrivileges)
+		err = revokePrivileges(client, adminPolicy, roleCreate.name, aerospikePrivileges)
 
 		if err != nil {
 			return fmt.Errorf("Error revoking privileges for role %s: %v", roleCreate.name, err)
@@ -446,7 +624,7 @@ func (roleCreate AerospikeRoleCreateUpdate) updateRole(client *as.Client, adminP
 			return fmt.Errorf("Could not update role %s: %v", roleCreate.name, err)
 		}
 
-		err = client.GrantPrivileges(adminPolicy, roleCreate.name, aerospikePrivileges)
+		err = grantPrivileges(client, adminPolicy, roleCreate.name, aerospikePrivileges)
 
 		if err != nil {
 			return fmt.Errorf("Error granting privileges for role %s: %v", roleCreate.name, err)
@@ -455,9 +633,9 @@ func (roleCreate AerospikeRoleCreateUpdate) updateRole(client *as.Client, adminP
 		logger.Info("Granted privileges to role", log.Ctx{"rolename": roleCreate.name, "privileges": privilegesToGrant})
 	}
 
-	if !reflect.DeepEqual(role.Whitelist, roleCreate.whitelist) {
+	if !reflect.DeepEqual(role.whitelist, roleCreate.whitelist) {
 		// Set whitelist.
-		err = client.SetWhitelist(adminPolicy, roleCreate.name, roleCreate.whitelist)
+		err := client.SetWhitelist(adminPolicy, roleCreate.name, roleCreate.whitelist)
 
 		if err != nil {
 			return fmt.Errorf("Error setting whitelist for role %s: %v", roleCreate.name, err)
@@ -465,6 +643,17 @@ func (roleCreate AerospikeRoleCreateUpdate) updateRole(client *as.Client, adminP
 
 	}
 
+	if role.readQuota != roleCreate.readQuota || role.writeQuota != roleCreate.writeQuota {
+		// Set quotas.
+		err := setQuotas(client, adminPolicy, roleCreate.name, roleCreate.readQuota, roleCreate.writeQuota)
+
+		if err != nil {
+			return fmt.Errorf("Error setting quotas for role %s: %v", roleCreate.name, err)
+		}
+
+		logger.Info("Set quotas for role", log.Ctx{"rolename": roleCreate.name, "readQuota": roleCreate.readQuota, "writeQuota": roleCreate.writeQuota})
+	}
+
 	logger.Info("Updated role", log.Ctx{"rolename": roleCreate.name})
 	return nil
 }
@@ -0,0 +1,175 @@
+package asconfig
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
+	"github.com/ashishshinde/aerospike-client-go/pkg/bcrypt"
+)
+
+func TestGetNamesToDrop(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  []string
+		required []string
+		managed  []string
+		policy   aerospikev1alpha1.AerospikeAccessControlPolicy
+		want     []string
+	}{
+		{
+			name:     "authoritative drops all not required",
+			current:  []string{"admin", "app", "manual"},
+			required: []string{"admin"},
+			managed:  []string{"app"},
+			policy:   aerospikev1alpha1.AerospikeAccessControlPolicyAuthoritative,
+			want:     []string{"app", "manual"},
+		},
+		{
+			name:     "managed only preserves not managed",
+			current:  []string{"admin", "app", "manual"},
+			required: []string{"admin"},
+			managed:  []string{"app"},
+			policy:   aerospikev1alpha1.AerospikeAccessControlPolicyManagedOnly,
+			want:     []string{"app"},
+		},
+		{
+			name:     "managed only keeps required",
+			current:  []string{"admin", "app", "manual"},
+			required: []string{"admin", "app", "manual"},
+			managed:  []string{"app"},
+			policy:   aerospikev1alpha1.AerospikeAccessControlPolicyManagedOnly,
+			want:     []string{},
+		},
+		{
+			name:     "managed only without managed",
+			current:  []string{"admin", "manual"},
+			required: []string{"admin"},
+			policy:   aerospikev1alpha1.AerospikeAccessControlPolicyManagedOnly,
+			want:     []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := getNamesToDrop(test.current, test.required, test.managed, test.policy)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got names to drop %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGetRolesToDropPredefined(t *testing.T) {
+	tests := []struct {
+		image string
+		want  []string
+	}{
+		{"aerospike/aerospike-server-enterprise:6.0.0.1", []string{"profiler"}},
+		// Roles named after privileges added in 6.0 are user defined on older servers.
+		{"aerospike/aerospike-server-enterprise:5.5.0.3", []string{"profiler", "truncate"}},
+	}
+
+	for _, test := range tests {
+		got := getRolesToDrop([]string{"read", "sys-admin", "profiler", "truncate"}, []string{}, nil, aerospikev1alpha1.AerospikeAccessControlPolicyAuthoritative, test.image)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got roles to drop %v, want %v", test.image, got, test.want)
+		}
+	}
+}
+
+func TestGetNamesToDropAdopted(t *testing.T) {
+	current := &aerospikev1alpha1.AerospikeClusterSpec{
+		AerospikeAccessControl: &aerospikev1alpha1.AerospikeAccessControlSpec{
+			Roles: []aerospikev1alpha1.AerospikeRoleSpec{
+				{Name: "profiler", Privileges: []string{"read"}},
+				{Name: "writer", Privileges: []string{"read-write"}},
+			},
+			Users: []aerospikev1alpha1.AerospikeUserSpec{
+				{Name: "admin", SecretName: "admin-secret", Roles: []string{"sys-admin", "user-admin"}},
+				{Name: "app1", SecretName: "app1-secret", Roles: []string{"profiler"}},
+				{Name: "app2", SecretName: "app2-secret", Roles: []string{"writer"}},
+			},
+		},
+	}
+
+	// The users and roles of the last applied access control are adopted, except admin.
+	managed := NewManagedAccessControlStatus(current)
+	if want := []string{"app1", "app2"}; !reflect.DeepEqual(managed.Users, want) {
+		t.Errorf("got managed users %v, want %v", managed.Users, want)
+	}
+	if want := []string{"profiler", "writer"}; !reflect.DeepEqual(managed.Roles, want) {
+		t.Errorf("got managed roles %v, want %v", managed.Roles, want)
+	}
+
+	policy := aerospikev1alpha1.AerospikeAccessControlPolicyManagedOnly
+	usersToDrop := getNamesToDrop([]string{"admin", "app1", "app2", "manual"}, []string{"admin", "app1"}, managed.Users, policy)
+	if want := []string{"app2"}; !reflect.DeepEqual(usersToDrop, want) {
+		t.Errorf("got users to drop %v, want %v", usersToDrop, want)
+	}
+	rolesToDrop := getRolesToDrop([]string{"profiler", "writer", "manual", "read"}, []string{"profiler"}, managed.Roles, policy, "aerospike/aerospike-server-enterprise:6.0.0.1")
+	if want := []string{"writer"}; !reflect.DeepEqual(rolesToDrop, want) {
+		t.Errorf("got roles to drop %v, want %v", rolesToDrop, want)
+	}
+}
+
+func TestUpdateManagedNames(t *testing.T) {
+	tests := []struct {
+		name    string
+		managed []string
+		current []string
+		updated string
+		dropped bool
+		want    []string
+	}{
+		{name: "created", managed: []string{"app1"}, current: []string{"admin", "app1"}, updated: "app2", want: []string{"app1", "app2"}},
+		{name: "updated existing", managed: []string{"app1"}, current: []string{"admin", "app1", "manual"}, updated: "manual", want: []string{"app1"}},
+		{name: "updated managed", managed: []string{"app1"}, current: []string{"admin"}, updated: "app1", want: []string{"app1"}},
+		{name: "dropped", managed: []string{"app1", "app2"}, current: []string{"admin", "app1", "app2"}, updated: "app1", dropped: true, want: []string{"app2"}},
+		{name: "dropped not managed", managed: []string{"app1"}, current: []string{"admin", "app1", "manual"}, updated: "manual", dropped: true, want: []string{"app1"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := updateManagedNames(test.managed, test.current, test.updated, test.dropped)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got managed names %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestAdminSecurityMetadata(t *testing.T) {
+	smd, err := AdminSecurityMetadata("bootstrap")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var items []json.RawMessage
+	if err := json.Unmarshal(smd, &items); err != nil {
+		t.Fatalf("got invalid security metadata: %v", err)
+	}
+
+	if len(items) != 3 {
+		t.Fatalf("got %d security metadata items, want 3", len(items))
+	}
+
+	var header []int64
+	if err := json.Unmarshal(items[0], &header); err != nil || !reflect.DeepEqual(header, []int64{0, 0}) {
+		t.Errorf("got security metadata header %s, want [0,0]", items[0])
+	}
+
+	var password, role smdItem
+	json.Unmarshal(items[1], &password)
+	json.Unmarshal(items[2], &role)
+
+	if password.Key != "admin|P" || !strings.HasPrefix(password.Value, passwordHashSalt) || !bcrypt.Match("bootstrap", password.Value) {
+		t.Errorf("got admin password item %+v", password)
+	}
+
+	if want := (smdItem{Key: "admin|R|user-admin", Generation: 1}); role != want {
+		t.Errorf("got admin role item %+v, want %+v", role, want)
+	}
+}
@@ -5,11 +5,14 @@ package asconfig
 import (
 	"fmt"
 	"net"
+	"net/url"
 	"strings"
 
 	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
 	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/utils"
+	libasconfig "github.com/aerospike/aerospike-management-lib/asconfig"
 	log "github.com/inconshreveable/log15"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 // Logger type alias.
@@ -45,8 +48,15 @@ const (
 	// The admin username.
 	adminUsername = "admin"
 
-	// The default admin user password.
-	defaultAdminPAssword = "admin"
+	// Minimum server version supporting role quotas.
+	quotasMinVersion = "5.6"
+
+	// Maximum LDAP polling period in seconds.
+	ldapPollingPeriodMax = 86400
+
+	// Minimum and maximum LDAP session TTL in seconds.
+	ldapSessionTTLMin = 120
+	ldapSessionTTLMax = 864000
 )
 
 // Chacacters forbidden in role name.
@@ -55,15 +65,18 @@ var roleNameForbiddenChars []string = []string{";", ":"}
 // Chacacters forbidden in username.
 var userNameForbiddenChars []string = []string{";", ":"}
 
-// Predefined roles names.
-var predefinedRoles = map[string]struct{}{
-	"user-admin":     struct{}{},
-	"sys-admin":      struct{}{},
-	"data-admin":     struct{}{},
-	"read":           struct{}{},
-	"read-write":     struct{}{},
-	"read-write-udf": struct{}{},
-	"write":          struct{}{},
+// Predefined roles names and the minimum server version predefining them. Empty if predefined by all versions.
+var predefinedRoles = map[string]string{
+	"user-admin":     "",
+	"sys-admin":      "",
+	"data-admin":     "",
+	"read":           "",
+	"read-write":     "",
+	"read-write-udf": "",
+	"write":          "",
+	"udf-admin":      "6.0",
+	"sindex-admin":   "6.0",
+	"truncate":       "6.0",
 }
 
 // Expect at least one user with these required roles.
@@ -72,15 +85,27 @@ var requiredRoles = []string{
 	"user-admin",
 }
 
+// privilegeDefinition has the scopes of a privilege and the minimum server version supporting it.
+type privilegeDefinition struct {
+	// Valid scopes for the privilege.
+	scopes []PrivilegeScope
+
+	// Minimum server version supporting the privilege. Empty if supported by all versions.
+	minVersion string
+}
+
 // Privilege string allowed in the spec and associated scopes.
-var privileges = map[string][]PrivilegeScope{
-	"read":           []PrivilegeScope{Global, NamespaceSet},
-	"write":          []PrivilegeScope{Global, NamespaceSet},
-	"read-write":     []PrivilegeScope{Global, NamespaceSet},
-	"read-write-udf": []PrivilegeScope{Global, NamespaceSet},
-	"data-admin":     []PrivilegeScope{Global},
-	"sys-admin":      []PrivilegeScope{Global},
-	"user-admin":     []PrivilegeScope{Global},
+var privileges = map[string]privilegeDefinition{
+	"read":           {scopes: []PrivilegeScope{Global, NamespaceSet}},
+	"write":          {scopes: []PrivilegeScope{Global, NamespaceSet}},
+	"read-write":     {scopes: []PrivilegeScope{Global, NamespaceSet}},
+	"read-write-udf": {scopes: []PrivilegeScope{Global, NamespaceSet}},
+	"data-admin":     {scopes: []PrivilegeScope{Global}},
+	"sys-admin":      {scopes: []PrivilegeScope{Global}},
+	"user-admin":     {scopes: []PrivilegeScope{Global}},
+	"udf-admin":      {scopes: []PrivilegeScope{Global}, minVersion: "6.0"},
+	"sindex-admin":   {scopes: []PrivilegeScope{Global}, minVersion: "6.0"},
+	"truncate":       {scopes: []PrivilegeScope{Global, NamespaceSet}, minVersion: "6.0"},
 }
 
 // IsAerospikeAccessControlValid validates the accessControl speciication in the clusterSpec.
@@ -109,16 +134,30 @@ func IsAerospikeAccessControlValid(aerospikeCluster *aerospikev1alpha1.Aerospike
 		return false, fmt.Errorf("Security is enabled but access control is missing")
 	}
 
+	switch aerospikeCluster.AerospikeAccessControl.Policy {
+	case "", aerospikev1alpha1.AerospikeAccessControlPolicyAuthoritative, aerospikev1alpha1.AerospikeAccessControlPolicyManagedOnly:
+	default:
+		return false, fmt.Errorf("Invalid access control policy %s", aerospikeCluster.AerospikeAccessControl.Policy)
+	}
+
 	// Validate roles.
-	_, err = isRoleSpecValid(aerospikeCluster.AerospikeAccessControl.Roles, aerospikeCluster.AerospikeConfig)
+	_, err = isRoleSpecValid(aerospikeCluster.AerospikeAccessControl.Roles, aerospikeCluster.Image, aerospikeCluster.AerospikeConfig)
 	if err != nil {
 		return false, err
 	}
 
+	// Validate LDAP.
+	if aerospikeCluster.AerospikeAccessControl.LDAP != nil {
+		_, err = isLDAPSpecValid(aerospikeCluster.AerospikeAccessControl.LDAP, aerospikeCluster.AerospikeAccessControl.Roles, aerospikeCluster.Image, aerospikeCluster.AerospikeConfig)
+		if err != nil {
+			return false, err
+		}
+	}
+
 	roleMap := getRolesFromSpec(aerospikeCluster)
 
 	// Validate users.
-	_, err = isUserSpecValid(aerospikeCluster.AerospikeAccessControl.Users, roleMap)
+	_, err = isUserSpecValid(aerospikeCluster.AerospikeAccessControl.Users, roleMap, aerospikeCluster.Image)
 
 	if err != nil {
 		return false, err
@@ -143,7 +182,7 @@ func isSecurityEnabled(aerospikeCluster *aerospikev1alpha1.AerospikeClusterSpec)
 }
 
 // isRoleSpecValid indicates if input role spec is valid.
-func isRoleSpecValid(roles []aerospikev1alpha1.AerospikeRoleSpec, aerospikeConfig aerospikev1alpha1.Values) (bool, error) {
+func isRoleSpecValid(roles []aerospikev1alpha1.AerospikeRoleSpec, image string, aerospikeConfig aerospikev1alpha1.Values) (bool, error) {
 	seenRoles := map[string]bool{}
 	for _, roleSpec := range roles {
 		_, isSeen := seenRoles[roleSpec.Name]
@@ -153,8 +192,7 @@ func isRoleSpecValid(roles []aerospikev1alpha1.AerospikeRoleSpec, aerospikeConfi
 		}
 		seenRoles[roleSpec.Name] = true
 
-		_, ok := predefinedRoles[roleSpec.Name]
-		if ok {
+		if isPredefinedRole(roleSpec.Name, image) {
 			// Cannot modify or add predefined roles.
 			return false, fmt.Errorf("Cannot create or modify predefined role: %s", roleSpec.Name)
 		}
@@ -175,7 +213,7 @@ func isRoleSpecValid(roles []aerospikev1alpha1.AerospikeRoleSpec, aerospikeConfi
 			}
 			seenPrivileges[privilege] = true
 
-			_, err = isPrivilegeValid(privilege, aerospikeConfig)
+			_, err = isPrivilegeValid(privilege, image, aerospikeConfig)
 
 			if err != nil {
 				return false, fmt.Errorf("Role '%s' has invalid privilege: %v", roleSpec.Name, err)
@@ -199,6 +237,15 @@ func isRoleSpecValid(roles []aerospikev1alpha1.AerospikeRoleSpec, aerospikeConfi
 			}
 		}
 
+		// Validate quotas.
+		if roleSpec.ReadQuota > 0 || roleSpec.WriteQuota > 0 {
+			_, err = isQuotaSupported(image, aerospikeConfig)
+
+			if err != nil {
+				return false, fmt.Errorf("Role '%s' cannot have quotas: %v", roleSpec.Name, err)
+			}
+		}
+
 	}
 
 	return true, nil
@@ -225,15 +272,22 @@ func isRoleNameValid(roleName string) (bool, error) {
 }
 
 // Indicates if privilege is a valid privilege.
-func isPrivilegeValid(privilege string, aerospikeConfig aerospikev1alpha1.Values) (bool, error) {
+func isPrivilegeValid(privilege string, image string, aerospikeConfig aerospikev1alpha1.Values) (bool, error) {
 	parts := strings.Split(privilege, ".")
 
-	_, ok := privileges[parts[0]]
+	definition, ok := privileges[parts[0]]
 	if !ok {
 		// First part of the privilege is not part of defined privileges.
 		return false, fmt.Errorf("Invalid privilege %s", privilege)
 	}
 
+	if definition.minVersion != "" {
+		_, err := isServerVersionAtLeast(image, definition.minVersion)
+		if err != nil {
+			return false, fmt.Errorf("Privilege %s is not supported: %v", privilege, err)
+		}
+	}
+
 	nParts := len(parts)
 
 	if nParts > 3 {
@@ -242,8 +296,7 @@ func isPrivilegeValid(privilege string, aerospikeConfig aerospikev1alpha1.Values
 
 	if nParts > 1 {
 		// This privilege should necessarily have NamespaceSet scope.
-		scopes := privileges[parts[0]]
-		if !scopeContains(scopes, NamespaceSet) {
+		if !scopeContains(definition.scopes, NamespaceSet) {
 			return false, fmt.Errorf("Privilege %s cannot have namespace or set scope", privilege)
 		}
 
@@ -266,6 +319,162 @@ func isPrivilegeValid(privilege string, aerospikeConfig aerospikev1alpha1.Values
 	return true, nil
 }
 
+// isLDAPSpecValid indicates if the LDAP spec is valid.
+func isLDAPSpecValid(ldap *aerospikev1alpha1.AerospikeLDAPSpec, roles []aerospikev1alpha1.AerospikeRoleSpec, image string, aerospikeConfig aerospikev1alpha1.Values) (bool, error) {
+	if len(ldap.Servers) == 0 {
+		return false, fmt.Errorf("LDAP needs at least one server")
+	}
+
+	for _, server := range ldap.Servers {
+		serverURL, err := url.Parse(server)
+		if err != nil || (serverURL.Scheme != "ldap" && serverURL.Scheme != "ldaps") || serverURL.Host == "" {
+			return false, fmt.Errorf("Invalid LDAP server URL %s", server)
+		}
+	}
+
+	if ldap.DisableTLS && ldap.TLSCASecretName != "" {
+		return false, fmt.Errorf("LDAP TLS CA secret cannot be set with TLS disabled")
+	}
+
+	if len(strings.TrimSpace(ldap.QueryBaseDN)) == 0 {
+		return false, fmt.Errorf("LDAP query base DN cannot be empty")
+	}
+
+	if (ldap.UserDNPattern == "") == (ldap.UserQueryPattern == "") {
+		return false, fmt.Errorf("LDAP needs exactly one of user DN pattern or user query pattern")
+	}
+
+	if (ldap.QueryUserDN == "") != (ldap.QueryUserSecretName == "") {
+		return false, fmt.Errorf("LDAP query user DN and query user secret should be set together")
+	}
+
+	if len(ldap.RoleQueryPatterns) == 0 {
+		return false, fmt.Errorf("LDAP needs at least one role query pattern")
+	}
+
+	for _, secretName := range []string{ldap.QueryUserSecretName, ldap.TLSCASecretName} {
+		if secretName == "" {
+			continue
+		}
+		if errs := validation.IsDNS1123Subdomain(secretName); len(errs) > 0 {
+			return false, fmt.Errorf("Invalid LDAP secret name %s: %v", secretName, errs)
+		}
+	}
+
+	for _, secretKey := range []string{ldap.QueryUserSecretKey, ldap.TLSCASecretKey} {
+		if secretKey == "" {
+			continue
+		}
+		if errs := validation.IsConfigMapKey(secretKey); len(errs) > 0 {
+			return false, fmt.Errorf("Invalid LDAP secret key %s: %v", secretKey, errs)
+		}
+	}
+
+	if ldap.PollingPeriod != nil && (*ldap.PollingPeriod < 0 || *ldap.PollingPeriod > ldapPollingPeriodMax) {
+		return false, fmt.Errorf("LDAP polling period %d should be between 0 and %d", *ldap.PollingPeriod, ldapPollingPeriodMax)
+	}
+
+	if ldap.SessionTTL != nil && (*ldap.SessionTTL < ldapSessionTTLMin || *ldap.SessionTTL > ldapSessionTTLMax) {
+		return false, fmt.Errorf("LDAP session TTL %d should be between %d and %d", *ldap.SessionTTL, ldapSessionTTLMin, ldapSessionTTLMax)
+	}
+
+	// Validate role mappings.
+	specRoles := map[string]bool{}
+	for _, roleSpec := range roles {
+		specRoles[roleSpec.Name] = true
+	}
+
+	seenGroups := map[string]bool{}
+	for _, roleMapping := range ldap.RoleMappings {
+		if seenGroups[roleMapping.Group] {
+			// Cannot have duplicate group entries.
+			return false, fmt.Errorf("Duplicate LDAP role mapping for group: %s", roleMapping.Group)
+		}
+		seenGroups[roleMapping.Group] = true
+
+		_, err := isRoleNameValid(roleMapping.Group)
+		if err != nil {
+			return false, fmt.Errorf("Invalid LDAP role mapping group: %v", err)
+		}
+
+		// Groups named after access control roles are rejected by the admission webhook with the field path.
+		if isPredefinedRole(roleMapping.Group, image) {
+			return false, fmt.Errorf("LDAP role mapping group %s cannot be named after a predefined role", roleMapping.Group)
+		}
+
+		if len(roleMapping.Roles) == 0 {
+			return false, fmt.Errorf("LDAP role mapping for group %s has no roles", roleMapping.Group)
+		}
+
+		for _, roleName := range roleMapping.Roles {
+			if specRoles[roleName] {
+				continue
+			}
+
+			if _, ok := predefinedRoles[roleName]; ok {
+				_, err = isPrivilegeValid(roleName, image, aerospikeConfig)
+				if err != nil {
+					return false, fmt.Errorf("LDAP role mapping for group %s has invalid role: %v", roleMapping.Group, err)
+				}
+			} else {
+				return false, fmt.Errorf("LDAP role mapping for group %s has unknown role %s", roleMapping.Group, roleName)
+			}
+		}
+	}
+
+	return true, nil
+}
+
+// isQuotaSupported indicates if role quotas are supported by the server version and enabled in the config.
+func isQuotaSupported(image string, aerospikeConfig aerospikev1alpha1.Values) (bool, error) {
+	_, err := isServerVersionAtLeast(image, quotasMinVersion)
+	if err != nil {
+		return false, err
+	}
+
+	if secConf, ok := aerospikeConfig["security"].(map[string]interface{}); ok {
+		if enabled, ok := secConf["enable-quotas"].(bool); ok && enabled {
+			return true, nil
+		}
+	}
+
+	return false, fmt.Errorf("Quotas are not enabled in security config")
+}
+
+// isPredefinedRole indicates if the role is predefined by the server version of the image.
+func isPredefinedRole(roleName string, image string) bool {
+	minVersion, ok := predefinedRoles[roleName]
+	if !ok {
+		return false
+	}
+
+	if minVersion == "" {
+		return true
+	}
+
+	_, err := isServerVersionAtLeast(image, minVersion)
+	return err == nil
+}
+
+// isServerVersionAtLeast indicates if the server version of the image is at least minVersion.
+func isServerVersionAtLeast(image string, minVersion string) (bool, error) {
+	version, err := utils.GetImageVersion(image)
+	if err != nil {
+		return false, err
+	}
+
+	val, err := libasconfig.CompareVersions(version, minVersion)
+	if err != nil {
+		return false, fmt.Errorf("Failed to check image version: %v", err)
+	}
+
+	if val < 0 {
+		return false, fmt.Errorf("Needs server version %s or later, image version is %s", minVersion, version)
+	}
+
+	return true, nil
+}
+
 // isNetAddressValid Indicates if networ/address sspecification is valid.
 func isNetAddressValid(address string) (bool, error) {
 	ip := net.ParseIP(address)
@@ -324,7 +533,7 @@ func subset(first, second []string) bool {
 }
 
 // isUserSpecValid indicates if input user specification is valid.
-func isUserSpecValid(users []aerospikev1alpha1.AerospikeUserSpec, roles map[string]aerospikev1alpha1.AerospikeRoleSpec) (bool, error) {
+func isUserSpecValid(users []aerospikev1alpha1.AerospikeUserSpec, roles map[string]aerospikev1alpha1.AerospikeRoleSpec, image string) (bool, error) {
 	requiredRolesUserFound := false
 	seenUsers := map[string]bool{}
 	for _, userSpec := range users {
@@ -354,8 +563,7 @@ func isUserSpecValid(users []aerospikev1alpha1.AerospikeUserSpec, roles map[stri
 			_, ok := roles[roleName]
 			if !ok {
 				// Check is this is a predefined role.
-				_, ok = predefinedRoles[roleName]
-				if !ok {
+				if !isPredefinedRole(roleName, image) {
 					// Neither a specified role nor a predefined role.
 					return false, fmt.Errorf("User '%s' has non-existent role %s", userSpec.Name, roleName)
 				}
@@ -364,9 +572,21 @@ func isUserSpecValid(users []aerospikev1alpha1.AerospikeUserSpec, roles map[stri
 
 		// TODO We should validate actual password here but we cannot read the secret here.
 		// Will have to be done at the time of creating the user!
-		if len(strings.TrimSpace(userSpec.SecretName)) == 0 {
+		if userSpec.PasswordSource != nil {
+			if userSpec.SecretName != "" {
+				return false, fmt.Errorf("User %s cannot have both secret name and password source", userSpec.Name)
+			}
+			if _, err := isPasswordSourceValid(userSpec.PasswordSource); err != nil {
+				return false, fmt.Errorf("User %s has invalid password source: %v", userSpec.Name, err)
+			}
+		} else if len(strings.TrimSpace(userSpec.SecretName)) == 0 {
 			return false, fmt.Errorf("User %s has empty secret name", userSpec.Name)
 		}
+		if userSpec.SecretKey != "" {
+			if errs := validation.IsConfigMapKey(userSpec.SecretKey); len(errs) != 0 {
+				return false, fmt.Errorf("User %s has invalid secret key %s: %s", userSpec.Name, userSpec.SecretKey, strings.Join(errs, ", "))
+			}
+		}
 
 		if subset(requiredRoles, userSpec.Roles) && userSpec.Name == adminUsername {
 			// We found admin user that has the required roles.
@@ -381,6 +601,52 @@ func isUserSpecValid(users []aerospikev1alpha1.AerospikeUserSpec, roles map[stri
 	return true, nil
 }
 
+// isPasswordSourceValid indicates if the password source has exactly one valid source.
+func isPasswordSourceValid(source *aerospikev1alpha1.AerospikePasswordSourceSpec) (bool, error) {
+	sources := 0
+	if source.File != "" {
+		sources++
+		if !utils.IsPasswordFileAllowed(source.File) {
+			return false, fmt.Errorf("Password file %s is not in the operator password file directory", source.File)
+		}
+	}
+
+	if secret := source.Secret; secret != nil {
+		sources++
+		if errs := validation.IsDNS1123Subdomain(secret.Name); len(errs) != 0 {
+			return false, fmt.Errorf("Invalid secret name %s: %s", secret.Name, strings.Join(errs, ", "))
+		}
+		if errs := validation.IsDNS1123Label(secret.Namespace); len(errs) != 0 {
+			return false, fmt.Errorf("Invalid secret namespace %s: %s", secret.Namespace, strings.Join(errs, ", "))
+		}
+		if secret.Key != "" {
+			if errs := validation.IsConfigMapKey(secret.Key); len(errs) != 0 {
+				return false, fmt.Errorf("Invalid secret key %s: %s", secret.Key, strings.Join(errs, ", "))
+			}
+		}
+	}
+
+	if http := source.HTTP; http != nil {
+		sources++
+		passwordURL, err := url.Parse(http.URL)
+		if err != nil || (passwordURL.Scheme != "http" && passwordURL.Scheme != "https") || passwordURL.Host == "" {
+			return false, fmt.Errorf("Invalid password URL %s, should be an http or https URL", http.URL)
+		}
+		if !utils.IsPasswordHostAllowed(passwordURL.Host) {
+			return false, fmt.Errorf("Password URL host %s is not an operator password host", passwordURL.Host)
+		}
+		if http.BearerTokenFile != "" && !utils.IsPasswordFileAllowed(http.BearerTokenFile) {
+			return false, fmt.Errorf("Bearer token file %s is not in the operator password file directory", http.BearerTokenFile)
+		}
+	}
+
+	if sources != 1 {
+		return false, fmt.Errorf("Exactly one of file, secret and http should be set")
+	}
+
+	return true, nil
+}
+
 // isUserNameValid Indicates if a user name is valid.
 func isUserNameValid(userName string) (bool, error) {
 	if len(strings.TrimSpace(userName)) == 0 {
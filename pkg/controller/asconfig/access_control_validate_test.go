@@ -0,0 +1,131 @@
+package asconfig
+
+import (
+	"reflect"
+	"testing"
+
+	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
+	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/utils"
+)
+
+func ldapClusterSpec(ldap *aerospikev1alpha1.AerospikeLDAPSpec) *aerospikev1alpha1.AerospikeClusterSpec {
+	return &aerospikev1alpha1.AerospikeClusterSpec{
+		Image: "aerospike/aerospike-server-enterprise:5.5.0.3",
+		AerospikeConfig: aerospikev1alpha1.Values{
+			"security": map[string]interface{}{
+				"enable-security": true,
+			},
+		},
+		AerospikeAccessControl: &aerospikev1alpha1.AerospikeAccessControlSpec{
+			Roles: []aerospikev1alpha1.AerospikeRoleSpec{
+				{Name: "profiler", Privileges: []string{"read", "sys-admin"}},
+			},
+			Users: []aerospikev1alpha1.AerospikeUserSpec{
+				{Name: "admin", SecretName: "admin-secret", Roles: []string{"sys-admin", "user-admin"}},
+			},
+			LDAP: ldap,
+		},
+	}
+}
+
+func validLDAPSpec() *aerospikev1alpha1.AerospikeLDAPSpec {
+	return &aerospikev1alpha1.AerospikeLDAPSpec{
+		Servers:           []string{"ldaps://ldap.example.com:636"},
+		QueryBaseDN:       "dc=example,dc=com",
+		UserDNPattern:     "uid=${un},ou=People,dc=example,dc=com",
+		RoleQueryPatterns: []string{"(&(objectClass=posixGroup)(memberUid=${un}))"},
+		RoleMappings: []aerospikev1alpha1.AerospikeLDAPRoleMapping{
+			{Group: "dba", Roles: []string{"profiler", "read-write", "read"}},
+		},
+	}
+}
+
+func TestIsAerospikeAccessControlValidLDAP(t *testing.T) {
+	if _, err := IsAerospikeAccessControlValid(ldapClusterSpec(validLDAPSpec())); err != nil {
+		t.Errorf("valid ldap: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(ldap *aerospikev1alpha1.AerospikeLDAPSpec)
+	}{
+		{"no servers", func(ldap *aerospikev1alpha1.AerospikeLDAPSpec) { ldap.Servers = nil }},
+		{"invalid server", func(ldap *aerospikev1alpha1.AerospikeLDAPSpec) { ldap.Servers = []string{"http://ldap.example.com"} }},
+		{"both user patterns", func(ldap *aerospikev1alpha1.AerospikeLDAPSpec) { ldap.UserQueryPattern = "(uid=${un})" }},
+		{"query user without secret", func(ldap *aerospikev1alpha1.AerospikeLDAPSpec) { ldap.QueryUserDN = "cn=admin,dc=example,dc=com" }},
+		{"no role query patterns", func(ldap *aerospikev1alpha1.AerospikeLDAPSpec) { ldap.RoleQueryPatterns = nil }},
+		{"predefined group", func(ldap *aerospikev1alpha1.AerospikeLDAPSpec) { ldap.RoleMappings[0].Group = "read" }},
+		{"unknown mapped role", func(ldap *aerospikev1alpha1.AerospikeLDAPSpec) { ldap.RoleMappings[0].Roles = []string{"unknown"} }},
+		{"unsupported mapped role", func(ldap *aerospikev1alpha1.AerospikeLDAPSpec) { ldap.RoleMappings[0].Roles = []string{"truncate"} }},
+	}
+
+	for _, test := range tests {
+		ldap := validLDAPSpec()
+		test.mutate(ldap)
+		if _, err := IsAerospikeAccessControlValid(ldapClusterSpec(ldap)); err == nil {
+			t.Errorf("%s: expected error", test.name)
+		}
+	}
+}
+
+func TestGetRolesFromSpecLDAPGroups(t *testing.T) {
+	roles := getRolesFromSpec(ldapClusterSpec(validLDAPSpec()))
+
+	want := aerospikev1alpha1.AerospikeRoleSpec{Name: "dba", Privileges: []string{"read", "sys-admin", "read-write"}}
+	if !reflect.DeepEqual(roles["dba"], want) {
+		t.Errorf("got group role %v, want %v", roles["dba"], want)
+	}
+
+	// A group named after an access control role does not replace it.
+	ldap := validLDAPSpec()
+	ldap.RoleMappings[0].Group = "profiler"
+	roles = getRolesFromSpec(ldapClusterSpec(ldap))
+
+	want = aerospikev1alpha1.AerospikeRoleSpec{Name: "profiler", Privileges: []string{"read", "sys-admin"}}
+	if !reflect.DeepEqual(roles["profiler"], want) {
+		t.Errorf("got role %v, want %v", roles["profiler"], want)
+	}
+}
+
+func TestIsAerospikeAccessControlValidPasswordSource(t *testing.T) {
+	if err := utils.InitPasswordSources("/vault/secrets", "passwords.example.com"); err != nil {
+		t.Fatal(err)
+	}
+	defer utils.InitPasswordSources("", "")
+
+	tests := []struct {
+		name    string
+		source  aerospikev1alpha1.AerospikePasswordSourceSpec
+		wantErr bool
+	}{
+		{"file", aerospikev1alpha1.AerospikePasswordSourceSpec{File: "/vault/secrets/app"}, false},
+		{"secret", aerospikev1alpha1.AerospikePasswordSourceSpec{Secret: &aerospikev1alpha1.AerospikePasswordSecretSource{Name: "app", Namespace: "secrets"}}, false},
+		{"http", aerospikev1alpha1.AerospikePasswordSourceSpec{HTTP: &aerospikev1alpha1.AerospikePasswordHTTPSource{URL: "https://passwords.example.com/app", BearerTokenFile: "/vault/secrets/token"}}, false},
+		{"no source", aerospikev1alpha1.AerospikePasswordSourceSpec{}, true},
+		{"two sources", aerospikev1alpha1.AerospikePasswordSourceSpec{File: "/vault/secrets/app", HTTP: &aerospikev1alpha1.AerospikePasswordHTTPSource{URL: "https://passwords.example.com/app"}}, true},
+		{"relative file", aerospikev1alpha1.AerospikePasswordSourceSpec{File: "secrets/app"}, true},
+		{"file outside directory", aerospikev1alpha1.AerospikePasswordSourceSpec{File: "/vault/secrets/../../var/run/secrets/kubernetes.io/serviceaccount/token"}, true},
+		{"host not allowed", aerospikev1alpha1.AerospikePasswordSourceSpec{HTTP: &aerospikev1alpha1.AerospikePasswordHTTPSource{URL: "http://169.254.169.254/latest/meta-data"}}, true},
+		{"token file outside directory", aerospikev1alpha1.AerospikePasswordSourceSpec{HTTP: &aerospikev1alpha1.AerospikePasswordHTTPSource{URL: "https://passwords.example.com/app", BearerTokenFile: "/var/run/secrets/kubernetes.io/serviceaccount/token"}}, true},
+		{"invalid secret namespace", aerospikev1alpha1.AerospikePasswordSourceSpec{Secret: &aerospikev1alpha1.AerospikePasswordSecretSource{Name: "app", Namespace: "Secrets"}}, true},
+		{"invalid url", aerospikev1alpha1.AerospikePasswordSourceSpec{HTTP: &aerospikev1alpha1.AerospikePasswordHTTPSource{URL: "ftp://passwords.example.com/app"}}, true},
+	}
+
+	for _, test := range tests {
+		spec := ldapClusterSpec(nil)
+		source := test.source
+		spec.AerospikeAccessControl.Users = append(spec.AerospikeAccessControl.Users, aerospikev1alpha1.AerospikeUserSpec{Name: "app", PasswordSource: &source, Roles: []string{"read"}})
+		_, err := IsAerospikeAccessControlValid(spec)
+		if test.wantErr && err == nil {
+			t.Errorf("%s: expected error", test.name)
+		} else if !test.wantErr && err != nil {
+			t.Errorf("%s: %v", test.name, err)
+		}
+	}
+
+	spec := ldapClusterSpec(nil)
+	spec.AerospikeAccessControl.Users[0].PasswordSource = &aerospikev1alpha1.AerospikePasswordSourceSpec{File: "/vault/secrets/admin"}
+	if _, err := IsAerospikeAccessControlValid(spec); err == nil {
+		t.Errorf("secret name and password source: expected error")
+	}
+}
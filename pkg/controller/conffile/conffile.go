@@ -0,0 +1,417 @@
+package conffile
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
+	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/configschema"
+	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/utils"
+	"github.com/aerospike/aerospike-management-lib/asconfig"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DefaultKey is the default ConfigMap key of the aerospike.conf file.
+	DefaultKey = "aerospike.conf"
+
+	// VersionAnnotation has the resource version of the aerospikeConfigFile ConfigMap last parsed into the cluster spec.
+	VersionAnnotation = "aerospike.com/config-file-version"
+)
+
+// The parser follows the aerospike.conf reader of the management lib, which is not exported by the lib.
+
+// listFields are the fields which can be repeated in a section. They are kept in a list under their plural name.
+var listFields = map[string]bool{
+	"access-address":               true,
+	"address":                      true,
+	"alternate-access-address":     true,
+	"dc-int-ext-ipmap":             true,
+	"dc-node-address-port":         true,
+	"device":                       true,
+	"feature-key-file":             true,
+	"file":                         true,
+	"mesh-seed-address-port":       true,
+	"mount":                        true,
+	"multicast-group":              true,
+	"node-address-port":            true,
+	"report-data-op":               true,
+	"role-query-pattern":           true,
+	"tls-access-address":           true,
+	"tls-address":                  true,
+	"tls-alternate-access-address": true,
+	"tls-mesh-seed-address-port":   true,
+	"tls-node":                     true,
+	"xdr-remote-datacenter":        true,
+}
+
+// listSections are the named sections which can be repeated. They are kept in a list under their plural name with the
+// section name as the name field.
+var listSections = map[string]bool{
+	"datacenter": true,
+	"dc":         true,
+	"file":       true,
+	"namespace":  true,
+	"set":        true,
+	"tls":        true,
+}
+
+// boolFields are true when present without a value.
+var boolFields = map[string]bool{
+	"enable-benchmark-batch-sub": true,
+	"enable-benchmarks-read":     true,
+	"enable-benchmarks-storage":  true,
+	"enable-benchmarks-udf":      true,
+	"enable-benchmarks-udf-sub":  true,
+	"enable-benchmarks-write":    true,
+}
+
+// timeFields can have a time unit suffix, e.g. 30d. The integer fields of the config schema can have a size unit
+// suffix, e.g. 4G.
+var timeFields = map[string]bool{"default-ttl": true, "max-ttl": true, "tomb-raider-eligible-age": true, "tomb-raider-period": true}
+
+// ConfigMapKey returns the ConfigMap key of the aerospike.conf file.
+func ConfigMapKey(spec *aerospikev1alpha1.AerospikeConfigFileSpec) string {
+	if spec.Key == "" {
+		return DefaultKey
+	}
+	return spec.Key
+}
+
+// Load reads and parses the aerospike.conf file from the aerospikeConfigFile ConfigMap of the cluster, with the config
+// schema of the cluster image version. Returns the parsed config and the resource version of the ConfigMap.
+func Load(reader client.Reader, aeroCluster *aerospikev1alpha1.AerospikeCluster) (aerospikev1alpha1.Values, string, error) {
+	version, err := utils.GetImageVersion(aeroCluster.Spec.Image)
+	if err != nil {
+		return nil, "", err
+	}
+
+	configschema.RLock()
+	schema, err := configschema.Schema(version)
+	configschema.RUnlock()
+	if err != nil {
+		return nil, "", err
+	}
+
+	configMap, err := GetConfigMap(reader, aeroCluster)
+	if err != nil {
+		return nil, "", err
+	}
+
+	key := ConfigMapKey(aeroCluster.Spec.AerospikeConfigFile)
+	data, ok := configMap.Data[key]
+	if !ok {
+		return nil, "", fmt.Errorf("Key %s not found in aerospikeConfigFile ConfigMap %s", key, configMap.Name)
+	}
+
+	config, err := Parse([]byte(data), schema)
+	if err != nil {
+		return nil, "", fmt.Errorf("Failed to parse %s in aerospikeConfigFile ConfigMap %s: %v", key, configMap.Name, err)
+	}
+	return config, configMap.ResourceVersion, nil
+}
+
+// GetConfigMap returns the aerospikeConfigFile ConfigMap of the cluster.
+func GetConfigMap(reader client.Reader, aeroCluster *aerospikev1alpha1.AerospikeCluster) (*corev1.ConfigMap, error) {
+	name := aeroCluster.Spec.AerospikeConfigFile.ConfigMapName
+	configMap := &corev1.ConfigMap{}
+	if err := reader.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: aeroCluster.Namespace}, configMap); err != nil {
+		return nil, fmt.Errorf("Failed to get aerospikeConfigFile ConfigMap %s: %v", name, err)
+	}
+	return configMap, nil
+}
+
+// IsReferenced returns true if the cluster reads its aerospike.conf file from the ConfigMap.
+func IsReferenced(aeroCluster *aerospikev1alpha1.AerospikeCluster, configMapName string) bool {
+	return aeroCluster.Spec.AerospikeConfigFile != nil && aeroCluster.Spec.AerospikeConfigFile.ConfigMapName == configMapName
+}
+
+// Parse parses an aerospike.conf file into the aerospikeConfig format of the cluster spec. Repeated fields and named
+// sections are lists under their plural name, e.g. namespaces and files, and typed sections like storage-engine have
+// the section type as the type field. Sizes like 4G of the integer fields of the json schema are converted to bytes.
+func Parse(data []byte, schema string) (aerospikev1alpha1.Values, error) {
+	intFields, err := integerFields(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{scanner: bufio.NewScanner(bytes.NewReader(data))}
+	config, err := p.parseSection("")
+	if err != nil {
+		return nil, fmt.Errorf("Line %d: %v", p.line, err)
+	}
+	if len(config) == 0 {
+		return nil, fmt.Errorf("File has no config")
+	}
+	if err := convertSizes(config, "", intFields); err != nil {
+		return nil, err
+	}
+	fixFeatureKeyFiles(config)
+	return config, nil
+}
+
+type parser struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+// parseSection parses the fields and subsections of a section up to its closing brace. The top level section, with an
+// empty name, ends with the file.
+func (p *parser) parseSection(name string) (map[string]interface{}, error) {
+	conf := map[string]interface{}{}
+	for p.scanner.Scan() {
+		p.line++
+
+		// Everything after # is a comment.
+		tokens := strings.Fields(strings.SplitN(p.scanner.Text(), "#", 2)[0])
+		if len(tokens) == 0 {
+			continue
+		}
+
+		var err error
+		switch {
+		case tokens[0] == "}":
+			if name == "" || len(tokens) != 1 {
+				return nil, fmt.Errorf("Unexpected %q", strings.Join(tokens, " "))
+			}
+			return conf, nil
+		case len(tokens) > 1 && tokens[len(tokens)-1] == "{":
+			err = p.addSection(conf, tokens[:len(tokens)-1])
+		default:
+			err = addField(conf, tokens)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := p.scanner.Err(); err != nil {
+		return nil, err
+	}
+	if name != "" {
+		return nil, fmt.Errorf("Section %s is not closed", name)
+	}
+	return conf, nil
+}
+
+// addSection parses the section and adds it to conf.
+func (p *parser) addSection(conf map[string]interface{}, tokens []string) error {
+	key := tokens[0]
+	section, err := p.parseSection(key)
+	if err != nil {
+		return err
+	}
+
+	switch len(tokens) {
+	case 1:
+		// Repeated unnamed sections are merged.
+		if existing, ok := conf[key].(map[string]interface{}); ok {
+			for k, v := range section {
+				existing[k] = v
+			}
+			return nil
+		}
+		if key == "logging" {
+			conf[key] = append(toList(conf[key]), logSinks(section)...)
+			return nil
+		}
+		conf[key] = section
+	case 2:
+		if listSections[key] {
+			section["name"] = tokens[1]
+			plural := asconfig.PluralOf(key)
+			conf[plural] = append(toList(conf[plural]), section)
+			return nil
+		}
+		// Typed sections like storage-engine device.
+		section["type"] = tokens[1]
+		conf[key] = section
+	default:
+		return fmt.Errorf("Invalid section %q", strings.Join(tokens, " "))
+	}
+	return nil
+}
+
+// addField parses the field and adds it to conf.
+func addField(conf map[string]interface{}, tokens []string) error {
+	key := tokens[0]
+	if len(tokens) == 1 {
+		if !boolFields[key] {
+			return fmt.Errorf("Field %s has no value", key)
+		}
+		conf[key] = true
+		return nil
+	}
+	value := strings.Join(tokens[1:], " ")
+
+	switch {
+	case key == "context":
+		// Log sink contexts, e.g. context any info.
+		if len(tokens) != 3 {
+			return fmt.Errorf("Invalid log context %q", value)
+		}
+		conf[tokens[1]] = tokens[2]
+	case key == "storage-engine" || key == "index-type":
+		// Typed sections without fields, e.g. storage-engine memory.
+		conf[key] = map[string]interface{}{"type": value}
+	case listFields[key]:
+		plural := asconfig.PluralOf(key)
+		conf[plural] = append(toList(conf[plural]), value)
+	case timeFields[key]:
+		n, err := deHumanize(value, false)
+		if err != nil {
+			return fmt.Errorf("Invalid %s %q", key, value)
+		}
+		conf[key] = n
+	default:
+		conf[key] = parseValue(value)
+	}
+	return nil
+}
+
+// integerFields returns the fields of the json schema by their path, e.g. namespaces.storage-engine.write-block-size,
+// true for the integer fields. Fields which are integers in some of the alternative schemas only are false.
+func integerFields(schema string) (map[string]bool, error) {
+	var schemaObj map[string]interface{}
+	if err := json.Unmarshal([]byte(schema), &schemaObj); err != nil {
+		return nil, fmt.Errorf("Failed to parse config schema: %v", err)
+	}
+
+	fields := map[string]bool{}
+	addIntegerFields(schemaObj, "", fields)
+	return fields, nil
+}
+
+func addIntegerFields(schemaObj map[string]interface{}, path string, fields map[string]bool) {
+	properties, _ := schemaObj["properties"].(map[string]interface{})
+	for key, value := range properties {
+		property, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		fieldPath := joinPath(path, key)
+		if property["type"] == "integer" {
+			if _, ok := fields[fieldPath]; !ok {
+				fields[fieldPath] = true
+			}
+		} else {
+			fields[fieldPath] = false
+		}
+		addIntegerFields(property, fieldPath, fields)
+	}
+
+	// Array items and alternative schemas, like the storage-engine types, are kept under the same path.
+	if items, ok := schemaObj["items"].(map[string]interface{}); ok {
+		addIntegerFields(items, path, fields)
+	}
+	for _, value := range toList(schemaObj["oneOf"]) {
+		if alternative, ok := value.(map[string]interface{}); ok {
+			addIntegerFields(alternative, path, fields)
+		}
+	}
+}
+
+// convertSizes converts the sizes like 4G of the integer fields in conf to bytes.
+func convertSizes(conf map[string]interface{}, path string, intFields map[string]bool) error {
+	for key, value := range conf {
+		fieldPath := joinPath(path, key)
+		switch v := value.(type) {
+		case map[string]interface{}:
+			if err := convertSizes(v, fieldPath, intFields); err != nil {
+				return err
+			}
+		case []interface{}:
+			for _, item := range v {
+				if section, ok := item.(map[string]interface{}); ok {
+					if err := convertSizes(section, fieldPath, intFields); err != nil {
+						return err
+					}
+				}
+			}
+		case string:
+			if !intFields[fieldPath] {
+				continue
+			}
+			n, err := deHumanize(v, true)
+			if err != nil {
+				return fmt.Errorf("Invalid %s %q", key, v)
+			}
+			conf[key] = n
+		}
+	}
+	return nil
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// logSinks returns the log sinks of the logging section, named by their file or console.
+func logSinks(logging map[string]interface{}) []interface{} {
+	sinks := toList(logging["files"])
+	if console, ok := logging["console"].(map[string]interface{}); ok {
+		console["name"] = "console"
+		sinks = append(sinks, console)
+	}
+	return sinks
+}
+
+// fixFeatureKeyFiles keeps a single feature key file under feature-key-file, which is supported by all server versions.
+func fixFeatureKeyFiles(config aerospikev1alpha1.Values) {
+	service, ok := config["service"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	if files := toList(service["feature-key-files"]); len(files) == 1 {
+		delete(service, "feature-key-files")
+		service["feature-key-file"] = files[0]
+	}
+}
+
+func toList(value interface{}) []interface{} {
+	list, _ := value.([]interface{})
+	return list
+}
+
+// parseValue returns the value as a number or a bool if possible.
+func parseValue(value string) interface{} {
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return n
+	}
+	if f, err := strconv.ParseFloat(value, 64); err == nil && !math.IsInf(f, 0) && !math.IsNaN(f) {
+		return f
+	}
+	if value == "true" || value == "false" {
+		return value == "true"
+	}
+	return value
+}
+
+// deHumanize converts a size like 4G, in bytes, or a time like 30d, in seconds, to a number.
+func deHumanize(value string, isSize bool) (int64, error) {
+	multipliers := map[byte]int64{'s': 1, 'm': 60, 'h': 60 * 60, 'd': 24 * 60 * 60}
+	if isSize {
+		multipliers = map[byte]int64{'k': 1 << 10, 'm': 1 << 20, 'g': 1 << 30, 't': 1 << 40, 'p': 1 << 50}
+	}
+
+	multiplier := int64(1)
+	if m, ok := multipliers[strings.ToLower(value[len(value)-1:])[0]]; ok {
+		multiplier = m
+		value = value[:len(value)-1]
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("Invalid value %s", value)
+	}
+	return n * multiplier, nil
+}
@@ -0,0 +1,143 @@
+package conffile
+
+import (
+	"reflect"
+	"testing"
+
+	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
+	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/configschema"
+)
+
+const testConfFile = `# Aerospike database configuration file.
+service {
+    proto-fd-max 15000
+    feature-key-file /etc/aerospike/secret/features.conf
+}
+
+logging {
+    file /var/log/aerospike/aerospike.log {
+        context any info
+        context migrate debug
+    }
+    console {
+        context any warning
+    }
+}
+
+network {
+    service {
+        port 3000
+    }
+    heartbeat {
+        mode mesh
+        port 3002
+        mesh-seed-address-port 10.0.0.1 3002
+        mesh-seed-address-port 10.0.0.2 3002
+        interval 150
+    }
+    fabric {
+        port 3001
+    }
+}
+
+namespace test {
+    replication-factor 2
+    memory-size 4G   # 4 GiB
+    default-ttl 30d
+    enable-benchmarks-read
+    storage-engine device {
+        file /opt/aerospike/data/test.dat
+        filesize 1G
+        write-block-size 128K
+        data-in-memory true
+    }
+}
+
+namespace bar {
+    memory-size 1024
+    storage-engine memory
+}
+`
+
+func TestParse(t *testing.T) {
+	config, err := Parse([]byte(testConfFile), configschema.SchemaMap["5.5.0"])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := aerospikev1alpha1.Values{
+		"service": map[string]interface{}{
+			"proto-fd-max":     int64(15000),
+			"feature-key-file": "/etc/aerospike/secret/features.conf",
+		},
+		"logging": []interface{}{
+			map[string]interface{}{"name": "/var/log/aerospike/aerospike.log", "any": "info", "migrate": "debug"},
+			map[string]interface{}{"name": "console", "any": "warning"},
+		},
+		"network": map[string]interface{}{
+			"service": map[string]interface{}{"port": int64(3000)},
+			"heartbeat": map[string]interface{}{
+				"mode":                    "mesh",
+				"port":                    int64(3002),
+				"mesh-seed-address-ports": []interface{}{"10.0.0.1 3002", "10.0.0.2 3002"},
+				"interval":                int64(150),
+			},
+			"fabric": map[string]interface{}{"port": int64(3001)},
+		},
+		"namespaces": []interface{}{
+			map[string]interface{}{
+				"name":                   "test",
+				"replication-factor":     int64(2),
+				"memory-size":            int64(4 * 1024 * 1024 * 1024),
+				"default-ttl":            int64(30 * 24 * 60 * 60),
+				"enable-benchmarks-read": true,
+				"storage-engine": map[string]interface{}{
+					"type":             "device",
+					"files":            []interface{}{"/opt/aerospike/data/test.dat"},
+					"filesize":         int64(1024 * 1024 * 1024),
+					"write-block-size": int64(128 * 1024),
+					"data-in-memory":   true,
+				},
+			},
+			map[string]interface{}{
+				"name":           "bar",
+				"memory-size":    int64(1024),
+				"storage-engine": map[string]interface{}{"type": "memory"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(config, want) {
+		t.Errorf("got config %v, want %v", config, want)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := map[string]string{
+		"empty":            "# no config\n",
+		"unclosed section": "service {\n    proto-fd-max 15000\n",
+		"unexpected brace": "service {\n}\n}\n",
+		"missing value":    "service {\n    proto-fd-max\n}\n",
+		"invalid size":     "namespace test {\n    memory-size 4X\n}\n",
+		"invalid bytes":    "namespace test {\n    storage-engine device {\n        write-block-size 128KB\n    }\n}\n",
+		"invalid section":  "namespace test extra {\n}\n",
+	}
+	for name, conf := range tests {
+		if _, err := Parse([]byte(conf), configschema.SchemaMap["5.5.0"]); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestParseDataSize(t *testing.T) {
+	conf := "namespace test {\n    storage-engine memory {\n        data-size 4G\n    }\n}\n"
+	config, err := Parse([]byte(conf), configschema.SchemaMap["7.0.0"])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	namespace := config["namespaces"].([]interface{})[0].(map[string]interface{})
+	want := map[string]interface{}{"type": "memory", "data-size": int64(4 * 1024 * 1024 * 1024)}
+	if !reflect.DeepEqual(namespace["storage-engine"], want) {
+		t.Errorf("got storage-engine %v, want %v", namespace["storage-engine"], want)
+	}
+}
@@ -40,6 +40,13 @@ for d in ${REQUIRED_DIRS[*]}; do
     echo creating directory "${TO_CREATE}"
     mkdir -p "$TO_CREATE"
 done
+
+# Seed the security metadata of a new node with the admin password of the operator.
+SECURITY_SMD="{{.AdminSecurityMetadataPath}}/security.smd"
+if [ -f "$SECURITY_SMD" ] && [ ! -f "$DEFAULT_WORK_DIR/smd/security.smd" ]; then
+    echo seeding security metadata
+    cp "$SECURITY_SMD" "$DEFAULT_WORK_DIR/smd/security.smd"
+fi
 {{- end }}
 
 # Kubernetes API details.
@@ -311,6 +318,12 @@ if 'pods' in status and podname in status['pods'] and 'initializedVolumePaths' i
 else:
     alreadyInitialized = []
 
+# Volumes of retained PVCs reattached to this pod are not initialized again.
+if 'retainedPVCs' in status:
+    for pvcName, retainedPVC in status['retainedPVCs'].items():
+        if retainedPVC['podName'] == podname:
+            alreadyInitialized.append(retainedPVC['path'])
+
 # Initialize unintialized volumes.
 initialized = []
 for volume in volumes:
@@ -517,11 +530,12 @@ echo "---------------------------------"
 `
 
 type initializeTemplateInput struct {
-	WorkDir         string
-	MultiPodPerHost bool
-	NetworkPolicy   aerospikev1alpha1.AerospikeNetworkPolicy
-	PodPort         int32
-	PodTLSPort      int32
+	WorkDir                   string
+	MultiPodPerHost           bool
+	NetworkPolicy             aerospikev1alpha1.AerospikeNetworkPolicy
+	PodPort                   int32
+	PodTLSPort                int32
+	AdminSecurityMetadataPath string
 }
 
 var initializeShTemplate, _ = template.New("initializeSh").Parse(initializeShTemplateStr)
@@ -533,11 +547,12 @@ func getBaseConfData(aeroCluster *aerospikev1alpha1.AerospikeCluster, rack aeros
 	workDir := utils.GetWorkDirectory(config)
 
 	initializeTemplateInput := initializeTemplateInput{
-		WorkDir:         workDir,
-		MultiPodPerHost: aeroCluster.Spec.MultiPodPerHost,
-		NetworkPolicy:   aeroCluster.Spec.AerospikeNetworkPolicy,
-		PodPort:         utils.ServicePort,
-		PodTLSPort:      utils.ServiceTLSPort,
+		WorkDir:                   workDir,
+		MultiPodPerHost:           aeroCluster.Spec.MultiPodPerHost,
+		NetworkPolicy:             aeroCluster.Spec.AerospikeNetworkPolicy,
+		PodPort:                   utils.ServicePort,
+		PodTLSPort:                utils.ServiceTLSPort,
+		AdminSecurityMetadataPath: utils.AdminSecurityMetadataPath,
 	}
 	var initializeSh bytes.Buffer
 	err := initializeShTemplate.Execute(&initializeSh, initializeTemplateInput)
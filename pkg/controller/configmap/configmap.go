@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
+	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/configschema"
 	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/utils"
 	"github.com/aerospike/aerospike-management-lib/asconfig"
 	log "github.com/inconshreveable/log15"
@@ -76,6 +77,9 @@ func BuildConfigTemplate(aeroCluster *aerospikev1alpha1.AerospikeCluster, rack a
 
 	pkglog.Debug("AerospikeConfig", log.Ctx{"config": config, "image": aeroCluster.Spec.Image})
 
+	configschema.RLock()
+	defer configschema.RUnlock()
+
 	asConf, err := asconfig.NewMapAsConfig(version[1], config)
 	if err != nil {
 		return "", fmt.Errorf("Failed to load config map by lib: %v", err)
@@ -0,0 +1,262 @@
+package configschema
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aerospike/aerospike-management-lib/asconfig"
+	log "github.com/inconshreveable/log15"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+var pkglog = log.New(log.Ctx{"module": "configschema"})
+
+// schemaVersionRe matches schema versions of the form 5.6.0.
+var schemaVersionRe = regexp.MustCompile(`^\d+\.\d+\.\d+$`)
+
+var (
+	// schemaLock guards the schemas registered with asconfig, which are replaced when reloaded.
+	schemaLock        sync.RWMutex
+	supportedVersions []string
+	schemas           map[string]string
+)
+
+// LoadSchemaDir loads the json schemas in dir. Schema files are named by the server version, e.g. 5_6_0.json or
+// 5.6.0.json. Hidden files, like the ones created by a mounted ConfigMap, and directories are skipped.
+func LoadSchemaDir(dir string) (map[string]string, error) {
+	fileInfo, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read config schema dir %s: %v", dir, err)
+	}
+
+	schemaMap := map[string]string{}
+	for _, file := range fileInfo {
+		if file.IsDir() || strings.HasPrefix(file.Name(), ".") {
+			continue
+		}
+
+		version := schemaVersion(file.Name())
+		if !schemaVersionRe.MatchString(version) {
+			return nil, fmt.Errorf("Config schema file %s is not named by a server version", file.Name())
+		}
+		if _, ok := schemaMap[version]; ok {
+			return nil, fmt.Errorf("Duplicate config schema for version %s in file %s", version, file.Name())
+		}
+
+		schema, err := ioutil.ReadFile(filepath.Join(dir, file.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read config schema file %s: %v", file.Name(), err)
+		}
+		if err := ValidateSchema(string(schema)); err != nil {
+			return nil, fmt.Errorf("Invalid config schema file %s: %v", file.Name(), err)
+		}
+		schemaMap[version] = string(schema)
+	}
+	return schemaMap, nil
+}
+
+// ValidateSchema checks that schema is a json schema for the aerospike config.
+func ValidateSchema(schema string) error {
+	var schemaObj map[string]interface{}
+	if err := json.Unmarshal([]byte(schema), &schemaObj); err != nil {
+		return fmt.Errorf("Failed to parse json: %v", err)
+	}
+
+	properties, ok := schemaObj["properties"].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("Schema does not have properties")
+	}
+	for _, section := range []string{"network", "namespaces"} {
+		if _, ok := properties[section]; !ok {
+			return fmt.Errorf("Schema does not have %s", section)
+		}
+	}
+
+	if _, err := gojsonschema.NewSchema(gojsonschema.NewStringLoader(schema)); err != nil {
+		return fmt.Errorf("Failed to compile schema: %v", err)
+	}
+	return nil
+}
+
+// GetSchemaMap returns the built in schemas with the schemas in dir added. Schemas in dir override the built in schema
+// for the same version.
+func GetSchemaMap(dir string) (map[string]string, error) {
+	schemaMap := map[string]string{}
+	for version, schema := range SchemaMap {
+		schemaMap[version] = schema
+	}
+
+	if dir == "" {
+		return schemaMap, nil
+	}
+
+	dirSchemaMap, err := LoadSchemaDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for version, schema := range dirSchemaMap {
+		schemaMap[version] = schema
+	}
+	return schemaMap, nil
+}
+
+// Init registers the built in schemas and the schemas in dir with asconfig.
+func Init(dir string) error {
+	schemaMap, err := GetSchemaMap(dir)
+	if err != nil {
+		return err
+	}
+	register(schemaMap)
+	return nil
+}
+
+// WatchSchemaDir reloads the schemas in dir every interval and registers them with asconfig if they have changed.
+// Invalid schemas are logged and the previously loaded schemas are retained. A mounted ConfigMap is updated in place
+// by the kubelet, hence polling instead of file notifications.
+func WatchSchemaDir(dir string, interval time.Duration, stop <-chan struct{}) {
+	logger := pkglog.New(log.Ctx{"dir": dir})
+
+	lastHash := ""
+	if schemaMap, err := GetSchemaMap(dir); err == nil {
+		lastHash = hashSchemaMap(schemaMap)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			schemaMap, err := GetSchemaMap(dir)
+			if err != nil {
+				logger.Error("Failed to reload config schemas. Retaining loaded schemas", log.Ctx{"err": err})
+				continue
+			}
+
+			if hash := hashSchemaMap(schemaMap); hash != lastHash {
+				logger.Info("Config schemas changed")
+				register(schemaMap)
+				lastHash = hash
+			}
+		}
+	}
+}
+
+// SupportedVersions returns the server versions with a registered config schema.
+func SupportedVersions() []string {
+	schemaLock.RLock()
+	defer schemaLock.RUnlock()
+
+	return append([]string{}, supportedVersions...)
+}
+
+// SchemaVersion returns the version of the registered schema used for the server version, the closest one at or below
+// it with the same major version, e.g. 6.0.0 for 6.3.0.1. Hold RLock while calling it and while using the schema version
+// with asconfig.
+func SchemaVersion(version string) (string, error) {
+	return closestVersion(supportedVersions, version)
+}
+
+// Schema returns the registered schema used for the server version. Hold RLock while calling it.
+func Schema(version string) (string, error) {
+	schemaVersion, err := SchemaVersion(version)
+	if err != nil {
+		return "", err
+	}
+	return schemas[schemaVersion], nil
+}
+
+// RLock locks the registered schemas for reading. Hold it while using asconfig since the schemas may be reloaded.
+func RLock() {
+	schemaLock.RLock()
+}
+
+// RUnlock undoes a single RLock call.
+func RUnlock() {
+	schemaLock.RUnlock()
+}
+
+func register(schemaMap map[string]string) {
+	versions := []string{}
+	for version := range schemaMap {
+		versions = append(versions, version)
+	}
+	sortVersions(versions)
+
+	schemaLock.Lock()
+	asconfig.InitFromMap(schemaMap)
+	supportedVersions = versions
+	schemas = schemaMap
+	schemaLock.Unlock()
+
+	pkglog.Info("Registered aerospike-server config schemas", log.Ctx{"versions": versions})
+}
+
+// schemaVersion returns the version from a schema file name, e.g. 5_6_0.json -> 5.6.0.
+func schemaVersion(fileName string) string {
+	name := strings.TrimSuffix(fileName, ".json")
+	return strings.Replace(name, "_", ".", -1)
+}
+
+// closestVersion returns the closest version at or below version in the sorted versions, within the same major
+// version. The config layout changes between major versions so an older major version schema is never used.
+func closestVersion(versions []string, version string) (string, error) {
+	closest := ""
+	for _, v := range versions {
+		val, err := asconfig.CompareVersions(v, version)
+		if err != nil {
+			return "", fmt.Errorf("Failed to compare version %s with %s: %v", version, v, err)
+		}
+		if val > 0 {
+			break
+		}
+		closest = v
+	}
+	if closest == "" {
+		return "", fmt.Errorf("No config schema for version %s", version)
+	}
+	if majorVersion(closest) != majorVersion(version) {
+		return "", fmt.Errorf("No config schema for major version %s of version %s, the latest schema below it is %s", majorVersion(version), version, closest)
+	}
+	return closest, nil
+}
+
+// majorVersion returns the major version of the version, e.g. 6 for 6.3.0.1.
+func majorVersion(version string) string {
+	return strings.SplitN(version, ".", 2)[0]
+}
+
+func sortVersions(versions []string) {
+	sort.Slice(versions, func(i, j int) bool {
+		val, err := asconfig.CompareVersions(versions[i], versions[j])
+		if err != nil {
+			return versions[i] < versions[j]
+		}
+		return val < 0
+	})
+}
+
+func hashSchemaMap(schemaMap map[string]string) string {
+	versions := []string{}
+	for version := range schemaMap {
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+
+	h := sha256.New()
+	for _, version := range versions {
+		h.Write([]byte(version))
+		h.Write([]byte(schemaMap[version]))
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
@@ -0,0 +1,173 @@
+package configschema
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeSchemaFiles(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "config-schemas")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestLoadSchemaDir(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		versions []string
+		valid    bool
+	}{
+		{
+			name:     "underscore and dotted names",
+			files:    map[string]string{"5_6_0.json": conf5_5_0, "5.7.0": conf5_5_0},
+			versions: []string{"5.6.0", "5.7.0"},
+			valid:    true,
+		},
+		{
+			name:     "hidden files skipped",
+			files:    map[string]string{"5_6_0.json": conf5_5_0, "..data": "not a schema"},
+			versions: []string{"5.6.0"},
+			valid:    true,
+		},
+		{
+			name:  "not named by version",
+			files: map[string]string{"latest.json": conf5_5_0},
+		},
+		{
+			name:  "duplicate version",
+			files: map[string]string{"5_6_0.json": conf5_5_0, "5.6.0.json": conf5_5_0},
+		},
+		{
+			name:  "invalid json",
+			files: map[string]string{"5_6_0.json": "{"},
+		},
+		{
+			name:  "not an aerospike config schema",
+			files: map[string]string{"5_6_0.json": `{"type": "object", "properties": {}}`},
+		},
+		{
+			name:  "invalid schema",
+			files: map[string]string{"5_6_0.json": `{"type": 1, "properties": {"network": {}, "namespaces": {}}}`},
+		},
+	}
+
+	for _, test := range tests {
+		dir := writeSchemaFiles(t, test.files)
+		schemaMap, err := LoadSchemaDir(dir)
+		os.RemoveAll(dir)
+
+		if !test.valid {
+			if err == nil {
+				t.Errorf("%s: expected error", test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", test.name, err)
+			continue
+		}
+
+		versions := []string{}
+		for version := range schemaMap {
+			versions = append(versions, version)
+		}
+		sortVersions(versions)
+		if !reflect.DeepEqual(versions, test.versions) {
+			t.Errorf("%s: loaded versions %v, want %v", test.name, versions, test.versions)
+		}
+	}
+}
+
+func TestInit(t *testing.T) {
+	dir := writeSchemaFiles(t, map[string]string{"7_10_0.json": conf7_0_0})
+	defer os.RemoveAll(dir)
+
+	if err := Init(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	versions := SupportedVersions()
+	if len(versions) != len(SchemaMap)+1 {
+		t.Errorf("got %d supported versions, want %d", len(versions), len(SchemaMap)+1)
+	}
+	if last := versions[len(versions)-1]; last != "7.10.0" {
+		t.Errorf("got last supported version %s, want 7.10.0", last)
+	}
+}
+
+func TestSchemaVersion(t *testing.T) {
+	if err := Init(""); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{version: "4.9.0.3", want: "4.9.0"},
+		{version: "5.5.0", want: "5.5.0"},
+		{version: "5.7.0.8", want: "5.5.0"},
+		{version: "6.3.0.1", want: "6.0.0"},
+		{version: "7.1.0.0", want: "7.0.0"},
+	}
+	for _, test := range tests {
+		RLock()
+		got, err := SchemaVersion(test.version)
+		RUnlock()
+		if err != nil {
+			t.Errorf("%s: %v", test.version, err)
+		} else if got != test.want {
+			t.Errorf("%s: got schema version %s, want %s", test.version, got, test.want)
+		}
+	}
+
+	RLock()
+	defer RUnlock()
+	for _, version := range []string{"3.15.0.1", "8.0.0.1"} {
+		if got, err := SchemaVersion(version); err == nil {
+			t.Errorf("%s: got schema version %s for major version without a schema", version, got)
+		}
+	}
+}
+
+func TestClosestVersion(t *testing.T) {
+	versions := []string{"4.9.0", "6.0.0", "6.1.0"}
+
+	tests := []struct {
+		version string
+		want    string
+		err     bool
+	}{
+		{version: "4.9.0.3", want: "4.9.0"},
+		{version: "6.0.0.0", want: "6.0.0"},
+		{version: "6.3.0.1", want: "6.1.0"},
+		{version: "4.8.0.1", err: true},
+		{version: "5.5.0.2", err: true},
+		{version: "7.0.0.0", err: true},
+	}
+	for _, test := range tests {
+		got, err := closestVersion(versions, test.version)
+		if test.err {
+			if err == nil {
+				t.Errorf("%s: got schema version %s, want error", test.version, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", test.version, err)
+		} else if got != test.want {
+			t.Errorf("%s: got schema version %s, want %s", test.version, got, test.want)
+		}
+	}
+}
@@ -21,4 +21,6 @@ var SchemaMap = map[string]string{
 	"5.3.0": conf5_3_0,
 	"5.4.0": conf5_4_0,
 	"5.5.0": conf5_5_0,
+	"6.0.0": conf6_0_0,
+	"7.0.0": conf7_0_0,
 }
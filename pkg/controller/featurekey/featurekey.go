@@ -0,0 +1,261 @@
+package featurekey
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
+	"github.com/aerospike/aerospike-kubernetes-operator/pkg/controller/utils"
+	"github.com/aerospike/aerospike-management-lib/asconfig"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Feature key settings.
+const (
+	keySerialNumber      = "serial-number"
+	keyValidUntilDate    = "valid-until-date"
+	keyValidUntilVersion = "valid-until-version"
+	keyClusterNodesLimit = "asdb-cluster-nodes-limit"
+
+	keyXdr               = "asdb-xdr"
+	keyStrongConsistency = "asdb-strong-consistency"
+	keyFlashIndex        = "asdb-flash-index"
+	keyPmem              = "asdb-pmem"
+	keyCompression       = "asdb-compression"
+	keyEncryptionAtRest  = "asdb-encryption-at-rest"
+	keyLdap              = "asdb-ldap"
+	keyRackAware         = "asdb-rack-aware"
+
+	// signatureStart starts the signature block that ends the feature key settings.
+	signatureStart = "-----"
+
+	validUntilDateLayout = "2006-01-02"
+)
+
+// FeatureKey is a parsed aerospike feature key file.
+type FeatureKey struct {
+	// SerialNumber of the feature key.
+	SerialNumber string
+	// ValidUntil is the expiry date of the feature key, nil if it does not expire.
+	ValidUntil *time.Time
+	// ValidUntilVersion is the last server version licensed by the feature key, empty if all versions are licensed.
+	ValidUntilVersion string
+
+	settings map[string]string
+}
+
+// Parse parses a feature key file. The file has a setting per line, the setting name followed by its value, and ends
+// with a signature block.
+func Parse(data []byte) (*FeatureKey, error) {
+	featureKey := &FeatureKey{settings: map[string]string{}}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, signatureStart) {
+			break
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// The setting name ends at the first whitespace, values may have spaces, e.g. account-name Acme Inc.
+		i := strings.IndexAny(line, " \t")
+		if i < 0 {
+			return nil, fmt.Errorf("Invalid feature key line %q", line)
+		}
+		featureKey.settings[line[:i]] = strings.TrimSpace(line[i+1:])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("Failed to read feature key: %v", err)
+	}
+	if len(featureKey.settings) == 0 {
+		return nil, fmt.Errorf("Feature key has no settings")
+	}
+
+	featureKey.SerialNumber = featureKey.settings[keySerialNumber]
+	featureKey.ValidUntilVersion = featureKey.settings[keyValidUntilVersion]
+
+	if date, ok := featureKey.settings[keyValidUntilDate]; ok {
+		validUntil, err := time.Parse(validUntilDateLayout, date)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid feature key %s %s: %v", keyValidUntilDate, date, err)
+		}
+		// The key is valid through the whole day.
+		validUntil = validUntil.Add(24*time.Hour - time.Second)
+		featureKey.ValidUntil = &validUntil
+	}
+
+	if _, err := featureKey.clusterNodesLimit(); err != nil {
+		return nil, err
+	}
+	return featureKey, nil
+}
+
+// SecretKey returns the key of the feature key file in the AerospikeConfigSecret. Returns false if the config does not
+// have a feature-key-file or the file is not in the secret mount path.
+func SecretKey(spec *aerospikev1alpha1.AerospikeClusterSpec) (string, bool) {
+	serviceConf, ok := spec.AerospikeConfig["service"].(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	path, ok := serviceConf["feature-key-file"].(string)
+	if !ok || path == "" || spec.AerospikeConfigSecret.SecretName == "" || spec.AerospikeConfigSecret.MountPath == "" {
+		return "", false
+	}
+	if filepath.Dir(filepath.Clean(path)) != filepath.Clean(spec.AerospikeConfigSecret.MountPath) {
+		return "", false
+	}
+	return filepath.Base(path), true
+}
+
+// Load reads and parses the feature key from the AerospikeConfigSecret of the cluster. Returns nil if the cluster does
+// not use a feature key from the secret.
+func Load(reader client.Reader, aeroCluster *aerospikev1alpha1.AerospikeCluster) (*FeatureKey, error) {
+	key, ok := SecretKey(&aeroCluster.Spec)
+	if !ok {
+		return nil, nil
+	}
+
+	secretName := aeroCluster.Spec.AerospikeConfigSecret.SecretName
+	secret := &corev1.Secret{}
+	if err := reader.Get(context.TODO(), types.NamespacedName{Name: secretName, Namespace: aeroCluster.Namespace}, secret); err != nil {
+		return nil, fmt.Errorf("Failed to get aerospikeConfigSecret %s: %v", secretName, err)
+	}
+
+	data, ok := secret.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("Feature key %s not found in aerospikeConfigSecret %s", key, secretName)
+	}
+
+	featureKey, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse feature key %s in aerospikeConfigSecret %s: %v", key, secretName, err)
+	}
+	return featureKey, nil
+}
+
+// IsLicensed returns true if the feature key licenses the feature. Features not listed in the feature key are treated
+// as licensed since older feature keys do not list all the features.
+func (f *FeatureKey) IsLicensed(key string) bool {
+	value, ok := f.settings[key]
+	return !ok || value == "true"
+}
+
+// IsExpired returns true if the feature key has expired at now.
+func (f *FeatureKey) IsExpired(now time.Time) bool {
+	return f.ValidUntil != nil && now.After(*f.ValidUntil)
+}
+
+// clusterNodesLimit returns the maximum cluster size licensed by the feature key, 0 if unlimited.
+func (f *FeatureKey) clusterNodesLimit() (int, error) {
+	value, ok := f.settings[keyClusterNodesLimit]
+	if !ok {
+		return 0, nil
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 0 {
+		return 0, fmt.Errorf("Invalid feature key %s %s", keyClusterNodesLimit, value)
+	}
+	return limit, nil
+}
+
+// Validate validates the cluster spec against the features licensed by the feature key. Pods fail to start with a
+// config using unlicensed features.
+func (f *FeatureKey) Validate(spec *aerospikev1alpha1.AerospikeClusterSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if f.IsExpired(time.Now()) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("aerospikeConfigSecret"), spec.AerospikeConfigSecret.SecretName, fmt.Sprintf("feature key %s expired on %s", f.SerialNumber, f.ValidUntil.Format(validUntilDateLayout))))
+	}
+
+	if f.ValidUntilVersion != "" {
+		if version, err := utils.GetImageVersion(spec.Image); err == nil {
+			// valid-until-version is major.minor and licenses all its patch versions.
+			if val, err := asconfig.CompareVersions(version, f.ValidUntilVersion+".999"); err == nil && val > 0 {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("image"), spec.Image, fmt.Sprintf("feature key %s is valid until version %s", f.SerialNumber, f.ValidUntilVersion)))
+			}
+		}
+	}
+
+	if limit, _ := f.clusterNodesLimit(); limit > 0 && int(spec.Size) > limit {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("size"), spec.Size, fmt.Sprintf("feature key %s licenses up to %d nodes", f.SerialNumber, limit)))
+	}
+
+	if len(spec.RackConfig.Namespaces) != 0 {
+		allErrs = append(allErrs, f.forbidUnlicensed(keyRackAware, fldPath.Child("rackConfig", "namespaces"))...)
+	}
+
+	allErrs = append(allErrs, f.validateConfig(spec.AerospikeConfig, fldPath.Child("aerospikeConfig"))...)
+
+	// Rack effective configs include the common config, hence only check the rack overrides.
+	racksPath := fldPath.Child("rackConfig", "racks")
+	for i, rack := range spec.RackConfig.Racks {
+		if rack.InputAerospikeConfig != nil {
+			allErrs = append(allErrs, f.validateConfig(utils.GetRackConfigOverride(rack), racksPath.Index(i).Child("aerospikeConfig"))...)
+		}
+	}
+	return allErrs
+}
+
+func (f *FeatureKey) validateConfig(config aerospikev1alpha1.Values, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if _, ok := config["xdr"]; ok {
+		allErrs = append(allErrs, f.forbidUnlicensed(keyXdr, fldPath.Child("xdr"))...)
+	}
+	if securityConf, ok := config["security"].(map[string]interface{}); ok {
+		if _, ok := securityConf["ldap"]; ok {
+			allErrs = append(allErrs, f.forbidUnlicensed(keyLdap, fldPath.Child("security", "ldap"))...)
+		}
+	}
+
+	nsConfList, _ := config["namespaces"].([]interface{})
+	for i, nsConfInterface := range nsConfList {
+		nsConf, ok := nsConfInterface.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		nsPath := fldPath.Child("namespaces").Index(i)
+
+		if sc, ok := nsConf["strong-consistency"].(bool); ok && sc {
+			allErrs = append(allErrs, f.forbidUnlicensed(keyStrongConsistency, nsPath.Child("strong-consistency"))...)
+		}
+		if indexType, ok := nsConf["index-type"].(map[string]interface{}); ok {
+			switch indexType["type"] {
+			case "flash":
+				allErrs = append(allErrs, f.forbidUnlicensed(keyFlashIndex, nsPath.Child("index-type"))...)
+			case "pmem":
+				allErrs = append(allErrs, f.forbidUnlicensed(keyPmem, nsPath.Child("index-type"))...)
+			}
+		}
+		if storage, ok := nsConf["storage-engine"].(map[string]interface{}); ok {
+			if storage["type"] == "pmem" {
+				allErrs = append(allErrs, f.forbidUnlicensed(keyPmem, nsPath.Child("storage-engine"))...)
+			}
+			if compression, ok := storage["compression"]; ok && compression != "none" {
+				allErrs = append(allErrs, f.forbidUnlicensed(keyCompression, nsPath.Child("storage-engine", "compression"))...)
+			}
+			if _, ok := storage["encryption-key-file"]; ok {
+				allErrs = append(allErrs, f.forbidUnlicensed(keyEncryptionAtRest, nsPath.Child("storage-engine", "encryption-key-file"))...)
+			}
+		}
+	}
+	return allErrs
+}
+
+func (f *FeatureKey) forbidUnlicensed(key string, fldPath *field.Path) field.ErrorList {
+	if f.IsLicensed(key) {
+		return nil
+	}
+	return field.ErrorList{field.Forbidden(fldPath, fmt.Sprintf("not licensed by feature key %s, %s is %s", f.SerialNumber, key, f.settings[key]))}
+}
@@ -0,0 +1,153 @@
+package featurekey
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const testFeatureKey = `# generated by aerospike
+feature-key-version              2
+serial-number                    123456789
+
+account-name                     Acme Inc
+account-ID                       test
+
+asdb-cluster-nodes-limit         3
+asdb-compression                 true
+asdb-flash-index                 false
+asdb-strong-consistency          false
+asdb-xdr                         false
+
+valid-until-date                 2099-01-31
+valid-until-version              6.0
+----- SIGNATURE ------------------------------------------------
+MEUCIQDbDk0bT8u+B4ZxKX0y1yUJ9ygU0nFt3/8Y
+----- END OF SIGNATURE -----------------------------------------
+`
+
+func TestParse(t *testing.T) {
+	featureKey, err := Parse([]byte(testFeatureKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if featureKey.SerialNumber != "123456789" {
+		t.Errorf("got serial number %s, want 123456789", featureKey.SerialNumber)
+	}
+	if want := time.Date(2099, 1, 31, 23, 59, 59, 0, time.UTC); featureKey.ValidUntil == nil || !featureKey.ValidUntil.Equal(want) {
+		t.Errorf("got valid until %v, want %v", featureKey.ValidUntil, want)
+	}
+	if accountName := featureKey.settings["account-name"]; accountName != "Acme Inc" {
+		t.Errorf("got account name %q, want Acme Inc", accountName)
+	}
+	if featureKey.ValidUntilVersion != "6.0" {
+		t.Errorf("got valid until version %s, want 6.0", featureKey.ValidUntilVersion)
+	}
+	if featureKey.IsLicensed(keyXdr) || !featureKey.IsLicensed(keyCompression) || !featureKey.IsLicensed(keyLdap) {
+		t.Errorf("unexpected licensed features %v", featureKey.settings)
+	}
+	if featureKey.IsExpired(time.Date(2099, 1, 31, 12, 0, 0, 0, time.UTC)) || !featureKey.IsExpired(time.Date(2099, 2, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("feature key should be valid through %v", featureKey.ValidUntil)
+	}
+
+	for _, invalid := range []string{"", "serial-number", "valid-until-date 31-01-2099", "asdb-cluster-nodes-limit many"} {
+		if _, err := Parse([]byte(invalid)); err == nil {
+			t.Errorf("Parse(%q) should fail", invalid)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	featureKey, err := Parse([]byte(testFeatureKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(spec *aerospikev1alpha1.AerospikeClusterSpec)
+		want   []string
+	}{
+		{name: "licensed", mutate: func(spec *aerospikev1alpha1.AerospikeClusterSpec) {}, want: []string{}},
+		{
+			name:   "size",
+			mutate: func(spec *aerospikev1alpha1.AerospikeClusterSpec) { spec.Size = 4 },
+			want:   []string{"spec.size"},
+		},
+		{
+			name: "version",
+			mutate: func(spec *aerospikev1alpha1.AerospikeClusterSpec) {
+				spec.Image = "aerospike/aerospike-server-enterprise:6.1.0.1"
+			},
+			want: []string{"spec.image"},
+		},
+		{
+			name: "xdr and namespace features",
+			mutate: func(spec *aerospikev1alpha1.AerospikeClusterSpec) {
+				spec.AerospikeConfig["xdr"] = map[string]interface{}{}
+				nsConf := spec.AerospikeConfig["namespaces"].([]interface{})[0].(map[string]interface{})
+				nsConf["strong-consistency"] = true
+				nsConf["index-type"] = map[string]interface{}{"type": "flash"}
+			},
+			want: []string{"spec.aerospikeConfig.xdr", "spec.aerospikeConfig.namespaces[0].strong-consistency", "spec.aerospikeConfig.namespaces[0].index-type"},
+		},
+		{
+			name: "rack config",
+			mutate: func(spec *aerospikev1alpha1.AerospikeClusterSpec) {
+				rackConfig := aerospikev1alpha1.Values{"xdr": map[string]interface{}{}}
+				spec.RackConfig.Racks = []aerospikev1alpha1.Rack{{ID: 1, InputAerospikeConfig: &rackConfig}}
+			},
+			want: []string{"spec.rackConfig.racks[0].aerospikeConfig.xdr"},
+		},
+		{
+			name: "rack config delete directive",
+			mutate: func(spec *aerospikev1alpha1.AerospikeClusterSpec) {
+				rackConfig := aerospikev1alpha1.Values{"xdr": map[string]interface{}{"$patch": "delete"}}
+				spec.RackConfig.Racks = []aerospikev1alpha1.Rack{{ID: 1, InputAerospikeConfig: &rackConfig}}
+			},
+			want: []string{},
+		},
+	}
+
+	for _, test := range tests {
+		spec := &aerospikev1alpha1.AerospikeClusterSpec{
+			Size:  3,
+			Image: "aerospike/aerospike-server-enterprise:6.0.0.1",
+			AerospikeConfig: aerospikev1alpha1.Values{
+				"namespaces": []interface{}{
+					map[string]interface{}{"name": "test", "storage-engine": map[string]interface{}{"type": "device", "compression": "lz4"}},
+				},
+			},
+		}
+		test.mutate(spec)
+
+		got := []string{}
+		for _, err := range featureKey.Validate(spec, field.NewPath("spec")) {
+			got = append(got, err.Field)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got error fields %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestSecretKey(t *testing.T) {
+	spec := &aerospikev1alpha1.AerospikeClusterSpec{
+		AerospikeConfig: aerospikev1alpha1.Values{
+			"service": map[string]interface{}{"feature-key-file": "/etc/aerospike/secret/features.conf"},
+		},
+		AerospikeConfigSecret: aerospikev1alpha1.AerospikeConfigSecretSpec{SecretName: "secret", MountPath: "/etc/aerospike/secret/"},
+	}
+	if key, ok := SecretKey(spec); !ok || key != "features.conf" {
+		t.Errorf("got secret key %s %v, want features.conf", key, ok)
+	}
+
+	spec.AerospikeConfigSecret.MountPath = "/etc/aerospike/other"
+	if _, ok := SecretKey(spec); ok {
+		t.Errorf("feature key file outside the secret mount path should not have a secret key")
+	}
+}
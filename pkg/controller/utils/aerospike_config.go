@@ -29,8 +29,60 @@ const (
 
 	// Defaults.
 	defaultWorkDirectory = "/opt/aerospike"
+
+	// PatchDirectiveKey is the map key marking a patch directive in a rack aerospikeConfig.
+	PatchDirectiveKey = "$patch"
+	// PatchDirectiveDelete deletes the key or the named list entry from the common aerospikeConfig.
+	PatchDirectiveDelete = "delete"
 )
 
+// IsDeleteDirective returns true if the rack aerospikeConfig value deletes the common aerospikeConfig value, i.e. it is
+// null or a map with `$patch: delete`.
+func IsDeleteDirective(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	valueMap, ok := value.(map[string]interface{})
+	return ok && valueMap[PatchDirectiveKey] == PatchDirectiveDelete
+}
+
+// StripDirectives returns a copy of the value without patch directives.
+func StripDirectives(value interface{}) interface{} {
+	switch vt := value.(type) {
+	case map[string]interface{}:
+		res := map[string]interface{}{}
+		for k, v := range vt {
+			if k == PatchDirectiveKey || IsDeleteDirective(v) {
+				continue
+			}
+			res[k] = StripDirectives(v)
+		}
+		return res
+
+	case []interface{}:
+		var res []interface{}
+		for _, v := range vt {
+			if IsDeleteDirective(v) {
+				continue
+			}
+			res = append(res, StripDirectives(v))
+		}
+		return res
+
+	default:
+		return value
+	}
+}
+
+// GetRackConfigOverride returns the rack aerospikeConfig without patch directives, i.e. the config the rack adds or
+// replaces. Returns nil if the rack does not override the common aerospikeConfig.
+func GetRackConfigOverride(rack aerospikev1alpha1.Rack) aerospikev1alpha1.Values {
+	if rack.InputAerospikeConfig == nil {
+		return nil
+	}
+	return StripDirectives(map[string]interface{}(*rack.InputAerospikeConfig)).(map[string]interface{})
+}
+
 // IsTLS tells if cluster is tls enabled
 func IsTLS(aerospikeConfig aerospikev1alpha1.Values) bool {
 	if confInterface, ok := aerospikeConfig[confKeyNetwork]; ok {
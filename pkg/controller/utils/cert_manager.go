@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"fmt"
+	"path/filepath"
+
+	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
+)
+
+const (
+	// CertManagerCertsPath is the directory the cert-manager certificates are mounted in, a sub directory per TLS name.
+	CertManagerCertsPath = "/etc/aerospike/certs"
+
+	// Files of a cert-manager certificate secret.
+	CertManagerCertFile = "tls.crt"
+	CertManagerKeyFile  = "tls.key"
+	CertManagerCAFile   = "ca.crt"
+
+	// CertManagerGroup is the API group of cert-manager.
+	CertManagerGroup = "cert-manager.io"
+)
+
+// GetTLSNames returns the names of the network.tls entries.
+func GetTLSNames(aerospikeConfig aerospikev1alpha1.Values) []string {
+	networkConf, _ := aerospikeConfig[confKeyNetwork].(map[string]interface{})
+	tlsConfList, _ := networkConf[confKeyTLS].([]interface{})
+
+	var names []string
+	for _, tlsConfInt := range tlsConfList {
+		tlsConf, _ := tlsConfInt.(map[string]interface{})
+		if name, ok := tlsConf["name"].(string); ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// CertManagerTLSPath returns the path of a file of the cert-manager certificate of the TLS name.
+func CertManagerTLSPath(tlsName, file string) string {
+	return filepath.Join(CertManagerCertsPath, tlsName, file)
+}
+
+// CertManagerSecretName returns the name of the cert-manager certificate secret of the TLS name.
+func CertManagerSecretName(aeroCluster *aerospikev1alpha1.AerospikeCluster, tlsName string) string {
+	return fmt.Sprintf("%s-%s-tls", aeroCluster.Name, tlsName)
+}
+
+// CertManagerClientSecretName returns the name of the cert-manager secret of the operator client certificate.
+func CertManagerClientSecretName(aeroCluster *aerospikev1alpha1.AerospikeCluster) string {
+	return fmt.Sprintf("%s-operator-client-tls", aeroCluster.Name)
+}
+
+// IsCertManagerSecret returns true if the secret is a cert-manager certificate secret of the cluster.
+func IsCertManagerSecret(aeroCluster *aerospikev1alpha1.AerospikeCluster, secretName string) bool {
+	if aeroCluster.Spec.CertManager == nil {
+		return false
+	}
+	if secretName == CertManagerClientSecretName(aeroCluster) {
+		return true
+	}
+	for _, tlsName := range GetTLSNames(aeroCluster.Spec.AerospikeConfig) {
+		if secretName == CertManagerSecretName(aeroCluster, tlsName) {
+			return true
+		}
+	}
+	return false
+}
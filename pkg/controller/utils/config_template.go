@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Annotations set on clusters using config templates.
+const (
+	// ConfigTemplateAppliedAnnotation has the merged config templates last applied to the cluster spec.
+	ConfigTemplateAppliedAnnotation = "aerospike.com/config-template-applied"
+	// ConfigTemplateVersionAnnotation has the resource versions of the config templates last applied to the cluster spec.
+	ConfigTemplateVersionAnnotation = "aerospike.com/config-template-version"
+)
+
+// GetConfigTemplates returns the config templates referenced by the cluster, in the order they are referenced.
+func GetConfigTemplates(reader client.Reader, aeroCluster *aerospikev1alpha1.AerospikeCluster) ([]aerospikev1alpha1.AerospikeConfigTemplate, error) {
+	var templates []aerospikev1alpha1.AerospikeConfigTemplate
+	for _, name := range aeroCluster.Spec.ConfigTemplates {
+		template := aerospikev1alpha1.AerospikeConfigTemplate{}
+		if err := reader.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: aeroCluster.Namespace}, &template); err != nil {
+			return nil, fmt.Errorf("Failed to get AerospikeConfigTemplate %s: %v", name, err)
+		}
+		templates = append(templates, template)
+	}
+	return templates, nil
+}
+
+// GetConfigTemplateVersion returns the version of the config templates, their names and resource versions.
+func GetConfigTemplateVersion(templates []aerospikev1alpha1.AerospikeConfigTemplate) string {
+	var versions []string
+	for _, template := range templates {
+		versions = append(versions, template.Name+"="+template.ResourceVersion)
+	}
+	return strings.Join(versions, ",")
+}
+
+// IsConfigTemplateReferenced returns true if the cluster references the config template.
+func IsConfigTemplateReferenced(aeroCluster *aerospikev1alpha1.AerospikeCluster, templateName string) bool {
+	return ContainsString(aeroCluster.Spec.ConfigTemplates, templateName)
+}
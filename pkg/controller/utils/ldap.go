@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"path/filepath"
+
+	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
+)
+
+const (
+	// LDAPQueryUserPath is the directory the LDAP query user secret is mounted in.
+	LDAPQueryUserPath = "/etc/aerospike/ldap/query-user"
+
+	// LDAPTLSCAPath is the directory the LDAP CA certificate secret is mounted in.
+	LDAPTLSCAPath = "/etc/aerospike/ldap/tls-ca"
+
+	// DefaultLDAPTLSCASecretKey is the default key of the CA certificate in the LDAP CA certificate secret.
+	DefaultLDAPTLSCASecretKey = "ca.crt"
+)
+
+// GetLDAPSpec returns the LDAP spec of the cluster spec, nil if LDAP is not configured.
+func GetLDAPSpec(spec *aerospikev1alpha1.AerospikeClusterSpec) *aerospikev1alpha1.AerospikeLDAPSpec {
+	if spec.AerospikeAccessControl == nil {
+		return nil
+	}
+	return spec.AerospikeAccessControl.LDAP
+}
+
+// GetLDAPQueryUserSecretKey returns the key of the password in the LDAP query user secret.
+func GetLDAPQueryUserSecretKey(ldap *aerospikev1alpha1.AerospikeLDAPSpec) string {
+	if ldap.QueryUserSecretKey == "" {
+		return DefaultUserSecretKey
+	}
+	return ldap.QueryUserSecretKey
+}
+
+// GetLDAPTLSCASecretKey returns the key of the CA certificate in the LDAP CA certificate secret.
+func GetLDAPTLSCASecretKey(ldap *aerospikev1alpha1.AerospikeLDAPSpec) string {
+	if ldap.TLSCASecretKey == "" {
+		return DefaultLDAPTLSCASecretKey
+	}
+	return ldap.TLSCASecretKey
+}
+
+// LDAPQueryUserPasswordFile returns the path of the mounted password file of the LDAP query user.
+func LDAPQueryUserPasswordFile(ldap *aerospikev1alpha1.AerospikeLDAPSpec) string {
+	return filepath.Join(LDAPQueryUserPath, GetLDAPQueryUserSecretKey(ldap))
+}
+
+// LDAPTLSCAFile returns the path of the mounted CA certificate file of the LDAP servers.
+func LDAPTLSCAFile(ldap *aerospikev1alpha1.AerospikeLDAPSpec) string {
+	return filepath.Join(LDAPTLSCAPath, GetLDAPTLSCASecretKey(ldap))
+}
@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// The password sources reading from the operator pod are restricted by the operator deployment, not by the cluster
+// spec: files are only read under the password file directory, and HTTP requests are only sent to the password hosts.
+// Both sources are disabled when not configured.
+
+var (
+	// passwordFileDirs are the directory the password and bearer token files are read from, as given and resolved.
+	passwordFileDirs []string
+
+	// passwordHTTPHosts are the hosts, with optional port, the password HTTP requests are sent to.
+	passwordHTTPHosts map[string]bool
+)
+
+// InitPasswordSources sets the password file directory and the comma separated password HTTP hosts.
+func InitPasswordSources(fileDir string, httpHosts string) error {
+	passwordFileDirs = nil
+	if fileDir != "" {
+		if !filepath.IsAbs(fileDir) {
+			return fmt.Errorf("Password file directory %s is not an absolute path", fileDir)
+		}
+		passwordFileDirs = []string{filepath.Clean(fileDir)}
+		// The resolved paths of the files are checked as well.
+		if realDir, err := filepath.EvalSymlinks(fileDir); err == nil && realDir != passwordFileDirs[0] {
+			passwordFileDirs = append(passwordFileDirs, realDir)
+		}
+	}
+
+	passwordHTTPHosts = map[string]bool{}
+	for _, host := range strings.Split(httpHosts, ",") {
+		if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+			passwordHTTPHosts[host] = true
+		}
+	}
+	return nil
+}
+
+// IsPasswordFileAllowed indicates if the path is under the password file directory.
+func IsPasswordFileAllowed(path string) bool {
+	if !filepath.IsAbs(path) {
+		return false
+	}
+	for _, dir := range passwordFileDirs {
+		rel, err := filepath.Rel(dir, filepath.Clean(path))
+		if err == nil && rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPasswordHostAllowed indicates if the host, with optional port, is a password HTTP host.
+func IsPasswordHostAllowed(host string) bool {
+	return passwordHTTPHosts[strings.ToLower(host)]
+}
@@ -45,6 +45,23 @@ const (
 	InfoPort     = 3003
 	InfoPortName = "info"
 
+	// DefaultUserSecretKey is the default key of the user password in the user secret.
+	DefaultUserSecretKey = "password"
+
+	// AdminSecretPendingPasswordKey is the key of the admin password being set on the cluster in the admin secret.
+	AdminSecretPendingPasswordKey = "pending-password"
+
+	// AdminSecretSecurityMetadataKey is the key of the security metadata seeding new nodes with the admin bootstrap
+	// password in the admin secret.
+	AdminSecretSecurityMetadataKey = "security.smd"
+
+	// AdminSecurityMetadataPath is the directory of the security metadata seed in the init container.
+	AdminSecurityMetadataPath = "/admin-security-metadata"
+
+	// PasswordAllowedNamespacesAnnotation lists the namespaces of the clusters allowed to use a secret in another
+	// namespace as a password source, separated by commas.
+	PasswordAllowedNamespacesAnnotation = "aerospike.com/allowed-namespaces"
+
 	// ReasonImagePullBackOff when pod status is Pending as container image pull failed.
 	ReasonImagePullBackOff = "ImagePullBackOff"
 	// ReasonImageInspectError is error inspecting image.
@@ -57,6 +74,14 @@ const (
 	aerospikeConfConfigMapPrefix = "aerospike-conf"
 )
 
+// Node labels used for rack affinity.
+const (
+	ZoneLabel     = "failure-domain.beta.kubernetes.io/zone"
+	RegionLabel   = "failure-domain.beta.kubernetes.io/region"
+	RackLabel     = "aerospike.com/rack-label"
+	HostnameLabel = "kubernetes.io/hostname"
+)
+
 const (
 	AerospikeServerContainerName     string = "aerospike-server"
 	AerospikeServerInitContainerName string = "aerospike-init"
@@ -72,6 +97,47 @@ func ConfigMapName(aeroCluster *aerospikev1alpha1.AerospikeCluster) string {
 	return fmt.Sprintf("%s-%s", aerospikeConfConfigMapPrefix, aeroCluster.Name)
 }
 
+// AdminSecretName returns the name of the secret with the admin password the operator has set on the cluster.
+func AdminSecretName(aeroCluster *aerospikev1alpha1.AerospikeCluster) string {
+	return fmt.Sprintf("%s-admin-credentials", aeroCluster.Name)
+}
+
+// GetUserSecretKey returns the key of the user password in the user secret.
+func GetUserSecretKey(userSpec *aerospikev1alpha1.AerospikeUserSpec) string {
+	if userSpec.SecretKey == "" {
+		return DefaultUserSecretKey
+	}
+	return userSpec.SecretKey
+}
+
+// GetPasswordSecretSourceKey returns the key of the password in a password source secret.
+func GetPasswordSecretSourceKey(source *aerospikev1alpha1.AerospikePasswordSecretSource) string {
+	if source.Key == "" {
+		return DefaultUserSecretKey
+	}
+	return source.Key
+}
+
+// IsUserSecret returns true if the secret is the password secret of a user in the spec or the status of the cluster,
+// in the cluster namespace or a password source secret in another namespace.
+func IsUserSecret(aeroCluster *aerospikev1alpha1.AerospikeCluster, secretNamespace, secretName string) bool {
+	for _, accessControl := range []*aerospikev1alpha1.AerospikeAccessControlSpec{aeroCluster.Spec.AerospikeAccessControl, aeroCluster.Status.AerospikeAccessControl} {
+		if accessControl == nil {
+			continue
+		}
+		for _, user := range accessControl.Users {
+			if user.SecretName == secretName && aeroCluster.Namespace == secretNamespace {
+				return true
+			}
+			if user.PasswordSource != nil && user.PasswordSource.Secret != nil &&
+				user.PasswordSource.Secret.Name == secretName && user.PasswordSource.Secret.Namespace == secretNamespace {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // NamespacedName return namespaced name
 func NamespacedName(namespace, name string) string {
 	return fmt.Sprintf("%s/%s", namespace, name)
@@ -145,6 +211,18 @@ func CheckPodImageFailed(pod *v1.Pod) error {
 	return nil
 }
 
+// GetPodUnschedulableCondition returns the PodScheduled condition of a pod the scheduler could not place, nil if the pod
+// is scheduled or not yet considered by the scheduler.
+func GetPodUnschedulableCondition(pod *v1.Pod) *v1.PodCondition {
+	for i := range pod.Status.Conditions {
+		cond := &pod.Status.Conditions[i]
+		if cond.Type == v1.PodScheduled && cond.Status == v1.ConditionFalse && cond.Reason == v1.PodReasonUnschedulable {
+			return cond
+		}
+	}
+	return nil
+}
+
 // IsCrashed returns true if pod is running and the aerospike container has crashed.
 func IsCrashed(pod *v1.Pod) bool {
 	if pod.Status.Phase != v1.PodRunning {
@@ -167,6 +245,11 @@ func isPodReady(pod *v1.Pod) bool {
 	return true
 }
 
+// IsEnterprise indicates if aerospike image is enterprise
+func IsEnterprise(image string) bool {
+	return strings.Contains(strings.ToLower(image), "enterprise")
+}
+
 // IsImageEqual returns true if image name image1 is equal to image name image2.
 func IsImageEqual(image1 string, image2 string) bool {
 	desiredImageWithVersion := strings.TrimPrefix(image1, DockerHubImagePrefix)
@@ -336,6 +419,24 @@ func GetPod(podName string, pods []corev1.Pod) *corev1.Pod {
 	return nil
 }
 
+// SplitRacks distributes nodes across racks. The first nodes%racks racks get an extra node.
+func SplitRacks(nodes, racks int) []int {
+	nodesPerRack, extraNodes := nodes/racks, nodes%racks
+
+	// Distributing nodes in given racks
+	var topology []int
+
+	for rackIdx := 0; rackIdx < racks; rackIdx++ {
+		nodesForThisRack := nodesPerRack
+		if rackIdx < extraNodes {
+			nodesForThisRack++
+		}
+		topology = append(topology, nodesForThisRack)
+	}
+
+	return topology
+}
+
 // LabelsForAerospikeCluster returns the labels for selecting the resources
 // belonging to the given AerospikeCluster CR name.
 func LabelsForAerospikeCluster(clName string) map[string]string {
@@ -1061,7 +1061,7 @@ func TestNoSecurityIntegration(t *testing.T) {
 		}
 	})
 
-	t.Run("SecurityUpdateReject", func(t *testing.T) {
+	t.Run("SecurityEnable", func(t *testing.T) {
 		accessControl := aerospikev1alpha1.AerospikeAccessControlSpec{
 			Roles: []aerospikev1alpha1.AerospikeRoleSpec{
 				aerospikev1alpha1.AerospikeRoleSpec{
@@ -1097,9 +1097,10 @@ func TestNoSecurityIntegration(t *testing.T) {
 			},
 		}
 
+		// Security is enabled with a rolling restart.
 		aeroCluster = getAerospikeClusterSpecWithAccessControl(&accessControl, true, ctx)
 		err := testAccessControlReconcile(aeroCluster, ctx, t)
-		if err == nil || !strings.Contains(err.Error(), "Cannot update cluster security config") {
+		if err != nil {
 			t.Error(err)
 		}
 	})
@@ -1288,10 +1289,11 @@ func TestAccessControlIntegration(t *testing.T) {
 		}
 	})
 
-	t.Run("SecurityUpdateReject", func(t *testing.T) {
+	t.Run("SecurityDisable", func(t *testing.T) {
+		// Security is disabled with a rolling restart.
 		aeroCluster := getAerospikeClusterSpecWithAccessControl(nil, false, ctx)
-		err := testAccessControlReconcile(aeroCluster, ctx, t)
-		if err == nil || !strings.Contains(err.Error(), "Cannot update cluster security config") {
+		err := aerospikeClusterCreateUpdate(aeroCluster, ctx, t)
+		if err != nil {
 			t.Error(err)
 		}
 	})
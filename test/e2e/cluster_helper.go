@@ -24,6 +24,7 @@ import (
 const (
 	latestClusterImage = "aerospike/aerospike-server-enterprise:5.4.0.5"
 	imageToUpgrade     = "aerospike/aerospike-server-enterprise:5.5.0.3"
+	communityImage     = "aerospike/aerospike-server:5.4.0.5"
 )
 
 var (
@@ -578,7 +579,7 @@ func createHDDAndDataInMemStorageCluster(clusterNamespacedName types.NamespacedN
 			"storage-engine": map[string]interface{}{
 				"type":           "device",
 				"files":          []interface{}{"/opt/aerospike/data/test.dat"},
-				"filesize":       2000955200,
+				"filesize":       1000955200,
 				"data-in-memory": true,
 			},
 		},
@@ -619,7 +620,7 @@ func createHDDAndDataInIndexStorageCluster(clusterNamespacedName types.Namespace
 			"storage-engine": map[string]interface{}{
 				"type":           "device",
 				"files":          []interface{}{"/opt/aerospike/data/test.dat"},
-				"filesize":       2000955200,
+				"filesize":       1000955200,
 				"data-in-memory": true,
 			},
 		},
@@ -15,6 +15,7 @@ import (
 	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
 	framework "github.com/operator-framework/operator-sdk/pkg/test"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 // Test cluster cr updation
@@ -73,6 +74,54 @@ func ClusterStorageCleanUpTest(t *testing.T, f *framework.Framework, ctx *framew
 			}
 		})
 
+		t.Run("RetainAndReattach", func(t *testing.T) {
+			aeroCluster := getCluster(t, f, ctx, clusterNamespacedName)
+
+			// Last rack removed with default policy should have its pvc retained
+			removedRackID := 4
+			stsName := aeroCluster.Name + "-" + strconv.Itoa(removedRackID)
+
+			pvcList, err := getAeroClusterPVCList(aeroCluster, client)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, pvc := range pvcList {
+				if !strings.Contains(pvc.Name, stsName) {
+					continue
+				}
+				if _, ok := pvc.Labels["aerospike.com/pvc-retained"]; !ok {
+					t.Fatalf("PVC %s of removed rack not labelled as retained", pvc.Name)
+				}
+				if _, ok := aeroCluster.Status.RetainedPVCs[pvc.Name]; !ok {
+					t.Fatalf("PVC %s of removed rack not found in status retainedPVCs", pvc.Name)
+				}
+			}
+
+			// Adding the rack back should reattach the retained pvc
+			aeroCluster.Spec.RackConfig.Racks = append(aeroCluster.Spec.RackConfig.Racks, getDummyRackConf(removedRackID)...)
+			aeroCluster.Spec.Size = aeroCluster.Spec.Size + 1
+			if err := updateAndWait(t, f, ctx, aeroCluster); err != nil {
+				t.Fatal(err)
+			}
+
+			aeroCluster = getCluster(t, f, ctx, clusterNamespacedName)
+			newPVCList, err := getAeroClusterPVCList(aeroCluster, client)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := matchPVCList(pvcList, newPVCList); err != nil {
+				t.Fatal(err)
+			}
+			for _, pvc := range newPVCList {
+				if _, ok := pvc.Labels["aerospike.com/pvc-retained"]; ok {
+					t.Fatalf("PVC %s still labelled as retained after reattach", pvc.Name)
+				}
+			}
+			if len(aeroCluster.Status.RetainedPVCs) != 0 {
+				t.Fatalf("Status retainedPVCs not cleaned up after reattach: %v", aeroCluster.Status.RetainedPVCs)
+			}
+		})
+
 		t.Run("CleanupAllVolumes", func(t *testing.T) {
 
 			// Set common FileSystemVolumePolicy, BlockVolumePolicy to true
@@ -217,6 +266,9 @@ func RackUsingLocalStorageTest(t *testing.T, f *framework.Framework, ctx *framew
 			},
 		}
 		remove := true
+		fsSize := resource.MustParse("1500Mi")
+		pvcLabels := map[string]string{"aerospike.com/test-volume": "workdir"}
+		pvcAnnotations := map[string]string{"aerospike.com/test-annotation": "workdir"}
 		// Rack is completely replaced
 		racks[0].InputStorage = &aerospikev1alpha1.AerospikeStorageSpec{
 			BlockVolumePolicy: aerospikev1alpha1.AerospikePersistentVolumePolicySpec{
@@ -235,9 +287,12 @@ func RackUsingLocalStorageTest(t *testing.T, f *framework.Framework, ctx *framew
 				},
 				{
 					Path:         "/opt/aerospike",
-					SizeInGB:     1,
+					Size:         &fsSize,
 					StorageClass: "ssd",
 					VolumeMode:   aerospikev1alpha1.AerospikeVolumeModeFilesystem,
+					AccessModes:  []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+					Labels:       pvcLabels,
+					Annotations:  pvcAnnotations,
 				},
 			},
 		}
@@ -275,6 +330,42 @@ func RackUsingLocalStorageTest(t *testing.T, f *framework.Framework, ctx *framew
 			}
 		})
 
+		t.Run("UseForPVCSpec", func(t *testing.T) {
+			newPVCList, err := getAeroClusterPVCList(aeroCluster, client)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pvcName, err := getPVCName("/opt/aerospike")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var found bool
+			for _, pvc := range newPVCList {
+				if !strings.HasPrefix(pvc.Name, pvcName) {
+					continue
+				}
+				found = true
+
+				if size := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; size.Cmp(fsSize) != 0 {
+					t.Fatalf("PVC %s has size %s, expected %s", pvc.Name, size.String(), fsSize.String())
+				}
+				for key, value := range pvcLabels {
+					if pvc.Labels[key] != value {
+						t.Fatalf("PVC %s missing label %s=%s", pvc.Name, key, value)
+					}
+				}
+				for key, value := range pvcAnnotations {
+					if pvc.Annotations[key] != value {
+						t.Fatalf("PVC %s missing annotation %s=%s", pvc.Name, key, value)
+					}
+				}
+			}
+			if !found {
+				t.Fatalf("PVC with prefix %s not found in cluster pvcList %v", pvcName, newPVCList)
+			}
+		})
+
 		t.Run("UseForCascadeDelete", func(t *testing.T) {
 			// There is only single rack
 			podName := aeroCluster.Name + "-" + strconv.Itoa(racks[0].ID) + "-" + strconv.Itoa(int(aeroCluster.Spec.Size-1))
@@ -427,6 +427,14 @@ func UpdateClusterTest(t *testing.T, f *framework.Framework, ctx *framework.Test
 				validateError(t, err, "should fail for updating MultiPodPerHost. Cannot be updated")
 			})
 
+			t.Run("RestoreFromSnapshot", func(t *testing.T) {
+				aeroCluster := getCluster(t, f, ctx, clusterNamespacedName)
+				aeroCluster.Spec.RestoreFromSnapshot = "aerocluster-snapshot"
+
+				err := f.Client.Update(goctx.TODO(), aeroCluster)
+				validateError(t, err, "should fail for updating RestoreFromSnapshot. Cannot be updated")
+			})
+
 			t.Run("StorageValidation", func(t *testing.T) {
 				aeroCluster := getCluster(t, f, ctx, clusterNamespacedName)
 				new := []aerospikev1alpha1.AerospikePersistentVolumeSpec{
@@ -540,9 +548,10 @@ func negativeDeployClusterValidationTest(t *testing.T, f *framework.Framework, c
 			err := deployCluster(t, f, ctx, aeroCluster)
 			validateError(t, err, "should fail for zero size")
 
-			// aeroCluster = createDummyAerospikeCluster(clusterNamespacedName, 9)
-			// err = deployCluster(t, f, ctx, aeroCluster)
-			// validateError(t, err, "should fail for community eidition having more than 8 nodes")
+			aeroCluster = createDummyAerospikeCluster(clusterNamespacedName, 9)
+			aeroCluster.Spec.Image = communityImage
+			err = deployCluster(t, f, ctx, aeroCluster)
+			validateError(t, err, "should fail for community eidition having more than 8 nodes")
 		})
 		t.Run("InvalidAerospikeConfig", func(t *testing.T) {
 			aeroCluster := createDummyAerospikeCluster(clusterNamespacedName, 1)
@@ -638,6 +647,17 @@ func negativeDeployClusterValidationTest(t *testing.T, f *framework.Framework, c
 						}
 					})
 
+					t.Run("StorageEngineFileSizeExceedsVolume", func(t *testing.T) {
+						aeroCluster = createDummyAerospikeCluster(clusterNamespacedName, 1)
+						aeroCluster.Spec.AerospikeConfig["namespaces"].([]interface{})[0].(map[string]interface{})["storage-engine"] = map[string]interface{}{
+							"type":     "device",
+							"files":    []interface{}{"/opt/aerospike/test.dat"},
+							"filesize": "2G",
+						}
+						err = deployCluster(t, f, ctx, aeroCluster)
+						validateError(t, err, "should fail for storage-engine.filesize exceeding volume size")
+					})
+
 					t.Run("InvalidxdrConfig", func(t *testing.T) {
 						aeroCluster = createDummyAerospikeCluster(clusterNamespacedName, 1)
 						if _, ok := aeroCluster.Spec.AerospikeConfig["namespaces"].([]interface{})[0].(map[string]interface{})["storage-engine"].(map[string]interface{})["devices"]; ok {
@@ -651,6 +671,13 @@ func negativeDeployClusterValidationTest(t *testing.T, f *framework.Framework, c
 						}
 					})
 				})
+
+				t.Run("MemorySizeExceedsResources", func(t *testing.T) {
+					aeroCluster = createDummyAerospikeCluster(clusterNamespacedName, 1)
+					aeroCluster.Spec.AerospikeConfig["namespaces"].([]interface{})[0].(map[string]interface{})["memory-size"] = "3G"
+					err = deployCluster(t, f, ctx, aeroCluster)
+					validateError(t, err, "should fail for memory-size exceeding container memory")
+				})
 			})
 
 			t.Run("ChangeDefaultConfig", func(t *testing.T) {
@@ -775,9 +802,10 @@ func negativeUpdateClusterValidationTest(t *testing.T, f *framework.Framework, c
 			err := f.Client.Update(goctx.TODO(), aeroCluster)
 			validateError(t, err, "should fail for zero size")
 
-			// aeroCluster = createDummyAerospikeCluster(clusterNamespacedName, 9)
-			// err = deployCluster(t, f, ctx, aeroCluster)
-			// validateError(t, err, "should fail for community eidition having more than 8 nodes")
+			ceCluster := createDummyAerospikeCluster(clusterNamespacedName, 9)
+			ceCluster.Spec.Image = communityImage
+			err = deployCluster(t, f, ctx, ceCluster)
+			validateError(t, err, "should fail for community eidition having more than 8 nodes")
 		})
 		t.Run("InvalidAerospikeConfig", func(t *testing.T) {
 			aeroCluster = getCluster(t, f, ctx, clusterNamespacedName)
@@ -15,6 +15,8 @@ fi
 
 # Create the custom custom resource.
 kubectl -n test apply -f deploy/crds/aerospike.com_aerospikeclusters_crd.yaml
+kubectl -n test apply -f deploy/crds/aerospike.com_aerospikeclustersnapshots_crd.yaml
+kubectl -n test apply -f deploy/crds/aerospike.com_aerospikeconfigtemplates_crd.yaml
 
 # Create storage classes.
 kubectl -n test apply -f deploy/samples/storage/local_storage_class.yaml
@@ -52,7 +52,7 @@ var aerospikeConfigPre5 = map[string]interface{}{
 			"storage-engine": map[string]interface{}{
 				"type":     "device",
 				"files":    []interface{}{"/opt/aerospike/data/test.dat"},
-				"filesize": 2000955200,
+				"filesize": 1000955200,
 			},
 		},
 	},
@@ -86,7 +86,7 @@ var aerospikeConfigCrashingPre5 = map[string]interface{}{
 			"storage-engine": map[string]interface{}{
 				"type":     "device",
 				"files":    []interface{}{"/opt/aerospike/data/test.dat"},
-				"filesize": 2000955200,
+				"filesize": 1000955200,
 			},
 		},
 	},
@@ -121,7 +121,7 @@ var aerospikeConfigPost5 = map[string]interface{}{
 			"storage-engine": map[string]interface{}{
 				"type":     "device",
 				"files":    []interface{}{"/opt/aerospike/data/test.dat"},
-				"filesize": 2000955200,
+				"filesize": 1000955200,
 			},
 		},
 	},
@@ -367,7 +367,7 @@ func getAerospikeClusterSpecWithNetworkPolicy(networkPolicy aerospikev1alpha1.Ae
 						"storage-engine": map[string]interface{}{
 							"type":     "device",
 							"files":    []interface{}{"/opt/aerospike/data/test.dat"},
-							"filesize": 2000955200,
+							"filesize": 1000955200,
 						},
 					},
 				},
@@ -2,8 +2,10 @@ package e2e
 
 import (
 	goctx "context"
+	"reflect"
 	"testing"
 
+	aerospikev1alpha1 "github.com/aerospike/aerospike-kubernetes-operator/pkg/apis/aerospike/v1alpha1"
 	framework "github.com/operator-framework/operator-sdk/pkg/test"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -68,6 +70,48 @@ func PodSpecTest(t *testing.T, f *framework.Framework, ctx *framework.TestCtx) {
 				t.Fatal(err)
 			}
 		})
+		t.Run("AttachVolumesToSidecar", func(t *testing.T) {
+			aeroCluster = getCluster(t, f, ctx, clusterNamespacedName)
+
+			// Attach the filesystem volume and a new memory backed emptyDir volume to the sidecar.
+			aeroCluster.Spec.Storage.Volumes[1].Sidecars = []aerospikev1alpha1.AerospikeVolumeAttachment{
+				{
+					ContainerName: sidecar2.Name,
+					Path:          "/opt/sidecar",
+					ReadOnly:      true,
+				},
+			}
+			aeroCluster.Spec.Storage.Volumes = append(aeroCluster.Spec.Storage.Volumes, aerospikev1alpha1.AerospikePersistentVolumeSpec{
+				Path:       "/opt/aerospike/tmpfs",
+				VolumeMode: aerospikev1alpha1.AerospikeVolumeModeEmptyDir,
+				EmptyDir: &corev1.EmptyDirVolumeSource{
+					Medium: corev1.StorageMediumMemory,
+				},
+				Sidecars: []aerospikev1alpha1.AerospikeVolumeAttachment{
+					{
+						ContainerName: sidecar2.Name,
+					},
+				},
+			})
+
+			if err := updateAndWait(t, f, ctx, aeroCluster); err != nil {
+				t.Fatal(err)
+			}
+
+			validateSidecarMounts(t, f, aeroCluster, sidecar2.Name, map[string]bool{"/opt/sidecar": true, "/opt/aerospike/tmpfs": false})
+		})
+		t.Run("DetachVolumesFromSidecar", func(t *testing.T) {
+			aeroCluster = getCluster(t, f, ctx, clusterNamespacedName)
+
+			aeroCluster.Spec.Storage.Volumes[1].Sidecars = nil
+			aeroCluster.Spec.Storage.Volumes = aeroCluster.Spec.Storage.Volumes[:len(aeroCluster.Spec.Storage.Volumes)-1]
+
+			if err := updateAndWait(t, f, ctx, aeroCluster); err != nil {
+				t.Fatal(err)
+			}
+
+			validateSidecarMounts(t, f, aeroCluster, sidecar2.Name, map[string]bool{})
+		})
 		t.Run("RemoveContainer", func(t *testing.T) {
 			aeroCluster = getCluster(t, f, ctx, clusterNamespacedName)
 
@@ -88,7 +132,58 @@ func PodSpecTest(t *testing.T, f *framework.Framework, ctx *framework.TestCtx) {
 			err := f.Client.Update(goctx.TODO(), aeroCluster)
 			validateError(t, err, "should fail for adding container with same name")
 		})
+		t.Run("AttachVolumeToUnknownSidecar", func(t *testing.T) {
+			aeroCluster = getCluster(t, f, ctx, clusterNamespacedName)
+			aeroCluster.Spec.Storage.Volumes[1].Sidecars = []aerospikev1alpha1.AerospikeVolumeAttachment{
+				{
+					ContainerName: "unknown",
+				},
+			}
+
+			err := f.Client.Update(goctx.TODO(), aeroCluster)
+			validateError(t, err, "should fail for attaching volume to unknown sidecar")
+		})
+		t.Run("AttachBlockVolumeWithSubPath", func(t *testing.T) {
+			aeroCluster = getCluster(t, f, ctx, clusterNamespacedName)
+			aeroCluster.Spec.PodSpec.Sidecars = append(aeroCluster.Spec.PodSpec.Sidecars, sidecar1)
+			aeroCluster.Spec.Storage.Volumes[0].Sidecars = []aerospikev1alpha1.AerospikeVolumeAttachment{
+				{
+					ContainerName: sidecar1.Name,
+					SubPath:       "data",
+				},
+			}
+
+			err := f.Client.Update(goctx.TODO(), aeroCluster)
+			validateError(t, err, "should fail for block volume attached with subPath")
+		})
 	})
 
 	deleteCluster(t, f, ctx, aeroCluster)
 }
+
+// validateSidecarMounts validates the storage volumes mounted in the sidecar. mounts maps mount path to readOnly.
+func validateSidecarMounts(t *testing.T, f *framework.Framework, aeroCluster *aerospikev1alpha1.AerospikeCluster, containerName string, mounts map[string]bool) {
+	podList, err := getClusterPodList(f, aeroCluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, pod := range podList.Items {
+		found := map[string]bool{}
+		for _, container := range pod.Spec.Containers {
+			if container.Name != containerName {
+				continue
+			}
+
+			for _, mount := range container.VolumeMounts {
+				if _, ok := mounts[mount.MountPath]; ok {
+					found[mount.MountPath] = mount.ReadOnly
+				}
+			}
+		}
+
+		if !reflect.DeepEqual(found, mounts) {
+			t.Fatalf("Sidecar %s in pod %s has mounts %v, expected %v", containerName, pod.Name, found, mounts)
+		}
+	}
+}
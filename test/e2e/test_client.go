@@ -49,9 +49,9 @@ func (pp FromSecretPasswordProvider) Get(username string, userSpec *aerospikev1a
 		return "", fmt.Errorf("Failed to get secret %s: %v", secretName, err)
 	}
 
-	passbyte, ok := secret.Data["password"]
+	passbyte, ok := secret.Data[utils.GetUserSecretKey(userSpec)]
 	if !ok {
-		return "", fmt.Errorf("Failed to get password from secret. Please check your secret %s", secretName)
+		return "", fmt.Errorf("Failed to get password from secret key %s. Please check your secret %s", utils.GetUserSecretKey(userSpec), secretName)
 	}
 	return string(passbyte), nil
 }
@@ -62,7 +62,7 @@ func getPasswordProvider(aeroCluster *aerospikev1alpha1.AerospikeCluster, client
 
 func getClient(aeroCluster *aerospikev1alpha1.AerospikeCluster, client *kubeClient.Client) (*as.Client, error) {
 	pp := getPasswordProvider(aeroCluster, client)
-	username, password, err := accessControl.AerospikeAdminCredentials(&aeroCluster.Spec, &aeroCluster.Status.AerospikeClusterSpec, &pp)
+	username, password, err := accessControl.AerospikeAdminCredentials(&aeroCluster.Spec, &aeroCluster.Status.AerospikeClusterSpec, utils.AdminSecretName(aeroCluster), &pp)
 
 	if err != nil {
 		return nil, err
@@ -221,7 +221,7 @@ func getClientPolicy(aeroCluster *aerospikev1alpha1.AerospikeCluster, client *ku
 		policy.TlsConfig = &tlsConf
 	}
 
-	user, pass, err := accessControl.AerospikeAdminCredentials(&aeroCluster.Spec, &aeroCluster.Status.AerospikeClusterSpec, getPasswordProvider(aeroCluster, client))
+	user, pass, err := accessControl.AerospikeAdminCredentials(&aeroCluster.Spec, &aeroCluster.Status.AerospikeClusterSpec, utils.AdminSecretName(aeroCluster), getPasswordProvider(aeroCluster, client))
 	if err != nil {
 		logger.Error("Failed to get cluster auth info", log.Ctx{"err": err})
 	}